@@ -1,10 +1,45 @@
 package base
 
 import (
-	// "fmt"
+	"fmt"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
 )
 
+// CorePackages are the packages implemented by natives instead of CX code.
+// Each function declares the structs and functions of a package
+var CorePackages = map[string]func(*CXPackage){
+	"http": addHTTPDeclarations,
+}
+
+// AddCorePackage adds the core package `name` to the program, so it can be
+// imported without being declared by the program. The current package
+// doesn't change
+func (prgrm *CXProgram) AddCorePackage(name string) (*CXPackage, error) {
+	addDecls, ok := CorePackages[name]
+	if !ok {
+		return nil, fmt.Errorf("package '%s' not found", name)
+	}
+
+	current := prgrm.CurrentPackage
+	pkg := MakePackage(name)
+	prgrm.AddPackage(pkg)
+	addDecls(pkg)
+	prgrm.CurrentPackage = current
+
+	return pkg, nil
+}
+
+// addCoreFields adds fields to a core package's struct from pairs of names
+// and basic types, and sets the struct's size
+func addCoreFields(strct *CXStruct, flds ...interface{}) {
+	for c := 0; c+1 < len(flds); c += 2 {
+		fld := MakeCoreArgument(flds[c].(string), flds[c+1].(int))
+		fld.Package = strct.Package
+		strct.AddField(fld)
+		strct.Size += fld.TotalSize
+	}
+}
+
 func (prgrm *CXProgram) AddPackage(mod *CXPackage) *CXProgram {
 	mod.Program = prgrm
 	found := false
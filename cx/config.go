@@ -14,6 +14,7 @@ const SYS_INIT_FUNC = "*init"
 const MAIN_PKG = "main"
 const NON_ASSIGN_PREFIX = "nonAssign"
 const LOCAL_PREFIX = "*lcl"
+const VARIADIC_LEN_PREFIX = "*len"
 const CORE_MODULE = "core"
 const ID_FN = "identity"
 const INIT_FN = "initDef"
@@ -36,8 +37,10 @@ const MAX_INT32 = int(MAX_UINT32 >> 1)
 const MIN_INT32 = -MAX_INT32 - 1
 
 var BASIC_TYPES []string = []string{
-	"bool", "str", "byte", "i32", "i64", "f32", "f64",
-	"[]bool", "[]str", "[]byte", "[]i32", "[]i64", "[]f32", "[]f64",
+	"bool", "str", "byte", "i8", "i16", "i32", "i64",
+	"ui8", "ui16", "ui32", "ui64", "f32", "f64",
+	"[]bool", "[]str", "[]byte", "[]i8", "[]i16", "[]i32", "[]i64",
+	"[]ui8", "[]ui16", "[]ui32", "[]ui64", "[]f32", "[]f64",
 }
 var NATIVE_FUNCTIONS = map[string]bool{
 	"i32.add": true, "i32.mul": true, "i32.sub": true, "i32.div": true,
@@ -0,0 +1,181 @@
+package base
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+)
+
+// a line of CX source code, the unit of the coverage profiles. Expressions
+// don't record their columns, so every line is a block
+type coverLine struct {
+	FileName string
+	Line     int
+}
+
+// a function of the covered programs and the lines of its expressions
+type coverFunc struct {
+	Name     string
+	FileName string
+	FileLine int
+	Lines    map[coverLine]bool
+}
+
+// CoverageProfile accumulates the execution counts of the lines of CX
+// programs. As cx test runs every test on a fresh program, the counts of
+// several programs built from the same source code can be added
+type CoverageProfile struct {
+	counts map[coverLine]int
+	stmts  map[coverLine]int // number of expressions in each line
+	funcs  map[string]*coverFunc
+}
+
+// NewCoverageProfile returns an empty coverage profile
+func NewCoverageProfile() *CoverageProfile {
+	return &CoverageProfile{
+		counts: make(map[coverLine]int),
+		stmts:  make(map[coverLine]int),
+		funcs:  make(map[string]*coverFunc),
+	}
+}
+
+// EnableCoverage makes the program record how many times each of its
+// expressions runs
+func (prgrm *CXProgram) EnableCoverage() {
+	prgrm.Coverage = make(map[*CXExpression]int)
+}
+
+// Add adds the execution counts recorded by `prgrm` to the profile. Every
+// expression with a file and line is added, so the lines that never ran are
+// reported as not covered. The count of a line is the count of its most
+// executed expression
+func (prof *CoverageProfile) Add(prgrm *CXProgram) {
+	counts := make(map[coverLine]int)
+	stmts := make(map[coverLine]int)
+
+	for _, pkg := range prgrm.Packages {
+		for _, fn := range pkg.Functions {
+			if fn.IsNative {
+				continue
+			}
+
+			var cf *coverFunc
+			for _, expr := range fn.Expressions {
+				if expr.FileName == "" || expr.FileLine <= 0 {
+					continue
+				}
+
+				fileName := expr.FileName
+				if abs, err := filepath.Abs(fileName); err == nil {
+					fileName = abs
+				}
+				line := coverLine{FileName: fileName, Line: expr.FileLine}
+				stmts[line]++
+				if count := prgrm.Coverage[expr]; count > counts[line] {
+					counts[line] = count
+				}
+
+				if fn.Name == SYS_INIT_FUNC {
+					continue
+				}
+				if cf == nil {
+					name := pkg.Name + "." + fn.Name
+					if cf = prof.funcs[name]; cf == nil {
+						cf = &coverFunc{Name: name, FileName: fileName, FileLine: expr.FileLine, Lines: make(map[coverLine]bool)}
+						prof.funcs[name] = cf
+					}
+				}
+				cf.Lines[line] = true
+			}
+		}
+	}
+
+	for line, n := range stmts {
+		if n > prof.stmts[line] {
+			prof.stmts[line] = n
+		}
+		prof.counts[line] += counts[line]
+	}
+}
+
+// sortedLines returns the profile's lines, sorted by file and line
+func (prof *CoverageProfile) sortedLines() []coverLine {
+	lines := make([]coverLine, 0, len(prof.stmts))
+	for line := range prof.stmts {
+		lines = append(lines, line)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].FileName != lines[j].FileName {
+			return lines[i].FileName < lines[j].FileName
+		}
+		return lines[i].Line < lines[j].Line
+	})
+	return lines
+}
+
+// WriteProfile writes the profile in the text format of Go's cover tool, so
+// it can be rendered with go tool cover -html. Every line is a block that
+// ends where the next line starts
+func (prof *CoverageProfile) WriteProfile(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "mode: count"); err != nil {
+		return err
+	}
+	for _, line := range prof.sortedLines() {
+		_, err := fmt.Fprintf(w, "%s:%d.1,%d.1 %d %d\n", line.FileName, line.Line, line.Line+1, prof.stmts[line], prof.counts[line])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// coverage returns the number of statements of `lines` and how many of them
+// ran
+func (prof *CoverageProfile) coverage(lines map[coverLine]bool) (stmts, covered int) {
+	for line := range lines {
+		stmts += prof.stmts[line]
+		if prof.counts[line] > 0 {
+			covered += prof.stmts[line]
+		}
+	}
+	return stmts, covered
+}
+
+// percent returns `covered` as a percentage of `stmts`
+func percent(stmts, covered int) float64 {
+	if stmts == 0 {
+		return 0
+	}
+	return 100 * float64(covered) / float64(stmts)
+}
+
+// WriteFuncSummary writes the percentage of statements covered in every
+// function and in total, as go tool cover -func does
+func (prof *CoverageProfile) WriteFuncSummary(w io.Writer) error {
+	funcs := make([]*coverFunc, 0, len(prof.funcs))
+	for _, cf := range prof.funcs {
+		funcs = append(funcs, cf)
+	}
+	sort.Slice(funcs, func(i, j int) bool {
+		if funcs[i].FileName != funcs[j].FileName {
+			return funcs[i].FileName < funcs[j].FileName
+		}
+		return funcs[i].FileLine < funcs[j].FileLine
+	})
+
+	for _, cf := range funcs {
+		stmts, covered := prof.coverage(cf.Lines)
+		if _, err := fmt.Fprintf(w, "%s:%d:\t%s\t%.1f%%\n", cf.FileName, cf.FileLine, cf.Name, percent(stmts, covered)); err != nil {
+			return err
+		}
+	}
+
+	all := make(map[coverLine]bool, len(prof.stmts))
+	for line := range prof.stmts {
+		all[line] = true
+	}
+	stmts, covered := prof.coverage(all)
+	_, err := fmt.Fprintf(w, "total:\t(statements)\t%.1f%%\n", percent(stmts, covered))
+	return err
+}
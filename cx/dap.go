@@ -0,0 +1,638 @@
+package base
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// id of the only thread reported to the client, as CX is still
+// single-threaded
+const DAP_THREAD_ID = 1
+
+// DAPLaunchFunc loads the program of a launch request, compiled and ready to
+// run, from its source file and the arguments given to it
+type DAPLaunchFunc func(program string, args []string) (*CXProgram, error)
+
+// DAPServer speaks the Debug Adapter Protocol, so editors can debug CX
+// programs. It reads requests from `in` and writes responses and events to
+// `out`, running the program on the compiled VM with a Debugger. Requests are
+// handled one at a time, so a running program can't be paused, only stopped
+// at breakpoints and after steps
+type DAPServer struct {
+	in     *bufio.Reader
+	out    io.Writer
+	launch DAPLaunchFunc
+
+	mu  sync.Mutex // messages are written by Serve and by Output
+	seq int
+
+	prgrm       *CXProgram
+	stopOnEntry bool
+	configured  bool
+	started     bool
+	breakpoints map[string][]int // lines of the breakpoints set before the launch, by file
+	refs        []dapRef         // values listed by variables requests, by reference - 1
+	done        bool
+}
+
+// dapRef is what a variables reference lists: the variables of a stack frame,
+// the globals of a package, or the fields or elements of a value
+type dapRef struct {
+	fn      *CXFunction // function of the frame, nil for a value
+	fp      int
+	globals bool
+	byts    []byte
+	t       valueType
+}
+
+type dapRequest struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+type dapResponse struct {
+	Seq        int         `json:"seq"`
+	Type       string      `json:"type"`
+	RequestSeq int         `json:"request_seq"`
+	Success    bool        `json:"success"`
+	Command    string      `json:"command"`
+	Message    string      `json:"message,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+}
+
+type dapEvent struct {
+	Seq   int         `json:"seq"`
+	Type  string      `json:"type"`
+	Event string      `json:"event"`
+	Body  interface{} `json:"body,omitempty"`
+}
+
+type dapCapabilities struct {
+	SupportsConfigurationDoneRequest bool `json:"supportsConfigurationDoneRequest"`
+	SupportsEvaluateForHovers        bool `json:"supportsEvaluateForHovers"`
+	SupportsTerminateRequest         bool `json:"supportsTerminateRequest"`
+}
+
+type dapLaunchArguments struct {
+	Program     string   `json:"program"`
+	Args        []string `json:"args"`
+	StopOnEntry bool     `json:"stopOnEntry"`
+}
+
+type dapSource struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+type dapSetBreakpointsArguments struct {
+	Source      dapSource `json:"source"`
+	Breakpoints []struct {
+		Line int `json:"line"`
+	} `json:"breakpoints"`
+}
+
+type dapBreakpoint struct {
+	ID       int    `json:"id,omitempty"`
+	Verified bool   `json:"verified"`
+	Line     int    `json:"line"`
+	Message  string `json:"message,omitempty"`
+}
+
+type dapThread struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type dapStackTraceArguments struct {
+	StartFrame int `json:"startFrame"`
+	Levels     int `json:"levels"`
+}
+
+type dapStackFrame struct {
+	ID     int        `json:"id"`
+	Name   string     `json:"name"`
+	Source *dapSource `json:"source,omitempty"`
+	Line   int        `json:"line"`
+	Column int        `json:"column"`
+}
+
+type dapScope struct {
+	Name               string `json:"name"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+type dapVariable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+type dapEvaluateArguments struct {
+	Expression string `json:"expression"`
+	FrameID    int    `json:"frameId"`
+}
+
+type dapEvaluateBody struct {
+	Result             string `json:"result"`
+	Type               string `json:"type"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+type dapStoppedBody struct {
+	Reason            string `json:"reason"`
+	Description       string `json:"description,omitempty"`
+	ThreadID          int    `json:"threadId"`
+	AllThreadsStopped bool   `json:"allThreadsStopped"`
+	HitBreakpointIDs  []int  `json:"hitBreakpointIds,omitempty"`
+}
+
+// NewDAPServer returns a server reading requests from `r` and writing to
+// `w`, which loads the programs it's asked to launch with `launch`
+func NewDAPServer(r io.Reader, w io.Writer, launch DAPLaunchFunc) *DAPServer {
+	return &DAPServer{
+		in:          bufio.NewReader(r),
+		out:         w,
+		launch:      launch,
+		breakpoints: make(map[string][]int),
+	}
+}
+
+// Serve handles requests until the client disconnects or closes the input
+func (srv *DAPServer) Serve() error {
+	for !srv.done {
+		req, err := srv.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		srv.handle(req)
+	}
+	return nil
+}
+
+// Output sends `text` to the client as an output event, e.g. what the
+// program prints, with category "stdout" or "stderr"
+func (srv *DAPServer) Output(category, text string) {
+	srv.event("output", map[string]string{"category": category, "output": text})
+}
+
+// readProtocolMessage reads a message of the base protocol shared by the
+// Debug Adapter and Language Server protocols, a JSON object preceded by a
+// Content-Length header, and returns the object
+func readProtocolMessage(in *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := in.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line != "" {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if length >= 0 {
+				break
+			}
+			continue
+		}
+		if idx := strings.Index(line, ":"); idx > 0 && strings.EqualFold(line[:idx], "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(line[idx+1:])); err != nil || length < 0 {
+				return nil, fmt.Errorf("invalid header %q", line)
+			}
+		}
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(in, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// writeProtocolMessage writes a message of the base protocol
+func writeProtocolMessage(out io.Writer, msg interface{}) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Fprintf(out, "Content-Length: %d\r\n\r\n", len(body))
+	out.Write(body)
+}
+
+// read reads a request
+func (srv *DAPServer) read() (*dapRequest, error) {
+	body, err := readProtocolMessage(srv.in)
+	if err != nil {
+		return nil, err
+	}
+	var req dapRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// send writes a message numbered by `seq`, a field of the message
+func (srv *DAPServer) send(seq *int, msg interface{}) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	srv.seq++
+	*seq = srv.seq
+	writeProtocolMessage(srv.out, msg)
+}
+
+func (srv *DAPServer) respond(req *dapRequest, body interface{}, err error) {
+	resp := &dapResponse{Type: "response", RequestSeq: req.Seq, Success: err == nil, Command: req.Command, Body: body}
+	if err != nil {
+		resp.Message, resp.Body = err.Error(), nil
+	}
+	srv.send(&resp.Seq, resp)
+}
+
+func (srv *DAPServer) event(name string, body interface{}) {
+	evt := &dapEvent{Type: "event", Event: name, Body: body}
+	srv.send(&evt.Seq, evt)
+}
+
+// handle responds to a request. Requests that run the program respond
+// before it runs, and the events they cause follow the response
+func (srv *DAPServer) handle(req *dapRequest) {
+	var body interface{}
+	var err error
+	var then func()
+
+	switch req.Command {
+	case "initialize":
+		body = dapCapabilities{SupportsConfigurationDoneRequest: true, SupportsEvaluateForHovers: true, SupportsTerminateRequest: true}
+	case "launch":
+		if err = srv.launchProgram(req.Arguments); err == nil {
+			then = func() {
+				srv.event("initialized", nil)
+				if srv.configured {
+					srv.run()
+				}
+			}
+		}
+	case "setBreakpoints":
+		body, err = srv.setBreakpoints(req.Arguments)
+	case "setExceptionBreakpoints":
+	case "configurationDone":
+		srv.configured = true
+		if srv.prgrm != nil && !srv.started {
+			then = srv.run
+		}
+	case "threads":
+		body = map[string][]dapThread{"threads": {{ID: DAP_THREAD_ID, Name: "main"}}}
+	case "stackTrace":
+		body, err = srv.stackTrace(req.Arguments)
+	case "scopes":
+		body, err = srv.scopes(req.Arguments)
+	case "variables":
+		body, err = srv.variables(req.Arguments)
+	case "evaluate":
+		body, err = srv.evaluate(req.Arguments)
+	case "continue", "next", "stepIn", "stepOut":
+		mode := map[string]int{"continue": DEBUG_CONTINUE, "next": DEBUG_NEXT, "stepIn": DEBUG_STEPIN, "stepOut": DEBUG_FINISH}[req.Command]
+		if err = srv.checkPaused(); err == nil {
+			if req.Command == "continue" {
+				body = map[string]bool{"allThreadsContinued": true}
+			}
+			then = func() { srv.resume(mode, false) }
+		}
+	case "disconnect", "terminate":
+		srv.done = true
+	default:
+		err = fmt.Errorf("unsupported request %q", req.Command)
+	}
+
+	srv.respond(req, body, err)
+	if then != nil {
+		then()
+	}
+}
+
+// launchProgram loads the program and applies the breakpoints set before
+func (srv *DAPServer) launchProgram(raw json.RawMessage) error {
+	var args dapLaunchArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return err
+	}
+	if srv.prgrm != nil {
+		return errors.New("a program was already launched")
+	}
+	if args.Program == "" {
+		return errors.New("no program to launch")
+	}
+
+	prgrm, err := srv.launch(args.Program, args.Args)
+	if err != nil {
+		return err
+	}
+	srv.prgrm, srv.stopOnEntry = prgrm, args.StopOnEntry
+
+	dbg := prgrm.EnableDebugging()
+	for fileName, lines := range srv.breakpoints {
+		for _, line := range lines {
+			dbg.Break(prgrm, fmt.Sprintf("%s:%d", fileName, line))
+		}
+	}
+	return nil
+}
+
+// run starts the program once it's launched and configured
+func (srv *DAPServer) run() {
+	srv.started = true
+	if srv.stopOnEntry {
+		srv.resume(DEBUG_STEPIN, true)
+	} else {
+		srv.resume(DEBUG_CONTINUE, false)
+	}
+}
+
+// resume runs the program as `mode` says, and tells the client why it
+// stopped or that it finished
+func (srv *DAPServer) resume(mode int, entry bool) {
+	srv.refs = nil
+	dbg := srv.prgrm.Debugger
+
+	err := srv.prgrm.DebugResume(mode)
+	if err != nil {
+		srv.Output("stderr", err.Error()+"\n")
+	}
+
+	if dbg.Finished {
+		exitCode := srv.prgrm.ExitCode
+		if err != nil && exitCode == 0 {
+			exitCode = 1
+		}
+		srv.event("exited", map[string]int{"exitCode": exitCode})
+		srv.event("terminated", nil)
+		return
+	}
+
+	body := dapStoppedBody{Reason: "step", Description: dbg.Reason, ThreadID: DAP_THREAD_ID, AllThreadsStopped: true}
+	switch {
+	case entry:
+		body.Reason = "entry"
+	case dbg.Hit != nil:
+		body.Reason, body.HitBreakpointIDs = "breakpoint", []int{dbg.Hit.ID}
+	case strings.HasPrefix(dbg.Reason, "watchpoint"):
+		body.Reason = "data breakpoint"
+	}
+	srv.event("stopped", body)
+}
+
+// checkPaused returns an error if there's no program stopped to inspect
+func (srv *DAPServer) checkPaused() error {
+	if srv.prgrm == nil {
+		return errors.New("no program was launched")
+	}
+	return srv.prgrm.Debugger.checkPaused()
+}
+
+// setBreakpoints replaces the breakpoints of a file. Before the program is
+// launched they can't be checked, so they are kept and reported unverified
+func (srv *DAPServer) setBreakpoints(raw json.RawMessage) (interface{}, error) {
+	var args dapSetBreakpointsArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	fileName := args.Source.Path
+	if fileName == "" {
+		fileName = args.Source.Name
+	}
+
+	breakpoints := make([]dapBreakpoint, 0, len(args.Breakpoints))
+	if srv.prgrm == nil {
+		srv.breakpoints[fileName] = nil
+		for _, bp := range args.Breakpoints {
+			srv.breakpoints[fileName] = append(srv.breakpoints[fileName], bp.Line)
+			breakpoints = append(breakpoints, dapBreakpoint{Line: bp.Line})
+		}
+		return map[string][]dapBreakpoint{"breakpoints": breakpoints}, nil
+	}
+
+	dbg := srv.prgrm.Debugger
+	dbg.ClearBreakpoints(fileName)
+	for _, bp := range args.Breakpoints {
+		added, err := dbg.Break(srv.prgrm, fmt.Sprintf("%s:%d", fileName, bp.Line))
+		if err != nil {
+			breakpoints = append(breakpoints, dapBreakpoint{Line: bp.Line, Message: err.Error()})
+		} else {
+			breakpoints = append(breakpoints, dapBreakpoint{ID: added.ID, Verified: true, Line: bp.Line})
+		}
+	}
+	return map[string][]dapBreakpoint{"breakpoints": breakpoints}, nil
+}
+
+// dapSourceOf describes a source file with its absolute path
+func dapSourceOf(fileName string) *dapSource {
+	path, err := filepath.Abs(fileName)
+	if err != nil {
+		path = fileName
+	}
+	return &dapSource{Name: filepath.Base(fileName), Path: path}
+}
+
+// frame returns the call of a stack frame id, which is its index in the call
+// stack plus one. The id 0 is the innermost call
+func (srv *DAPServer) frame(id int) (*CXCall, error) {
+	if err := srv.checkPaused(); err != nil {
+		return nil, err
+	}
+	if id == 0 {
+		id = srv.prgrm.CallCounter + 1
+	}
+	if id < 1 || id > srv.prgrm.CallCounter+1 {
+		return nil, fmt.Errorf("invalid frame %d", id)
+	}
+	return &srv.prgrm.CallStack[id-1], nil
+}
+
+// stackTrace lists the calls from the innermost one, each at the line of the
+// expression it's running
+func (srv *DAPServer) stackTrace(raw json.RawMessage) (interface{}, error) {
+	var args dapStackTraceArguments
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, err
+		}
+	}
+	if err := srv.checkPaused(); err != nil {
+		return nil, err
+	}
+
+	frames := []dapStackFrame{}
+	for c := srv.prgrm.CallCounter - args.StartFrame; c >= 0; c-- {
+		if args.Levels > 0 && len(frames) == args.Levels {
+			break
+		}
+		call := &srv.prgrm.CallStack[c]
+		frame := dapStackFrame{ID: c + 1, Name: profileFuncName(call.Operator), Column: 1}
+		if call.Line < len(call.Operator.Expressions) {
+			expr := call.Operator.Expressions[call.Line]
+			frame.Source, frame.Line = dapSourceOf(expr.FileName), expr.FileLine
+		}
+		frames = append(frames, frame)
+	}
+
+	return map[string]interface{}{"stackFrames": frames, "totalFrames": srv.prgrm.CallCounter + 1}, nil
+}
+
+// reference returns a new variables reference to `ref`
+func (srv *DAPServer) reference(ref dapRef) int {
+	srv.refs = append(srv.refs, ref)
+	return len(srv.refs)
+}
+
+// scopes lists the local variables and the globals of a stack frame
+func (srv *DAPServer) scopes(raw json.RawMessage) (interface{}, error) {
+	var args struct {
+		FrameID int `json:"frameId"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	call, err := srv.frame(args.FrameID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := []dapScope{
+		{Name: "Locals", VariablesReference: srv.reference(dapRef{fn: call.Operator, fp: call.FramePointer})},
+		{Name: "Globals", VariablesReference: srv.reference(dapRef{fn: call.Operator, globals: true})},
+	}
+	return map[string][]dapScope{"scopes": scopes}, nil
+}
+
+// variables lists the variables of a scope, or the fields, elements or
+// pointed value of a variable
+func (srv *DAPServer) variables(raw json.RawMessage) (interface{}, error) {
+	var args struct {
+		VariablesReference int `json:"variablesReference"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	if err := srv.checkPaused(); err != nil {
+		return nil, err
+	}
+	if args.VariablesReference < 1 || args.VariablesReference > len(srv.refs) {
+		return nil, fmt.Errorf("invalid variables reference %d", args.VariablesReference)
+	}
+	ref := srv.refs[args.VariablesReference-1]
+	prgrm := srv.prgrm
+
+	vars := []dapVariable{}
+	switch {
+	case ref.fn == nil:
+		vars = srv.children(ref.byts, ref.t)
+	case ref.globals:
+		if ref.fn.Package != nil {
+			for _, decl := range ref.fn.Package.Globals {
+				if byts, t, err := prgrm.debugDecl(decl, 0); err == nil {
+					vars = append(vars, srv.variable(decl.Name, byts, t))
+				}
+			}
+		}
+	default:
+		for _, decl := range debugVariables(ref.fn) {
+			if byts, t, err := prgrm.debugDecl(decl, ref.fp); err == nil {
+				vars = append(vars, srv.variable(decl.Name, byts, t))
+			}
+		}
+	}
+	return map[string][]dapVariable{"variables": vars}, nil
+}
+
+// variable describes a value, with a reference to its children if it has any
+func (srv *DAPServer) variable(name string, byts []byte, t valueType) dapVariable {
+	v := dapVariable{Name: name, Value: debugFormat(srv.prgrm, byts, t), Type: t.name()}
+	if srv.expandable(byts, t) {
+		v.VariablesReference = srv.reference(dapRef{byts: byts, t: t})
+	}
+	return v
+}
+
+// expandable reports whether a value has fields, elements or a pointed value
+func (srv *DAPServer) expandable(byts []byte, t valueType) bool {
+	switch t.specs[0] {
+	case DECL_STRUCT:
+		return t.strct != nil && len(t.strct.Fields) > 0
+	case DECL_ARRAY, DECL_SLICE:
+		return t.lengths[0] > 0
+	case DECL_POINTER:
+		_, _, err := srv.prgrm.debugDeref(byts, t)
+		return err == nil
+	}
+	return false
+}
+
+// children lists the fields of a struct, the elements of an array or slice,
+// or the value a pointer points to
+func (srv *DAPServer) children(byts []byte, t valueType) []dapVariable {
+	vars := []dapVariable{}
+	switch t.specs[0] {
+	case DECL_STRUCT:
+		var offset int
+		for _, fld := range t.strct.Fields {
+			if offset+fld.TotalSize > len(byts) {
+				break
+			}
+			vars = append(vars, srv.variable(fld.Name, byts[offset:offset+fld.TotalSize], declValueType(fld)))
+			offset += fld.TotalSize
+		}
+	case DECL_ARRAY, DECL_SLICE:
+		elt := t.elem()
+		size := elt.size()
+		for c := 0; c < t.lengths[0] && (c+1)*size <= len(byts); c++ {
+			vars = append(vars, srv.variable("["+strconv.Itoa(c)+"]", byts[c*size:(c+1)*size], elt))
+		}
+	case DECL_POINTER:
+		// the fields or elements of a pointed struct or array are listed
+		// directly, as they are accessed through the pointer
+		pointee, pt, err := srv.prgrm.debugDeref(byts, t)
+		if err != nil {
+			break
+		}
+		if pt.specs[0] != DECL_POINTER && srv.expandable(pointee, pt) {
+			return srv.children(pointee, pt)
+		}
+		vars = append(vars, srv.variable("*", pointee, pt))
+	}
+	return vars
+}
+
+// evaluate reads a variable in a stack frame, as DebugPrint does
+func (srv *DAPServer) evaluate(raw json.RawMessage) (interface{}, error) {
+	var args dapEvaluateArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	call, err := srv.frame(args.FrameID)
+	if err != nil {
+		return nil, err
+	}
+
+	byts, t, _, err := srv.prgrm.debugRead(call.Operator, call.FramePointer, args.Expression)
+	if err != nil {
+		return nil, err
+	}
+	v := srv.variable(args.Expression, byts, t)
+	return dapEvaluateBody{Result: v.Value, Type: v.Type, VariablesReference: v.VariablesReference}, nil
+}
@@ -0,0 +1,296 @@
+package base
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// dapClient sends requests to a DAPServer and reads its messages in order
+type dapClient struct {
+	t   *testing.T
+	w   io.Writer
+	r   *bufio.Reader
+	seq int
+}
+
+type dapMessage struct {
+	Type       string          `json:"type"`
+	Command    string          `json:"command"`
+	Event      string          `json:"event"`
+	RequestSeq int             `json:"request_seq"`
+	Success    bool            `json:"success"`
+	Message    string          `json:"message"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// startDAP serves a client with a server launching `prgrm`
+func startDAP(t *testing.T, prgrm *CXProgram) (*dapClient, chan error) {
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+
+	srv := NewDAPServer(reqR, respW, func(program string, args []string) (*CXProgram, error) {
+		if program != "add.cx" {
+			return nil, errors.New("open " + program + ": no such file or directory")
+		}
+		return prgrm, nil
+	})
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Serve()
+		respW.Close()
+	}()
+
+	return &dapClient{t: t, w: reqW, r: bufio.NewReader(respR)}, done
+}
+
+func (client *dapClient) send(command string, args interface{}) {
+	client.seq++
+	msg, _ := json.Marshal(map[string]interface{}{"seq": client.seq, "type": "request", "command": command, "arguments": args})
+	fmt.Fprintf(client.w, "Content-Length: %d\r\n\r\n%s", len(msg), msg)
+}
+
+func (client *dapClient) next() *dapMessage {
+	client.t.Helper()
+	header, err := client.r.ReadString('\n')
+	if err != nil {
+		client.t.Fatalf("reading a message: %v", err)
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+	if err != nil {
+		client.t.Fatalf("invalid header %q", header)
+	}
+	client.r.ReadString('\n')
+	body := make([]byte, length)
+	if _, err := io.ReadFull(client.r, body); err != nil {
+		client.t.Fatal(err)
+	}
+	var msg dapMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		client.t.Fatal(err)
+	}
+	return &msg
+}
+
+// request sends a request and decodes the body of its response, which must
+// be the next message, into `body`
+func (client *dapClient) request(command string, args interface{}, body interface{}) *dapMessage {
+	client.t.Helper()
+	client.send(command, args)
+	msg := client.next()
+	if msg.Type != "response" || msg.Command != command || msg.RequestSeq != client.seq {
+		client.t.Fatalf("got %s %s%s, want the response to %s", msg.Type, msg.Command, msg.Event, command)
+	}
+	if msg.Success && body != nil {
+		if err := json.Unmarshal(msg.Body, body); err != nil {
+			client.t.Fatal(err)
+		}
+	}
+	return msg
+}
+
+// event reads the next message, which must be the event `name`
+func (client *dapClient) event(name string, body interface{}) {
+	client.t.Helper()
+	msg := client.next()
+	if msg.Type != "event" || msg.Event != name {
+		client.t.Fatalf("got %s %s%s, want the %s event", msg.Type, msg.Command, msg.Event, name)
+	}
+	if body != nil {
+		if err := json.Unmarshal(msg.Body, body); err != nil {
+			client.t.Fatal(err)
+		}
+	}
+}
+
+// stopped reads a stopped event and checks where the program stopped
+func (client *dapClient) stopped(reason, fnName string, line int) dapStoppedBody {
+	client.t.Helper()
+	var stopped dapStoppedBody
+	client.event("stopped", &stopped)
+	if stopped.Reason != reason {
+		client.t.Errorf("stopped because of %q (%s), want %q", stopped.Reason, stopped.Description, reason)
+	}
+
+	var trace struct{ StackFrames []dapStackFrame }
+	client.request("stackTrace", map[string]int{"threadId": DAP_THREAD_ID}, &trace)
+	if len(trace.StackFrames) == 0 || trace.StackFrames[0].Name != fnName || trace.StackFrames[0].Line != line {
+		client.t.Fatalf("stopped at %+v, want %s at line %d", trace.StackFrames, fnName, line)
+	}
+	return stopped
+}
+
+// variables returns the values of the variables of a reference by name
+func (client *dapClient) variables(ref int) map[string]dapVariable {
+	client.t.Helper()
+	var resp struct{ Variables []dapVariable }
+	if msg := client.request("variables", map[string]int{"variablesReference": ref}, &resp); !msg.Success {
+		client.t.Fatalf("variables failed: %s", msg.Message)
+	}
+	vars := make(map[string]dapVariable)
+	for _, v := range resp.Variables {
+		vars[v.Name] = v
+	}
+	return vars
+}
+
+func (client *dapClient) evaluate(expr string, frameID int) (string, bool) {
+	client.t.Helper()
+	var result dapEvaluateBody
+	msg := client.request("evaluate", map[string]interface{}{"expression": expr, "frameId": frameID}, &result)
+	if !msg.Success {
+		return msg.Message, false
+	}
+	return result.Result, true
+}
+
+func TestDAPSession(t *testing.T) {
+	prgrm := makeAddProgram()
+	client, done := startDAP(t, prgrm)
+	path, _ := filepath.Abs("add.cx")
+
+	var caps dapCapabilities
+	client.request("initialize", map[string]string{"adapterID": "cx"}, &caps)
+	if !caps.SupportsConfigurationDoneRequest {
+		t.Errorf("configurationDone is not supported")
+	}
+
+	// breakpoints set before the launch are kept, unverified
+	var bps struct{ Breakpoints []dapBreakpoint }
+	client.request("setBreakpoints", map[string]interface{}{
+		"source":      map[string]string{"name": "add.cx"},
+		"breakpoints": []map[string]int{{"line": 13}},
+	}, &bps)
+	if len(bps.Breakpoints) != 1 || bps.Breakpoints[0].Verified {
+		t.Errorf("breakpoints before the launch = %+v, want one unverified", bps.Breakpoints)
+	}
+
+	if msg := client.request("launch", map[string]interface{}{"program": "add.cx"}, nil); !msg.Success {
+		t.Fatalf("launch failed: %s", msg.Message)
+	}
+	client.event("initialized", nil)
+
+	client.request("setBreakpoints", map[string]interface{}{
+		"source":      map[string]string{"path": path},
+		"breakpoints": []map[string]int{{"line": 11}, {"line": 99}},
+	}, &bps)
+	if len(bps.Breakpoints) != 2 || !bps.Breakpoints[0].Verified || bps.Breakpoints[1].Verified || bps.Breakpoints[1].Message == "" {
+		t.Errorf("breakpoints = %+v, want line 11 verified and line 99 not", bps.Breakpoints)
+	}
+	line11 := bps.Breakpoints[0].ID
+
+	client.request("configurationDone", nil, nil)
+	stopped := client.stopped("breakpoint", "main.main", 11)
+	if len(stopped.HitBreakpointIDs) != 1 || stopped.HitBreakpointIDs[0] != line11 {
+		t.Errorf("hit breakpoints %v, want [%d]", stopped.HitBreakpointIDs, line11)
+	}
+
+	var threads struct{ Threads []dapThread }
+	client.request("threads", nil, &threads)
+	if len(threads.Threads) != 1 || threads.Threads[0].ID != DAP_THREAD_ID {
+		t.Errorf("threads = %+v, want one", threads.Threads)
+	}
+
+	client.request("stepIn", map[string]int{"threadId": DAP_THREAD_ID}, nil)
+	client.stopped("step", "main.add", 6)
+
+	var trace struct {
+		StackFrames []dapStackFrame
+		TotalFrames int
+	}
+	client.request("stackTrace", map[string]int{"threadId": DAP_THREAD_ID}, &trace)
+	if trace.TotalFrames != 2 || len(trace.StackFrames) != 2 {
+		t.Fatalf("stack = %+v, want add called by main", trace.StackFrames)
+	}
+	if caller := trace.StackFrames[1]; caller.Name != "main.main" || caller.Line != 11 || caller.Source == nil || caller.Source.Path != path {
+		t.Errorf("caller = %+v, want main.main at %s:11", caller, path)
+	}
+
+	var scopes struct{ Scopes []dapScope }
+	client.request("scopes", map[string]int{"frameId": trace.StackFrames[0].ID}, &scopes)
+	if len(scopes.Scopes) != 2 || scopes.Scopes[0].Name != "Locals" || scopes.Scopes[1].Name != "Globals" {
+		t.Fatalf("scopes = %+v, want Locals and Globals", scopes.Scopes)
+	}
+	locals := client.variables(scopes.Scopes[0].VariablesReference)
+	if len(locals) != 3 || locals["a"].Value != "2" || locals["b"].Value != "3" || locals["c"].Value != "0" || locals["a"].Type != "i32" {
+		t.Errorf("locals of add = %+v, want a = 2, b = 3 and c = 0", locals)
+	}
+
+	if result, ok := client.evaluate("b", trace.StackFrames[0].ID); !ok || result != "3" {
+		t.Errorf("b = %s, want 3", result)
+	}
+	if result, ok := client.evaluate("x", trace.StackFrames[1].ID); !ok || result != "0" {
+		t.Errorf("x in main = %s, want 0", result)
+	}
+	if _, ok := client.evaluate("y", trace.StackFrames[0].ID); ok {
+		t.Errorf("evaluating an undefined variable succeeded")
+	}
+
+	client.request("stepOut", map[string]int{"threadId": DAP_THREAD_ID}, nil)
+	client.stopped("step", "main.main", 12)
+	if result, _ := client.evaluate("x", 0); result != "5" {
+		t.Errorf("x after add(2, 3) = %s, want 5", result)
+	}
+
+	// stepping over add, the breakpoint set before the launch stops main
+	client.request("next", map[string]int{"threadId": DAP_THREAD_ID}, nil)
+	client.stopped("breakpoint", "main.main", 13)
+
+	client.request("scopes", map[string]int{"frameId": 1}, &scopes)
+	globals := client.variables(scopes.Scopes[1].VariablesReference)
+	if globals["total"].Value != "0" || globals["pair"].Type != "[2]i32" || globals["pair"].VariablesReference == 0 {
+		t.Fatalf("globals = %+v, want total = 0 and pair expandable", globals)
+	}
+	elts := client.variables(globals["pair"].VariablesReference)
+	if len(elts) != 2 || elts["[0]"].Value != "7" || elts["[1]"].Value != "9" {
+		t.Errorf("elements of pair = %+v, want 7 and 9", elts)
+	}
+
+	client.request("continue", map[string]int{"threadId": DAP_THREAD_ID}, nil)
+	var exited struct{ ExitCode int }
+	client.event("exited", &exited)
+	client.event("terminated", nil)
+	if total := ReadI32(&prgrm.Stacks[0], 0, prgrm.Packages[0].Globals[0]); total != 6 {
+		t.Errorf("total = %d, want 6", total)
+	}
+	if _, ok := client.evaluate("total", 0); ok {
+		t.Errorf("evaluating after the program finished succeeded")
+	}
+
+	client.request("disconnect", nil, nil)
+	if err := <-done; err != nil {
+		t.Errorf("Serve returned %v", err)
+	}
+}
+
+func TestDAPStopOnEntry(t *testing.T) {
+	client, done := startDAP(t, makeAddProgram())
+
+	if msg := client.request("launch", map[string]interface{}{"program": "missing.cx"}, nil); msg.Success {
+		t.Errorf("launching a missing program succeeded")
+	}
+	if msg := client.request("launch", map[string]interface{}{"program": "add.cx", "stopOnEntry": true}, nil); !msg.Success {
+		t.Fatalf("launch failed: %s", msg.Message)
+	}
+	client.event("initialized", nil)
+	client.request("configurationDone", nil, nil)
+	client.stopped("entry", "main.main", 10)
+
+	client.request("continue", map[string]int{"threadId": DAP_THREAD_ID}, nil)
+	client.event("exited", nil)
+	client.event("terminated", nil)
+
+	if msg := client.request("next", map[string]int{"threadId": DAP_THREAD_ID}, nil); msg.Success {
+		t.Errorf("stepping a finished program succeeded")
+	}
+	client.request("disconnect", nil, nil)
+	if err := <-done; err != nil {
+		t.Errorf("Serve returned %v", err)
+	}
+}
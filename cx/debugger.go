@@ -0,0 +1,625 @@
+package base
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+// ways in which the debugger resumes a paused program
+const (
+	DEBUG_CONTINUE = iota // until a breakpoint or a watchpoint stops it
+	DEBUG_NEXT            // until the next line of the function, stepping over calls
+	DEBUG_STEPIN          // until the next line, entering calls
+	DEBUG_FINISH          // until the function returns
+)
+
+// Breakpoint stops the program before a line runs, or when a function is
+// called
+type Breakpoint struct {
+	ID       int
+	FileName string // file of a line breakpoint, as given by the user
+	Line     int
+	Function *CXFunction // function of a function breakpoint
+	Hits     int
+
+	files map[string]bool // whether the files of the expressions are the breakpoint's
+}
+
+func (bp *Breakpoint) String() string {
+	if bp.Function != nil {
+		return fmt.Sprintf("breakpoint %d in %s", bp.ID, profileFuncName(bp.Function))
+	}
+	return fmt.Sprintf("breakpoint %d at %s:%d", bp.ID, bp.FileName, bp.Line)
+}
+
+// matches reports whether `fileName`, the file of an expression, is the file
+// of the breakpoint
+func (bp *Breakpoint) matches(fileName string) bool {
+	match, found := bp.files[fileName]
+	if !found {
+		match = debugFileMatches(fileName, bp.FileName)
+		if bp.files == nil {
+			bp.files = make(map[string]bool)
+		}
+		bp.files[fileName] = match
+	}
+	return match
+}
+
+// Watchpoint stops the program when the value of a variable changes. The
+// variables of a function are watched while the call where the watchpoint
+// was set is running
+type Watchpoint struct {
+	ID    int
+	Expr  string
+	frame int         // call stack frame of the variable, if it's not a global
+	fn    *CXFunction // function of the frame, nil for globals
+	scope *CXFunction // where the variable is looked up
+	value []byte
+	text  string
+}
+
+// Debugger runs a program from the REPL, stopping it at breakpoints and
+// watchpoints, and stepping through it line by line. It works on the call
+// stack of the compiled VM, so the variables are read from the stack frames
+// of the calls and from the data segment
+type Debugger struct {
+	Breakpoints []*Breakpoint
+	Watchpoints []*Watchpoint
+	Reason      string      // why the program last stopped
+	Hit         *Breakpoint // breakpoint that stopped the program, if one did
+	Started     bool
+	Finished    bool
+
+	nextID   int
+	running  bool
+	paused   bool
+	resuming bool // the expression the program stopped at runs without stopping again
+	mode     int
+	depth    int // call stack depth, line and expression where the program resumed
+	line     int
+	index    int
+}
+
+// EnableDebugging attaches a debugger to the program, which isn't started
+// until it's resumed
+func (prgrm *CXProgram) EnableDebugging() *Debugger {
+	prgrm.Debugger = &Debugger{nextID: 1}
+	return prgrm.Debugger
+}
+
+// debugFileMatches reports whether `fileName`, the file of an expression, is
+// the file given by the user, which can omit its directories or be absolute
+func debugFileMatches(fileName, given string) bool {
+	fileName, given = filepath.Clean(fileName), filepath.Clean(given)
+	if fileName == given || strings.HasSuffix(fileName, string(filepath.Separator)+given) {
+		return true
+	}
+	absFile, err := filepath.Abs(fileName)
+	if err != nil {
+		return false
+	}
+	absGiven, err := filepath.Abs(given)
+	return err == nil && absFile == absGiven
+}
+
+// Break adds a breakpoint at `spec`, which is either a line, e.g. main.cx:12,
+// or a function, e.g. foo or pkg.foo
+func (dbg *Debugger) Break(prgrm *CXProgram, spec string) (*Breakpoint, error) {
+	bp := &Breakpoint{}
+
+	if idx := strings.LastIndex(spec, ":"); idx > 0 {
+		line, err := strconv.Atoi(spec[idx+1:])
+		if err != nil || line < 1 {
+			return nil, fmt.Errorf("invalid line in %q", spec)
+		}
+		bp.FileName, bp.Line = spec[:idx], line
+
+		found := false
+		for _, pkg := range prgrm.Packages {
+			for _, fn := range pkg.Functions {
+				for _, expr := range fn.Expressions {
+					if expr.FileLine == line && bp.matches(expr.FileName) {
+						found = true
+					}
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no code at %s", spec)
+		}
+	} else {
+		pkgName, fnName := MAIN_PKG, spec
+		if idx := strings.Index(spec, "."); idx > 0 {
+			pkgName, fnName = spec[:idx], spec[idx+1:]
+		}
+		pkg, err := prgrm.GetPackage(pkgName)
+		if err != nil {
+			return nil, err
+		}
+		for _, fn := range pkg.Functions {
+			if fn.Name == fnName && !fn.IsNative {
+				bp.Function = fn
+			}
+		}
+		if bp.Function == nil {
+			return nil, fmt.Errorf("function %s not found", spec)
+		}
+	}
+
+	bp.ID = dbg.nextID
+	dbg.nextID++
+	dbg.Breakpoints = append(dbg.Breakpoints, bp)
+	return bp, nil
+}
+
+// ClearBreakpoints deletes the breakpoints at the lines of `fileName`, as
+// given to Break
+func (dbg *Debugger) ClearBreakpoints(fileName string) {
+	breakpoints := dbg.Breakpoints[:0]
+	for _, bp := range dbg.Breakpoints {
+		if bp.Function != nil || bp.FileName != fileName {
+			breakpoints = append(breakpoints, bp)
+		}
+	}
+	dbg.Breakpoints = breakpoints
+}
+
+// Watch adds a watchpoint on `expr`, a variable of the function the program
+// is stopped at, or a global
+func (dbg *Debugger) Watch(prgrm *CXProgram, expr string) (*Watchpoint, error) {
+	if err := dbg.checkPaused(); err != nil {
+		return nil, err
+	}
+
+	call := &prgrm.CallStack[prgrm.CallCounter]
+	byts, t, isLocal, err := prgrm.debugRead(call.Operator, call.FramePointer, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watchpoint{ID: dbg.nextID, Expr: expr, frame: prgrm.CallCounter}
+	if isLocal {
+		w.fn, w.scope = call.Operator, call.Operator
+	} else {
+		// a function without variables, so only the globals are found
+		w.scope = &CXFunction{Package: call.Operator.Package}
+	}
+	w.value = append([]byte{}, byts...)
+	w.text = debugFormat(prgrm, byts, t)
+
+	dbg.nextID++
+	dbg.Watchpoints = append(dbg.Watchpoints, w)
+	return w, nil
+}
+
+// checkPaused returns an error if the program isn't stopped
+func (dbg *Debugger) checkPaused() error {
+	if !dbg.Started {
+		return errors.New("the program is not running")
+	}
+	if dbg.Finished {
+		return errors.New("the program has finished")
+	}
+	return nil
+}
+
+// Location returns the function and expression where the program is stopped
+func (dbg *Debugger) Location(prgrm *CXProgram) (*CXFunction, *CXExpression) {
+	if dbg.checkPaused() != nil {
+		return nil, nil
+	}
+	call := &prgrm.CallStack[prgrm.CallCounter]
+	return call.Operator, call.Operator.Expressions[call.Line]
+}
+
+// start runs the *init function of the program, without stopping in it, and
+// puts main on the call stack
+func (dbg *Debugger) start(prgrm *CXProgram) error {
+	mod, err := prgrm.SelectPackage(MAIN_PKG)
+	if err != nil {
+		return err
+	}
+	initFn, err := mod.SelectFunction(SYS_INIT_FUNC)
+	if err != nil {
+		return err
+	}
+	mainFn, err := mod.SelectFunction(MAIN_FUNC)
+	if err != nil {
+		return err
+	}
+
+	if len(prgrm.Stacks) == 0 {
+		prgrm.Stacks = append(prgrm.Stacks, MakeStack(1024))
+	}
+	prgrm.CallStack[0] = MakeCall(initFn, nil, nil, mod, prgrm)
+	prgrm.CallCounter = 0
+	prgrm.Stacks[0].StackPointer = initFn.Size
+	prgrm.Terminated = false
+
+	for !prgrm.Terminated {
+		if err := prgrm.CallStack[prgrm.CallCounter].ccall(prgrm); err != nil {
+			return err
+		}
+	}
+
+	prgrm.CallStack[0] = MakeCall(mainFn, nil, nil, mod, prgrm)
+	prgrm.CallCounter = 0
+	prgrm.Stacks[0].StackPointer = mainFn.Size
+	prgrm.Terminated = prgrm.Exited || len(mainFn.Expressions) == 0
+
+	dbg.Started = true
+	return nil
+}
+
+// DebugResume starts or resumes the program until it stops as `mode` says,
+// stops at a breakpoint or watchpoint, or finishes. A program that hasn't
+// started stops at the first line of main if it's stepped through
+func (prgrm *CXProgram) DebugResume(mode int) (err error) {
+	dbg := prgrm.Debugger
+	if dbg.Finished {
+		return errors.New("the program has finished")
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+		dbg.running = false
+		if err != nil || prgrm.Terminated {
+			dbg.Finished = true
+		}
+	}()
+
+	if !dbg.Started {
+		if err := dbg.start(prgrm); err != nil {
+			return err
+		}
+		if mode != DEBUG_CONTINUE && !prgrm.Terminated {
+			dbg.Reason = "step"
+			return nil
+		}
+	} else {
+		dbg.resuming = true
+	}
+
+	if !prgrm.Terminated {
+		call := &prgrm.CallStack[prgrm.CallCounter]
+		dbg.mode, dbg.depth, dbg.index = mode, prgrm.CallCounter, call.Line
+		dbg.line = call.Operator.Expressions[call.Line].FileLine
+	}
+
+	dbg.running, dbg.paused = true, false
+	for !prgrm.Terminated && !dbg.paused {
+		if err := prgrm.CallStack[prgrm.CallCounter].ccall(prgrm); err != nil {
+			return err
+		}
+	}
+
+	if prgrm.Terminated {
+		dbg.Reason = "exit"
+		mainFn := prgrm.CallStack[0].Operator
+		if !prgrm.Exited && len(mainFn.Outputs) == 1 && mainFn.Outputs[0].Type == TYPE_I32 {
+			prgrm.ExitCode = int(ReadI32(&prgrm.Stacks[0], 0, mainFn.Outputs[0]))
+		}
+	}
+	return nil
+}
+
+// pause is called by ccall before running `expr`, and reports whether the
+// program must stop before it. Callbacks run by natives aren't stopped in, as
+// the native is waiting for them to finish
+func (dbg *Debugger) pause(prgrm *CXProgram, call *CXCall, expr *CXExpression) bool {
+	if !dbg.running || prgrm.CallBase != 0 {
+		return false
+	}
+	if dbg.resuming {
+		dbg.resuming = false
+		return false
+	}
+
+	dbg.Hit = nil
+	depth := prgrm.CallCounter
+	newLine := depth != dbg.depth || expr.FileLine != dbg.line || call.Line <= dbg.index
+
+	var reason string
+	switch dbg.mode {
+	case DEBUG_STEPIN:
+		if newLine {
+			reason = "step"
+		}
+	case DEBUG_NEXT:
+		if depth < dbg.depth || (depth == dbg.depth && newLine) {
+			reason = "step"
+		}
+	case DEBUG_FINISH:
+		if depth < dbg.depth {
+			reason = "finish"
+		}
+	}
+
+	for _, bp := range dbg.Breakpoints {
+		var hit bool
+		if bp.Function != nil {
+			hit = call.Line == 0 && call.Operator == bp.Function
+		} else {
+			// only the first expression of the line stops the program
+			hit = expr.FileLine == bp.Line && bp.matches(expr.FileName) &&
+				(call.Line == 0 || call.Operator.Expressions[call.Line-1].FileLine != bp.Line)
+		}
+		if hit {
+			bp.Hits++
+			dbg.Hit = bp
+			reason = bp.String()
+		}
+	}
+
+	if changed := dbg.checkWatchpoints(prgrm); changed != "" {
+		reason = changed
+	}
+
+	if reason == "" {
+		return false
+	}
+	dbg.Reason = reason
+	dbg.paused = true
+	return true
+}
+
+// checkWatchpoints updates the values of the watchpoints and describes the
+// ones that changed, or went out of scope and were deleted
+func (dbg *Debugger) checkWatchpoints(prgrm *CXProgram) string {
+	var changes []string
+	watchpoints := dbg.Watchpoints[:0]
+
+	for _, w := range dbg.Watchpoints {
+		if w.fn != nil && (w.frame > prgrm.CallCounter || prgrm.CallStack[w.frame].Operator != w.fn) {
+			changes = append(changes, fmt.Sprintf("watchpoint %d deleted: %s is out of scope", w.ID, w.Expr))
+			continue
+		}
+		watchpoints = append(watchpoints, w)
+
+		var fp int
+		if w.fn != nil {
+			fp = prgrm.CallStack[w.frame].FramePointer
+		}
+		byts, t, _, err := prgrm.debugRead(w.scope, fp, w.Expr)
+		if err != nil || bytes.Equal(byts, w.value) {
+			continue
+		}
+		text := debugFormat(prgrm, byts, t)
+		changes = append(changes, fmt.Sprintf("watchpoint %d: %s changed from %s to %s", w.ID, w.Expr, w.text, text))
+		w.value = append(w.value[:0], byts...)
+		w.text = text
+	}
+
+	dbg.Watchpoints = watchpoints
+	return strings.Join(changes, "\n")
+}
+
+// DebugPrint formats the value of `expr` in the function the program is
+// stopped at. `expr` is a variable, or a global, followed by field accesses
+// and indexes, e.g. points[2].x, and can be dereferenced with *
+func (prgrm *CXProgram) DebugPrint(expr string) (string, error) {
+	if err := prgrm.Debugger.checkPaused(); err != nil {
+		return "", err
+	}
+	call := &prgrm.CallStack[prgrm.CallCounter]
+	byts, t, _, err := prgrm.debugRead(call.Operator, call.FramePointer, expr)
+	if err != nil {
+		return "", err
+	}
+	return debugFormat(prgrm, byts, t), nil
+}
+
+// DebugLocals formats the parameters and local variables of the function
+// the program is stopped at, one per line
+func (prgrm *CXProgram) DebugLocals() (string, error) {
+	if err := prgrm.Debugger.checkPaused(); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	call := &prgrm.CallStack[prgrm.CallCounter]
+	for _, decl := range debugVariables(call.Operator) {
+		byts, t, _, err := prgrm.debugRead(call.Operator, call.FramePointer, decl.Name)
+		if err == nil {
+			fmt.Fprintf(&buf, "%s = %s\n", decl.Name, debugFormat(prgrm, byts, t))
+		}
+	}
+	return buf.String(), nil
+}
+
+// debugFormat formats a value read by the debugger, with its type names and
+// quoted strings
+func debugFormat(prgrm *CXProgram, byts []byte, t valueType) string {
+	var buf bytes.Buffer
+	formatBytes(&buf, prgrm, byts, t, true, true, 0)
+	return buf.String()
+}
+
+// debugVariables returns the declarations of the parameters and local
+// variables of `fn`, in the order they are declared
+func debugVariables(fn *CXFunction) (decls []*CXArgument) {
+	seen := make(map[string]bool)
+	add := func(decl *CXArgument) {
+		if decl.Name == "" || seen[decl.Name] || strings.HasPrefix(decl.Name, LOCAL_PREFIX) || strings.HasPrefix(decl.Name, NON_ASSIGN_PREFIX) {
+			return
+		}
+		seen[decl.Name] = true
+		decls = append(decls, decl)
+	}
+
+	for _, inp := range fn.Inputs {
+		add(inp)
+	}
+	for _, out := range fn.Outputs {
+		add(out)
+	}
+	for _, expr := range fn.Expressions {
+		for _, out := range expr.Outputs {
+			if out.IsLocalDeclaration && len(out.Fields) == 0 && len(out.Indexes) == 0 && out.DereferenceLevels == 0 {
+				add(out)
+			}
+		}
+	}
+	return decls
+}
+
+// debugIdent splits the identifier at the start of `s` from the rest
+func debugIdent(s string) (ident, rest string) {
+	c := 0
+	for c < len(s) && (s[c] == '_' || s[c] >= 'a' && s[c] <= 'z' || s[c] >= 'A' && s[c] <= 'Z' || c > 0 && isDigit(s[c])) {
+		c++
+	}
+	return s[:c], s[c:]
+}
+
+// debugDecl reads the value of a declared variable, from the stack frame at
+// `fp` or from the data segment if it's a global
+func (prgrm *CXProgram) debugDecl(decl *CXArgument, fp int) ([]byte, valueType, error) {
+	t := declValueType(decl)
+	start := decl.Offset
+	mem := []byte(prgrm.Data)
+	if decl.MemoryRead != MEM_DATA {
+		start += fp
+		mem = prgrm.Stacks[0].Stack
+	}
+	end := start + t.size()
+	if start < 0 || end > len(mem) {
+		return nil, t, fmt.Errorf("%s is out of memory", decl.Name)
+	}
+	return mem[start:end], t, nil
+}
+
+// debugDeref follows the pointer stored in `byts`
+func (prgrm *CXProgram) debugDeref(byts []byte, t valueType) ([]byte, valueType, error) {
+	if t.specs[0] != DECL_POINTER || (t.isBase() && t.typ == TYPE_STR) {
+		return nil, t, fmt.Errorf("%s is not a pointer", t.name())
+	}
+
+	var heapOffset int32
+	encoder.DeserializeAtomic(byts[:TYPE_POINTER_SIZE], &heapOffset)
+	if heapOffset == NULL_HEAP_ADDRESS {
+		return nil, t, errors.New("nil pointer dereference")
+	}
+
+	pointee := t.elem()
+	start := int(heapOffset) + OBJECT_HEADER_SIZE
+	end := start + pointee.size()
+	if end > len(prgrm.Heap.Heap) {
+		return nil, t, errors.New("invalid pointer")
+	}
+	return prgrm.Heap.Heap[start:end], pointee, nil
+}
+
+// debugRead reads the value of `expr` in a call to `fn` with its frame at
+// `fp`, as DebugPrint describes, and reports whether it's a variable of `fn`
+// rather than a global
+func (prgrm *CXProgram) debugRead(fn *CXFunction, fp int, expr string) (byts []byte, t valueType, isLocal bool, err error) {
+	expr = strings.Replace(strings.TrimSpace(expr), " ", "", -1)
+	var derefs int
+	for strings.HasPrefix(expr, "*") {
+		derefs++
+		expr = expr[1:]
+	}
+
+	name, rest := debugIdent(expr)
+	if name == "" {
+		return nil, t, false, fmt.Errorf("invalid expression %q", expr)
+	}
+
+	var decl *CXArgument
+	for _, local := range debugVariables(fn) {
+		if local.Name == name {
+			decl, isLocal = local, true
+		}
+	}
+	if decl == nil && fn.Package != nil {
+		decl, _ = fn.Package.GetGlobal(name)
+	}
+	if decl == nil && strings.HasPrefix(rest, ".") {
+		// a global of another package, e.g. pkg.foo
+		if pkg, pkgErr := prgrm.GetPackage(name); pkgErr == nil {
+			name, rest = debugIdent(rest[1:])
+			decl, _ = pkg.GetGlobal(name)
+		}
+	}
+	if decl == nil {
+		return nil, t, false, fmt.Errorf("undefined: %s", name)
+	}
+
+	if byts, t, err = prgrm.debugDecl(decl, fp); err != nil {
+		return nil, t, false, err
+	}
+
+	for rest != "" {
+		// fields and elements of pointed values are accessed through the pointer
+		for t.specs[0] == DECL_POINTER && !(t.isBase() && t.typ == TYPE_STR) {
+			if byts, t, err = prgrm.debugDeref(byts, t); err != nil {
+				return nil, t, false, err
+			}
+		}
+
+		switch rest[0] {
+		case '.':
+			var fldName string
+			fldName, rest = debugIdent(rest[1:])
+			if t.specs[0] != DECL_STRUCT || t.strct == nil {
+				return nil, t, false, fmt.Errorf("%s is not a struct", t.name())
+			}
+
+			var offset int
+			var found bool
+			for _, fld := range t.strct.Fields {
+				if fld.Name == fldName {
+					if offset+fld.TotalSize > len(byts) {
+						return nil, t, false, fmt.Errorf("%s is out of memory", fldName)
+					}
+					byts, t, found = byts[offset:offset+fld.TotalSize], declValueType(fld), true
+					break
+				}
+				offset += fld.TotalSize
+			}
+			if !found {
+				return nil, t, false, fmt.Errorf("%s has no field %s", t.strct.Name, fldName)
+			}
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, t, false, fmt.Errorf("invalid expression %q", expr)
+			}
+			idx, convErr := strconv.Atoi(rest[1:end])
+			if convErr != nil {
+				return nil, t, false, fmt.Errorf("invalid index %q", rest[1:end])
+			}
+			rest = rest[end+1:]
+			if t.specs[0] != DECL_ARRAY && t.specs[0] != DECL_SLICE {
+				return nil, t, false, fmt.Errorf("%s is not an array or a slice", t.name())
+			}
+			if idx < 0 || idx >= t.lengths[0] {
+				return nil, t, false, fmt.Errorf("index %d out of range [0:%d]", idx, t.lengths[0])
+			}
+
+			elt := t.elem()
+			size := elt.size()
+			byts, t = byts[idx*size:(idx+1)*size], elt
+		default:
+			return nil, t, false, fmt.Errorf("invalid expression %q", expr)
+		}
+	}
+
+	for c := 0; c < derefs; c++ {
+		if byts, t, err = prgrm.debugDeref(byts, t); err != nil {
+			return nil, t, false, err
+		}
+	}
+
+	return byts, t, isLocal, nil
+}
@@ -0,0 +1,1073 @@
+package base
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+// the operators the parser compiles to natives, by their opcodes
+var srcOperators = map[int]string{
+	OP_UND_EQUAL: "==", OP_UND_UNEQUAL: "!=", OP_UND_BITAND: "&", OP_UND_BITXOR: "^",
+	OP_UND_BITOR: "|", OP_UND_BITCLEAR: "&^", OP_UND_MUL: "*", OP_UND_DIV: "/",
+	OP_UND_MOD: "%", OP_UND_ADD: "+", OP_UND_SUB: "-", OP_UND_BITSHL: "<<",
+	OP_UND_BITSHR: ">>", OP_UND_LT: "<", OP_UND_GT: ">", OP_UND_LTEQ: "<=",
+	OP_UND_GTEQ: ">=", OP_BOOL_AND: "&&", OP_BOOL_OR: "||",
+}
+
+// the suffixes of the literals of the basic types, i32 and f32 having none
+var srcLiteralSuffixes = map[int]string{
+	TYPE_BYTE: "B", TYPE_I8: "SB", TYPE_I16: "H", TYPE_I64: "L", TYPE_UI8: "UB",
+	TYPE_UI16: "UH", TYPE_UI32: "U", TYPE_UI64: "UL", TYPE_F64: "D",
+}
+
+// isCorePackage reports whether a package is declared by CX itself instead
+// of by the source code of the program
+func isCorePackage(name string) bool {
+	_, isCore := CorePackages[name]
+	return isCore || name == CORE_MODULE || name == "gl" || name == "glfw" || name == "gltext"
+}
+
+// ToSource returns CX source code that declares the packages of the program
+// as they are in memory, with their imports, structs, globals and functions.
+// Unlike PrintProgram's listing it can be compiled again, so a program changed
+// at runtime by affordances or by the meta natives can be saved. Control flow
+// is rebuilt from the jumps of the functions: the jumps of if/else statements
+// and for loops become those statements again, and any other jump a goto.
+// The code is formatted as cx fmt formats it
+func (prgrm *CXProgram) ToSource() string {
+	var buf bytes.Buffer
+	inits := prgrm.srcGlobalInits()
+
+	// the first pass of the parser reads the files in order, so a package
+	// is written after the packages it imports, whose structs it can use
+	written := make(map[*CXPackage]bool)
+	var write func(pkg *CXPackage)
+	write = func(pkg *CXPackage) {
+		if written[pkg] {
+			return
+		}
+		written[pkg] = true
+		for _, imp := range pkg.Imports {
+			write(imp)
+		}
+		if isCorePackage(pkg.Name) {
+			return
+		}
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		srcPackage(&buf, pkg, inits)
+	}
+	for _, pkg := range prgrm.Packages {
+		write(pkg)
+	}
+
+	// in the style of cx fmt
+	if formatted, err := FormatSource(buf.Bytes()); err == nil {
+		return string(formatted)
+	}
+	return buf.String()
+}
+
+// srcGlobalInits returns the initial values of the globals, by their package
+// and name, from the assignments of the *init function of the main package
+func (prgrm *CXProgram) srcGlobalInits() map[string]string {
+	inits := make(map[string]string)
+
+	var initFn *CXFunction
+	for _, pkg := range prgrm.Packages {
+		if pkg.Name != MAIN_PKG {
+			continue
+		}
+		for _, fn := range pkg.Functions {
+			if fn.Name == SYS_INIT_FUNC {
+				initFn = fn
+			}
+		}
+	}
+	if initFn == nil {
+		return inits
+	}
+
+	src := newSrcFunction(prgrm, initFn)
+	src.reset()
+	src.onAssign = func(e *CXExpression, value string) {
+		if out := e.Outputs[0]; len(e.Outputs) == 1 && out.Package != nil && isPlainArg(out) {
+			inits[out.Package.Name+"."+out.Name] = value
+		}
+	}
+	src.block(0, len(initFn.Expressions))
+
+	return inits
+}
+
+func srcPackage(buf *bytes.Buffer, pkg *CXPackage, inits map[string]string) {
+	fmt.Fprintf(buf, "package %s\n", pkg.Name)
+
+	if len(pkg.Imports) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, imp := range pkg.Imports {
+		fmt.Fprintf(buf, "import \"%s\"\n", imp.Name)
+	}
+
+	for _, strct := range pkg.Structs {
+		fmt.Fprintf(buf, "\ntype %s struct {\n", strct.Name)
+		for _, fld := range strct.Fields {
+			fmt.Fprintf(buf, "\t%s %s\n", fld.Name, srcTypeName(pkg, fld))
+		}
+		buf.WriteString("}\n")
+	}
+
+	if len(pkg.Globals) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, glbl := range pkg.Globals {
+		if glbl.Name == "" {
+			continue
+		}
+		fmt.Fprintf(buf, "var %s %s", glbl.Name, srcTypeName(pkg, glbl))
+		if value, ok := inits[pkg.Name+"."+glbl.Name]; ok {
+			buf.WriteString(" = " + value)
+		}
+		buf.WriteString("\n")
+	}
+
+	for _, fn := range pkg.Functions {
+		if fn.Name == SYS_INIT_FUNC {
+			continue
+		}
+		buf.WriteString("\n")
+		newSrcFunction(pkg.Program, fn).write(buf)
+	}
+}
+
+// srcTypeName returns the type of a declaration as written in package `pkg`,
+// with the package of a struct declared by another one
+func srcTypeName(pkg *CXPackage, decl *CXArgument) string {
+	t := declValueType(decl)
+	name := t.name()
+	if decl.IsVariadic && t.specs[0] == DECL_SLICE {
+		name = "..." + t.elem().name()
+	}
+	if t.strct != nil && t.strct.Package != nil && pkg != nil && t.strct.Package.Name != pkg.Name {
+		name = strings.TrimSuffix(name, t.strct.Name) + t.strct.Package.Name + "." + t.strct.Name
+	}
+	return name
+}
+
+// srcParameters returns the parameters or results of a function as written
+// in its declaration
+func srcParameters(pkg *CXPackage, params []*CXArgument) string {
+	var formatted []string
+	for _, param := range params {
+		if strings.HasPrefix(param.Name, VARIADIC_LEN_PREFIX) {
+			continue
+		}
+		if param.Name == "" {
+			formatted = append(formatted, srcTypeName(pkg, param))
+		} else {
+			formatted = append(formatted, param.Name+" "+srcTypeName(pkg, param))
+		}
+	}
+	return "(" + strings.Join(formatted, ", ") + ")"
+}
+
+// isPlainArg reports whether an argument is a bare name, without fields,
+// indexes, dereferences or a reference
+func isPlainArg(arg *CXArgument) bool {
+	return len(arg.Fields) == 0 && len(arg.Indexes) == 0 && len(arg.DereferenceOperations) == 0 && !arg.IsReference
+}
+
+func isTempName(name string) bool {
+	return strings.HasPrefix(name, LOCAL_PREFIX)
+}
+
+// isDeclaration reports whether an expression declares a variable, instead
+// of being what's left of an identifier read by another expression
+func isDeclaration(expr *CXExpression) bool {
+	if expr.Operator != nil || len(expr.Inputs) > 0 || len(expr.Outputs) != 1 {
+		return false
+	}
+	out := expr.Outputs[0]
+	return out.Name != "" && out.Typ != TypeNames[TYPE_IDENTIFIER] && (out.IsLocalDeclaration || expr.IsShortDeclaration)
+}
+
+func isJump(expr *CXExpression) bool {
+	return expr.Operator != nil && expr.Operator.IsNative && expr.Operator.OpCode == OP_JMP
+}
+
+// srcUnparen removes the parentheses around a whole expression
+func srcUnparen(s string) string {
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return s
+	}
+	depth := 0
+	for c := 0; c < len(s)-1; c++ {
+		switch s[c] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '"':
+			// string literals can't contain quotes
+			if end := strings.IndexByte(s[c+1:], '"'); end >= 0 {
+				c += end + 1
+			}
+		}
+		if depth == 0 {
+			return s
+		}
+	}
+	return s[1 : len(s)-1]
+}
+
+func isIdentChar(ch byte) bool {
+	return isDigit(ch) || ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+// srcCondition returns the condition of an if statement or a for loop. A
+// condition ending with an identifier is parenthesized, as `if a < b {`
+// would be read as the struct literal `b {...}`
+func srcCondition(s string) string {
+	s = srcUnparen(s)
+	end := len(s)
+	for end > 0 && isIdentChar(s[end-1]) {
+		end--
+	}
+	if word := s[end:]; word != "" && !isDigit(word[0]) && word != "true" && word != "false" && !srcTypeKeywords[word] {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// srcStatement is a statement rebuilt from the expressions of a function
+type srcStatement struct {
+	start int      // index of its first expression
+	lines []string // not indented by the blocks around it
+}
+
+// srcFunction rebuilds the statements of a function from its expressions.
+// The temporaries the parser declares for nested expressions are written
+// back into the expression that reads them, if it's the only one
+type srcFunction struct {
+	prgrm *CXProgram
+	fn    *CXFunction
+	exprs []*CXExpression
+
+	inline  map[string]bool        // temporaries written into their reader
+	packs   map[string]bool        // temporaries packing an array literal or the arguments of a variadic call
+	structs map[string]bool        // temporaries written by a struct literal, written into their reader
+	temps   map[string]*CXArgument // temporaries declared as variables
+
+	pending   map[string]string            // inlined temporaries waiting for their reader
+	pendingAt map[string]int               // the expressions writing them
+	packed    map[string][]string          // the elements of each pack
+	literals  map[string]*srcStructLiteral // the struct literals being rebuilt, by the variable they're assigned to
+	short     map[string]bool              // variables short declared, until they're assigned
+	declared  map[string]bool              // variables declared without a value
+	labels    map[int]string               // labels of the expressions jumped to
+	written   map[int]bool                 // labels already written
+	relabel   bool                         // labels were added for jumps of the last pass
+	cur       *CXPackage                   // the package of the expression being rebuilt
+
+	// onAssign receives the assignments of the function, instead of them
+	// being written
+	onAssign func(expr *CXExpression, value string)
+}
+
+func newSrcFunction(prgrm *CXProgram, fn *CXFunction) *srcFunction {
+	src := &srcFunction{
+		prgrm:   prgrm,
+		fn:      fn,
+		exprs:   fn.Expressions,
+		inline:  make(map[string]bool),
+		packs:   make(map[string]bool),
+		structs: make(map[string]bool),
+		temps:   make(map[string]*CXArgument),
+		labels:  make(map[int]string),
+	}
+	for i, expr := range src.exprs {
+		if expr.Label != "" && !isJump(expr) {
+			src.labels[i] = expr.Label
+		}
+	}
+	src.findTemps()
+	return src
+}
+
+// findTemps sorts the temporaries of the function by how they're used
+func (src *srcFunction) findTemps() {
+	writes := make(map[string]int)
+	otherWrites := make(map[string]int)
+	reads := make(map[string]int)
+	otherReads := make(map[string]int)
+	readers := make(map[string]*CXExpression)
+	readPos := make(map[string]int)
+	packWrites := make(map[string]int)
+	structWrites := make(map[string]int)
+	badPacks := make(map[string]bool)
+	decls := make(map[string]*CXArgument)
+	writers := make(map[string]*CXExpression)
+	firstWrites := make(map[string]*CXArgument)
+	var names []string
+	seen := make(map[string]bool)
+
+	var read func(arg *CXArgument, expr *CXExpression, pos int)
+	read = func(arg *CXArgument, expr *CXExpression, pos int) {
+		if isTempName(arg.Name) {
+			if isPlainArg(arg) {
+				reads[arg.Name]++
+				readers[arg.Name], readPos[arg.Name] = expr, pos
+			} else {
+				otherReads[arg.Name]++
+			}
+		}
+		for _, idx := range arg.Indexes {
+			read(idx, expr, -1)
+		}
+		for _, fld := range arg.Fields {
+			for _, idx := range fld.Indexes {
+				read(idx, expr, -1)
+			}
+		}
+	}
+
+	for _, expr := range src.exprs {
+		for i, inp := range expr.Inputs {
+			read(inp, expr, i)
+		}
+		for _, out := range expr.Outputs {
+			for _, idx := range out.Indexes {
+				read(idx, expr, -1)
+			}
+			for _, fld := range out.Fields {
+				for _, idx := range fld.Indexes {
+					read(idx, expr, -1)
+				}
+			}
+			if !isTempName(out.Name) {
+				continue
+			}
+			if !seen[out.Name] {
+				seen[out.Name] = true
+				names = append(names, out.Name)
+			}
+			if _, found := firstWrites[out.Name]; !found && expr.Operator != nil {
+				firstWrites[out.Name] = out
+			}
+			switch {
+			case expr.Operator == nil:
+				decls[out.Name] = out
+			case isPlainArg(out):
+				writes[out.Name]++
+				writers[out.Name] = expr
+			case isStructWrite(expr, out):
+				structWrites[out.Name]++
+			case src.isPackWrite(expr, out, packWrites[out.Name]):
+				packWrites[out.Name]++
+			default:
+				if src.isPackWrite(expr, out, -1) {
+					// written out of order
+					badPacks[out.Name] = true
+				}
+				otherWrites[out.Name]++
+			}
+		}
+	}
+
+	for _, name := range names {
+		switch {
+		case writes[name] == 1 && otherWrites[name]+packWrites[name]+structWrites[name]+otherReads[name] == 0 && reads[name] == 1 &&
+			len(writers[name].Outputs) == 1:
+			src.inline[name] = true
+		case structWrites[name] > 0 && writes[name]+otherWrites[name]+packWrites[name]+otherReads[name] == 0 && reads[name] == 1:
+			src.structs[name] = true
+		case writes[name]+otherWrites[name]+structWrites[name]+otherReads[name] == 0 && reads[name] == 1 && !badPacks[name] &&
+			src.isPackReader(readers[name], readPos[name]):
+			src.packs[name] = true
+		default:
+			decl := decls[name]
+			if decl == nil {
+				decl = firstWrites[name]
+			}
+			src.temps[name] = decl
+		}
+	}
+}
+
+// isStructWrite reports whether an expression assigns a field of a struct
+// literal
+func isStructWrite(expr *CXExpression, out *CXArgument) bool {
+	return expr.IsStructLiteral && expr.Operator != nil && expr.Operator.IsNative && expr.Operator.OpCode == OP_IDENTITY &&
+		len(expr.Inputs) == 1 && len(out.Fields) > 0
+}
+
+// isPackWrite reports whether an expression writes element `n` of a pack,
+// or any of its elements if `n` is negative
+func (src *srcFunction) isPackWrite(expr *CXExpression, out *CXArgument, n int) bool {
+	if expr.Operator == nil || !expr.Operator.IsNative || expr.Operator.OpCode != OP_IDENTITY {
+		return false
+	}
+	if len(out.Indexes) != 1 || len(out.Fields) != 0 || out.Indexes[0].Name != "" {
+		return false
+	}
+	return n < 0 || src.literal(out.Indexes[0]) == strconv.Itoa(n)
+}
+
+// isPackReader reports whether the input `pos` of an expression is written
+// as the elements of a pack
+func (src *srcFunction) isPackReader(expr *CXExpression, pos int) bool {
+	if expr == nil || expr.Operator == nil || pos < 0 {
+		return false
+	}
+	op := expr.Operator
+	if op.IsNative {
+		return op.OpCode == OP_IDENTITY && expr.IsArrayLiteral && pos == 0
+	}
+	return pos < len(op.Inputs) && op.Inputs[pos].IsVariadic
+}
+
+// write writes the declaration of the function
+func (src *srcFunction) write(buf *bytes.Buffer) {
+	pkg := src.fn.Package
+	fmt.Fprintf(buf, "func %s%s %s {\n", src.fn.Name, srcParameters(pkg, src.fn.Inputs), srcParameters(pkg, src.fn.Outputs))
+
+	var stmts []srcStatement
+	for pass := 0; pass == 0 || src.relabel; pass++ {
+		// jumps that aren't part of an if statement or a for loop need
+		// labels, which are added to the statements by another pass
+		src.reset()
+		stmts = src.block(0, len(src.exprs))
+	}
+
+	var temps []string
+	for name := range src.temps {
+		temps = append(temps, name)
+	}
+	sort.Slice(temps, func(i, j int) bool {
+		if len(temps[i]) != len(temps[j]) {
+			return len(temps[i]) < len(temps[j])
+		}
+		return temps[i] < temps[j]
+	})
+	for _, name := range temps {
+		if decl := src.temps[name]; decl != nil {
+			fmt.Fprintf(buf, "\tvar %s %s\n", src.tempName(name), srcTypeName(pkg, decl))
+		}
+	}
+
+	for _, stmt := range stmts {
+		for _, line := range stmt.lines {
+			buf.WriteString("\t" + line + "\n")
+		}
+	}
+	buf.WriteString("}\n")
+}
+
+// reset clears what was found by rebuilding statements
+func (src *srcFunction) reset() {
+	src.relabel = false
+	src.pending = make(map[string]string)
+	src.pendingAt = make(map[string]int)
+	src.packed = make(map[string][]string)
+	src.literals = make(map[string]*srcStructLiteral)
+	src.short = make(map[string]bool)
+	src.declared = make(map[string]bool)
+	src.written = make(map[int]bool)
+}
+
+// tempName returns the name of a temporary declared as a variable, as the
+// names of the parser's temporaries can't be written
+func (src *srcFunction) tempName(name string) string {
+	return strings.TrimPrefix(name, "*")
+}
+
+// label returns the label of expression `i`, adding one if it has none
+func (src *srcFunction) label(i int) string {
+	if label, ok := src.labels[i]; ok {
+		return label
+	}
+	label := "L" + strconv.Itoa(i)
+	src.labels[i] = label
+	src.relabel = true
+	return label
+}
+
+// jumpTo returns the statement jumping to expression `i`
+func (src *srcFunction) jumpTo(i int) string {
+	if i >= len(src.exprs) {
+		return "return"
+	}
+	return "goto " + src.label(i)
+}
+
+// backJump returns the last expression in [lo, hi) that always jumps back
+// to expression `lo`, which is where a for loop starts, or -1
+func (src *srcFunction) backJump(lo, hi int) int {
+	for j := hi - 1; j > lo; j-- {
+		if expr := src.exprs[j]; src.isUnconditional(expr) && expr.ThenLines < 0 && j+1+expr.ThenLines == lo {
+			return j
+		}
+	}
+	return -1
+}
+
+// isUnconditional reports whether a jump without a label always jumps
+// ThenLines ahead, as the last jump of a loop or of the then branch of an if
+func (src *srcFunction) isUnconditional(expr *CXExpression) bool {
+	return isJump(expr) && expr.Label == "" && len(expr.Inputs) > 0 && expr.Inputs[0].Name == "" && src.literal(expr.Inputs[0]) == "true"
+}
+
+// isCondition reports whether expressions [lo, hi) only compute the
+// condition of a loop, without statements
+func (src *srcFunction) isCondition(lo, hi int) bool {
+	for i := lo; i < hi; i++ {
+		expr := src.exprs[i]
+		if i > lo && src.labels[i] != "" {
+			return false
+		}
+		if isDeclaration(expr) {
+			return false
+		}
+		if expr.Operator == nil {
+			continue
+		}
+		if isJump(expr) || len(expr.Outputs) != 1 || !src.inline[expr.Outputs[0].Name] {
+			return false
+		}
+	}
+	return true
+}
+
+// block rebuilds the statements of expressions [lo, hi)
+func (src *srcFunction) block(lo, hi int) (stmts []srcStatement) {
+	add := func(start int, lines ...string) {
+		if len(lines) > 0 {
+			stmts = append(stmts, srcStatement{start: start, lines: lines})
+		}
+	}
+	nested := func(lines []string, block []srcStatement) []string {
+		for _, stmt := range block {
+			for _, line := range stmt.lines {
+				lines = append(lines, "\t"+line)
+			}
+		}
+		return lines
+	}
+	writeLabel := func(i int) {
+		if label, ok := src.labels[i]; ok && !src.written[i] {
+			src.written[i] = true
+			add(i, label+":")
+		}
+	}
+
+	for i := lo; i < hi; {
+		expr := src.exprs[i]
+		src.cur = src.pkg(expr)
+
+		if j := src.backJump(i, hi); j >= 0 {
+			// a for loop: its condition, then a jump past the loop if
+			// it's false, then its statements and the jump back
+			d := -1
+			for k := i; k < j; k++ {
+				if e := src.exprs[k]; isJump(e) && e.Label == "" && e.ThenLines == 0 && k+1+e.ElseLines == j+1 {
+					d = k
+					break
+				}
+			}
+			if d >= 0 && src.isCondition(i, d) {
+				writeLabel(i)
+				for k := i; k < d; k++ {
+					src.statement(k)
+				}
+				src.cur = src.pkg(src.exprs[d])
+				lines := []string{"for " + srcCondition(src.arg(src.exprs[d].Inputs[0])) + " {"}
+				lines = nested(lines, src.block(d+1, j))
+				add(i, append(lines, "}")...)
+				i = j + 1
+				continue
+			}
+		}
+
+		writeLabel(i)
+
+		if !isJump(expr) {
+			add(i, src.statement(i)...)
+			i++
+			continue
+		}
+
+		if expr.Label != "" {
+			if expr.Label == " " || expr.ThenLines == MAX_INT32 {
+				add(i, "return")
+			} else {
+				add(i, "goto "+expr.Label)
+			}
+			i++
+			continue
+		}
+
+		if len(expr.Inputs) == 0 {
+			add(i, src.jumpTo(i+1+expr.ThenLines))
+			i++
+			continue
+		}
+
+		if elseStart := i + 1 + expr.ElseLines; expr.ThenLines == 0 && elseStart > i+1 && elseStart <= hi {
+			// an if statement: a jump to its else branch if the condition
+			// is false, then its then branch, ending with a jump past
+			// the else branch
+			k := elseStart - 1
+			if skip := src.exprs[k]; k > i && src.isUnconditional(skip) && skip.ThenLines >= 0 && k+1+skip.ThenLines <= hi {
+				end := k + 1 + skip.ThenLines
+				lines := []string{"if " + srcCondition(src.arg(expr.Inputs[0])) + " {"}
+				lines = nested(lines, src.block(i+1, k))
+				if end > elseStart {
+					elseStmts := src.block(elseStart, end)
+					if len(elseStmts) == 1 && strings.HasPrefix(elseStmts[0].lines[0], "if ") {
+						lines = append(lines, "} else "+elseStmts[0].lines[0])
+						lines = append(lines, elseStmts[0].lines[1:]...)
+						add(i, lines...)
+						i = end
+						continue
+					}
+					lines = append(lines, "} else {")
+					lines = nested(lines, elseStmts)
+				}
+				add(i, append(lines, "}")...)
+				i = end
+				continue
+			}
+		}
+
+		if src.isUnconditional(expr) {
+			add(i, src.jumpTo(i+1+expr.ThenLines))
+			i++
+			continue
+		}
+
+		// any other jump
+		lines := []string{"if " + srcCondition(src.arg(expr.Inputs[0])) + " {"}
+		if expr.ThenLines != 0 {
+			lines = append(lines, "\t"+src.jumpTo(i+1+expr.ThenLines))
+		}
+		lines = append(lines, "} else {", "\t"+src.jumpTo(i+1+expr.ElseLines), "}")
+		add(i, lines...)
+		i++
+	}
+
+	// temporaries whose reader is in another block
+	var starts []int
+	flushed := make(map[int]string)
+	for name, i := range src.pendingAt {
+		if _, ok := src.pending[name]; ok && i >= lo && i < hi {
+			starts = append(starts, i)
+			flushed[i] = name
+		}
+	}
+	sort.Ints(starts)
+	for _, i := range starts {
+		name := flushed[i]
+		src.temps[name] = src.exprs[i].Outputs[0]
+		delete(src.inline, name)
+		add(i, src.tempName(name)+" = "+srcUnparen(src.pending[name]))
+		delete(src.pending, name)
+	}
+
+	return stmts
+}
+
+// statement rebuilds the non-jump expression `i`. It returns no lines if the
+// expression is written into a later one, or doesn't come from a statement
+func (src *srcFunction) statement(i int) []string {
+	expr := src.exprs[i]
+	src.cur = src.pkg(expr)
+
+	if expr.Operator == nil {
+		if !isDeclaration(expr) {
+			// e.g. the condition of a for loop, or what's left of a ++
+			return nil
+		}
+		out := expr.Outputs[0]
+		switch {
+		case isTempName(out.Name):
+			// declared with the other temporaries, if it isn't a pack
+		case expr.IsShortDeclaration:
+			// its type is inferred again by its assignment
+			src.short[out.Name] = true
+		default:
+			src.declared[out.Name] = true
+			return []string{"var " + out.Name + " " + srcTypeName(src.cur, out)}
+		}
+		return nil
+	}
+
+	if len(expr.Outputs) == 1 && isStructWrite(expr, expr.Outputs[0]) {
+		return src.structLiteral(i)
+	}
+
+	if len(expr.Outputs) == 1 {
+		out := expr.Outputs[0]
+		if src.inline[out.Name] {
+			src.pending[out.Name] = src.value(expr)
+			src.pendingAt[out.Name] = i
+			return nil
+		}
+		if src.packs[out.Name] {
+			src.packed[out.Name] = append(src.packed[out.Name], srcUnparen(src.arg(expr.Inputs[0])))
+			return nil
+		}
+	}
+
+	value := src.value(expr)
+	if len(expr.Outputs) == 0 {
+		return []string{value}
+	}
+
+	if src.onAssign != nil {
+		src.onAssign(expr, srcUnparen(value))
+		return nil
+	}
+
+	if out := expr.Outputs[0]; len(expr.Outputs) == 1 && out.IsLocalDeclaration && out.Typ != TypeNames[TYPE_IDENTIFIER] &&
+		isPlainArg(out) && !src.short[out.Name] && !src.declared[out.Name] {
+		// a declaration with a value, as `var x i32 = 5` has no
+		// expression of its own
+		return []string{"var " + out.Name + " " + srcTypeName(src.cur, out) + " = " + srcUnparen(value)}
+	}
+
+	var outs []string
+	assign := " = "
+	for _, out := range expr.Outputs {
+		if isPlainArg(out) && src.short[out.Name] {
+			delete(src.short, out.Name)
+			assign = " := "
+		}
+		outs = append(outs, src.arg(out))
+	}
+	return []string{strings.Join(outs, ", ") + assign + srcUnparen(value)}
+}
+
+// structLiteral adds the field assigned by expression `i` to the struct
+// literal it belongs to, which is written once all its fields are added
+func (src *srcFunction) structLiteral(i int) []string {
+	expr := src.exprs[i]
+	out := expr.Outputs[0]
+
+	// the fields of a nested struct literal are added from the innermost
+	var path []*CXArgument
+	for c := len(out.Fields) - 1; c >= 0; c-- {
+		path = append(path, out.Fields[c])
+	}
+
+	value := srcUnparen(src.arg(expr.Inputs[0]))
+	var lines []string
+	lit := src.literals[out.Name]
+	if lit != nil && (!src.sameTarget(lit.out, out) || !lit.add(path, value)) {
+		// then it's another literal
+		lines = src.assignStructLiteral(i, lit)
+		lit = nil
+	}
+	if lit == nil {
+		lit = &srcStructLiteral{out: out, strct: path[0].CustomType, values: make(map[string]interface{})}
+		lit.add(path, value)
+		src.literals[out.Name] = lit
+	}
+
+	next := i + 1
+	for next < len(src.exprs) && src.isInlined(src.exprs[next]) {
+		next++
+	}
+	if next < len(src.exprs) && src.labels[next] == "" && len(src.exprs[next].Outputs) == 1 &&
+		isStructWrite(src.exprs[next], src.exprs[next].Outputs[0]) && src.sameTarget(src.exprs[next].Outputs[0], out) {
+		return lines
+	}
+
+	delete(src.literals, out.Name)
+	return append(lines, src.assignStructLiteral(i, lit)...)
+}
+
+// sameTarget reports whether the fields of two struct literals are assigned
+// to the same variable, or to the same element of an array
+func (src *srcFunction) sameTarget(a, b *CXArgument) bool {
+	if a.Name != b.Name || len(a.Indexes) != len(b.Indexes) {
+		return false
+	}
+	for c := range a.Indexes {
+		x, y := a.Indexes[c], b.Indexes[c]
+		if x.Name != y.Name || isTempName(x.Name) || !isPlainArg(x) || !isPlainArg(y) {
+			return false
+		}
+		if x.Name == "" && src.literal(x) != src.literal(y) {
+			return false
+		}
+	}
+	return true
+}
+
+// isInlined reports whether an expression is written into a later one
+func (src *srcFunction) isInlined(expr *CXExpression) bool {
+	return expr.Operator != nil && len(expr.Outputs) == 1 && src.inline[expr.Outputs[0].Name]
+}
+
+// assignStructLiteral writes the assignment of a struct literal, which ends
+// at expression `i`
+func (src *srcFunction) assignStructLiteral(i int, lit *srcStructLiteral) []string {
+	out := lit.out
+	value := lit.source(src.cur)
+	if src.structs[out.Name] {
+		src.pending[out.Name] = value
+		src.pendingAt[out.Name] = i
+		return nil
+	}
+
+	strct := *out
+	strct.Fields = nil
+	strct.DereferenceOperations = nil
+	for _, deref := range out.DereferenceOperations {
+		if deref != DEREF_FIELD {
+			strct.DereferenceOperations = append(strct.DereferenceOperations, deref)
+		}
+	}
+
+	assign := " = "
+	if isPlainArg(&strct) && src.short[strct.Name] {
+		delete(src.short, strct.Name)
+		assign = " := "
+	}
+	return []string{src.arg(&strct) + assign + value}
+}
+
+// srcStructLiteral is a struct literal rebuilt from the assignments of its
+// fields
+type srcStructLiteral struct {
+	out    *CXArgument // the first field assigned
+	strct  *CXStruct
+	fields []string
+	values map[string]interface{} // the source of a value, or a nested *srcStructLiteral
+}
+
+// add sets the value of the field at `path`, and reports whether it wasn't
+// set yet
+func (lit *srcStructLiteral) add(path []*CXArgument, value string) bool {
+	name := path[0].Name
+	prev, found := lit.values[name]
+	if len(path) == 1 {
+		if found {
+			return false
+		}
+		lit.fields = append(lit.fields, name)
+		lit.values[name] = value
+		return true
+	}
+
+	nested, ok := prev.(*srcStructLiteral)
+	if found && !ok {
+		return false
+	}
+	if !found {
+		nested = &srcStructLiteral{strct: path[1].CustomType, values: make(map[string]interface{})}
+		lit.fields = append(lit.fields, name)
+		lit.values[name] = nested
+	}
+	return nested.add(path[1:], value)
+}
+
+// source returns the struct literal as written in package `pkg`
+func (lit *srcStructLiteral) source(pkg *CXPackage) string {
+	var name string
+	if lit.strct != nil {
+		name = lit.strct.Name
+		if lit.strct.Package != nil && pkg != nil && lit.strct.Package.Name != pkg.Name {
+			name = lit.strct.Package.Name + "." + name
+		}
+	}
+
+	var fields []string
+	for _, fld := range lit.fields {
+		switch value := lit.values[fld].(type) {
+		case string:
+			fields = append(fields, fld+": "+value)
+		case *srcStructLiteral:
+			fields = append(fields, fld+": "+value.source(pkg))
+		}
+	}
+	return name + "{" + strings.Join(fields, ", ") + "}"
+}
+
+// pkg returns the package whose source an expression is written in
+func (src *srcFunction) pkg(expr *CXExpression) *CXPackage {
+	if expr.Package != nil {
+		return expr.Package
+	}
+	return src.fn.Package
+}
+
+// value rebuilds the call or the operation of an expression
+func (src *srcFunction) value(expr *CXExpression) string {
+	op := expr.Operator
+
+	var args []string
+	for c := 0; c < len(expr.Inputs); c++ {
+		inp := expr.Inputs[c]
+		if !op.IsNative && c < len(op.Inputs) && op.Inputs[c].IsVariadic {
+			if src.packs[inp.Name] {
+				args = append(args, src.packed[inp.Name]...)
+				delete(src.packed, inp.Name)
+			} else {
+				args = append(args, srcUnparen(src.arg(inp))+"...")
+			}
+			if c+1 < len(expr.Inputs) {
+				// the number of arguments packed
+				src.arg(expr.Inputs[c+1])
+				c++
+			}
+			continue
+		}
+		args = append(args, src.arg(inp))
+	}
+
+	if op.IsNative {
+		if sym, ok := srcOperators[op.OpCode]; ok && len(args) == 2 {
+			return "(" + args[0] + " " + sym + " " + args[1] + ")"
+		}
+		switch {
+		case op.OpCode == OP_BOOL_NOT && len(args) == 1 && !isTempName(expr.Inputs[0].Name):
+			// ! replaces the expression of its operand, so it can only
+			// negate a name or a literal
+			return "!" + args[0]
+		case op.OpCode == OP_IDENTITY && len(args) == 1:
+			return args[0]
+		}
+	}
+
+	for c := range args {
+		args[c] = srcUnparen(args[c])
+	}
+	return src.funcName(expr) + "(" + strings.Join(args, ", ") + ")"
+}
+
+// funcName returns the name an expression calls its operator by
+func (src *srcFunction) funcName(expr *CXExpression) string {
+	op := expr.Operator
+	if op.IsNative {
+		return OpNames[op.OpCode]
+	}
+	if op.Package != nil && src.cur != nil && op.Package.Name != src.cur.Name {
+		return op.Package.Name + "." + op.Name
+	}
+	return op.Name
+}
+
+// arg rebuilds an argument of an expression
+func (src *srcFunction) arg(arg *CXArgument) string {
+	if arg.Name == "" {
+		return src.literal(arg)
+	}
+
+	if isPlainArg(arg) {
+		if value, ok := src.pending[arg.Name]; ok {
+			delete(src.pending, arg.Name)
+			return value
+		}
+		if elts, ok := src.packed[arg.Name]; ok || src.packs[arg.Name] {
+			// an array literal
+			delete(src.packed, arg.Name)
+			return srcTypeName(src.cur, arg) + "{" + strings.Join(elts, ", ") + "}"
+		}
+	}
+
+	name := arg.Name
+	if isTempName(name) {
+		name = src.tempName(name)
+	} else if arg.Package != nil && src.cur != nil && arg.Package.Name != src.cur.Name {
+		name = arg.Package.Name + "." + name
+	}
+
+	var idx, fld int
+	for c, deref := range arg.DereferenceOperations {
+		switch deref {
+		case DEREF_ARRAY:
+			if idx < len(arg.Indexes) {
+				name += "[" + srcUnparen(src.arg(arg.Indexes[idx])) + "]"
+				idx++
+			}
+		case DEREF_FIELD:
+			if fld < len(arg.Fields) {
+				name += src.field(arg.Fields[fld])
+				fld++
+			}
+		case DEREF_POINTER:
+			name = "*" + name
+			if c < len(arg.DereferenceOperations)-1 {
+				name = "(" + name + ")"
+			}
+		}
+	}
+	for ; idx < len(arg.Indexes); idx++ {
+		name += "[" + srcUnparen(src.arg(arg.Indexes[idx])) + "]"
+	}
+	for ; fld < len(arg.Fields); fld++ {
+		name += src.field(arg.Fields[fld])
+	}
+
+	if arg.IsReference {
+		name = "&" + name
+	}
+	return name
+}
+
+// field rebuilds the access to a field, with its indexes
+func (src *srcFunction) field(fld *CXArgument) string {
+	name := "." + fld.Name
+	for _, idx := range fld.Indexes {
+		name += "[" + srcUnparen(src.arg(idx)) + "]"
+	}
+	return name
+}
+
+// literal rebuilds a literal from the data segment, or from its value if
+// it has none there, as a string literal only has its pointer there
+func (src *srcFunction) literal(arg *CXArgument) string {
+	var byts []byte
+	if arg.Type != TYPE_STR && arg.MemoryRead == MEM_DATA && arg.Offset+arg.Size <= len(src.prgrm.Data) {
+		byts = src.prgrm.Data[arg.Offset : arg.Offset+arg.Size]
+	} else if arg.Value != nil {
+		byts = *arg.Value
+	}
+
+	switch arg.Type {
+	case TYPE_STR:
+		var str string
+		encoder.DeserializeRaw(byts, &str)
+		return `"` + str + `"`
+	case TYPE_F32, TYPE_F64:
+		if len(byts) < GetArgSize(arg.Type) {
+			return ""
+		}
+		var f float64
+		if arg.Type == TYPE_F32 {
+			var f32 float32
+			encoder.DeserializeRaw(byts[:4], &f32)
+			f = float64(f32)
+		} else {
+			encoder.DeserializeRaw(byts[:8], &f)
+		}
+		num := strconv.FormatFloat(f, 'f', -1, 64)
+		if arg.Type == TYPE_F32 {
+			num = strconv.FormatFloat(f, 'f', -1, 32)
+		}
+		if !strings.Contains(num, ".") {
+			num += ".0"
+		}
+		return num + srcLiteralSuffixes[arg.Type]
+	}
+
+	return formatBasic(byts, arg.Type) + srcLiteralSuffixes[arg.Type]
+}
@@ -0,0 +1,75 @@
+package base
+
+import "testing"
+
+func TestToSource(t *testing.T) {
+	want := `package main
+
+var total i32
+var pair [2]i32
+
+func add (a i32, b i32) (c i32) {
+	c = i32.add(a, b)
+}
+
+func main () () {
+	var x i32
+	x = add(2, 3)
+	x = add(x, 1)
+	total = x
+}
+`
+	if src := makeAddProgram().ToSource(); src != want {
+		t.Errorf("got the source\n%s\nwant\n%s", src, want)
+	}
+}
+
+// TestToSourceIfElse checks that the jumps of an if/else statement are
+// turned back into one, the program being
+//
+//	func sign(n i32) (r i32) {
+//		if n < 0 {
+//			r = -1
+//		} else {
+//			r = 1
+//		}
+//	}
+func TestToSourceIfElse(t *testing.T) {
+	prgrm, main := makeTestProgram()
+
+	sign := MakeFunction("sign")
+	main.AddFunction(sign)
+	n, r, pred := testLocal("n", TYPE_I32, 0), testLocal("r", TYPE_I32, 4), testLocal("*lcl_0", TYPE_BOOL, 8)
+	sign.Inputs, sign.Outputs = []*CXArgument{n}, []*CXArgument{r}
+
+	lt := MakeExpression(Natives[OP_UND_LT], "sign.cx", 2)
+	lt.AddInput(n).AddInput(testData(prgrm, "", TYPE_I32, FromI32(0))).AddOutput(pred)
+	ifExpr := MakeExpression(Natives[OP_JMP], "sign.cx", 2)
+	ifExpr.AddInput(pred)
+	ifExpr.ThenLines, ifExpr.ElseLines = 0, 2
+	negative := MakeExpression(Natives[OP_IDENTITY], "sign.cx", 3)
+	negative.AddInput(testData(prgrm, "", TYPE_I32, FromI32(-1))).AddOutput(r)
+	skip := MakeExpression(Natives[OP_JMP], "sign.cx", 4)
+	skip.AddInput(testData(prgrm, "", TYPE_BOOL, FromBool(true)))
+	skip.ThenLines = 1
+	positive := MakeExpression(Natives[OP_IDENTITY], "sign.cx", 5)
+	positive.AddInput(testData(prgrm, "", TYPE_I32, FromI32(1))).AddOutput(r)
+	for _, expr := range []*CXExpression{lt, ifExpr, negative, skip, positive} {
+		sign.AddExpression(expr)
+	}
+	sign.Length, sign.Size = len(sign.Expressions), 9
+
+	want := `package main
+
+func sign (n i32) (r i32) {
+	if n < 0 {
+		r = -1
+	} else {
+		r = 1
+	}
+}
+`
+	if src := prgrm.ToSource(); src != want {
+		t.Errorf("got the source\n%s\nwant\n%s", src, want)
+	}
+}
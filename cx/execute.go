@@ -131,43 +131,11 @@ func (prgrm *CXProgram) UnRun(nCalls int) {
 }
 
 // Compiling from CXGO to CX Base
-func (prgrm *CXProgram) Compile(withProfiling bool) {
+func (prgrm *CXProgram) Compile() {
 	var asmNL string = "\n"
 	var program bytes.Buffer
 
-	if withProfiling {
-		program.WriteString(`package main;
-
-import (
-. "github.com/skycoin/cx/src/base"
-"os"
-	"log"
-	"flag"
-        "runtime"
-	"runtime/pprof"
-);
-
-var prgrm = MakeContext();var mod *CXModule;var imp *CXModule;var fn *CXFunction;var op *CXFunction;var expr *CXExpression;var strct *CXStruct;var fld *CXField;var arg *CXArgument;var tag string = "";
-
-var cpuprofile = flag.String("cpuprofile", "", "write cpu profile 'file'")
-var memprofile = flag.String("memprofile", "", "write memory profile to 'file'")
-
-func main () {
-	runtime.LockOSThread()
-
-	flag.Parse()
-	if *cpuprofile != "" {
-		f, err := os.Create(*cpuprofile)
-		if err != nil {
-			log.Fatal(err)
-		}
-		pprof.StartCPUProfile(f)
-		defer pprof.StopCPUProfile()
-	}
-`)
-	} else {
-		program.WriteString(`package main;import (. github.com/skycoin/cx/src/base"; "runtime";);var prgrm = MakeContext();var mod *CXModule;var imp *CXModule;var fn *CXFunction;var op *CXFunction;var expr *CXExpression;var strct *CXStruct;var arg *CXArgument;var tag string = "";func main () {runtime.LockOSThread();`)
-	}
+	program.WriteString(`package main;import (. github.com/skycoin/cx/src/base"; "runtime";);var prgrm = MakeContext();var mod *CXModule;var imp *CXModule;var fn *CXFunction;var op *CXFunction;var expr *CXExpression;var strct *CXStruct;var arg *CXArgument;var tag string = "";func main () {runtime.LockOSThread();`)
 
 	for _, mod := range prgrm.Packages {
 		program.WriteString(fmt.Sprintf(`mod = MakeModule("%s");prgrm.AddModule(mod);%s`, mod.Name, asmNL))
@@ -262,20 +230,6 @@ func main () {
 		}
 	}
 
-	program.WriteString(`
-if *memprofile != "" {
-        f, err := os.Create(*memprofile)
-        if err != nil {
-            log.Fatal("could not create memory profile: ", err)
-        }
-        runtime.GC() // get up-to-date statistics
-        if err := pprof.WriteHeapProfile(f); err != nil {
-            log.Fatal("could not write memory profile: ", err)
-        }
-        f.Close()
-    }
-`)
-
 	program.WriteString(`prgrm.Run(false, -1);}`)
 	ioutil.WriteFile(fmt.Sprintf("o.go"), []byte(program.String()), 0644)
 }
@@ -759,11 +713,11 @@ func checkNative(opName string, expr *CXExpression, call *CXCall, argsCopy *[]*C
 		isTesting = false
 	case "test.error":
 		//fmt.Println(isErrorPresent)
-		err = test_error((*argsCopy)[0], isErrorPresent, expr)
+		err = test_error(call.Program, (*argsCopy)[0], isErrorPresent, expr)
 		isErrorPresent = false
 		// case "test.bool", "test.byte", "test.str", "test.i32", "test.i64", "test.f32", "test.f64", "test.[]bool", "test.[]byte", "test.[]str", "test.[]i32", "test.[]f32", "test.[]f64":
 	case "test":
-		err = test_value((*argsCopy)[0], (*argsCopy)[1], (*argsCopy)[2], expr)
+		err = test_value(call.Program, (*argsCopy)[0], (*argsCopy)[1], (*argsCopy)[2], expr)
 		// multi dimensional array functions
 	case "mdim.append":
 		err = mdim_append((*argsCopy)[0], (*argsCopy)[1], expr, call)
@@ -1178,6 +1132,9 @@ func (call *CXCall) icall(withDebug bool, nCalls, callCounter int) error {
 		fn := call.Operator
 
 		if expr, err := fn.GetExpression(call.Line); err == nil {
+			if call.Program.Coverage != nil {
+				call.Program.Coverage[expr]++
+			}
 			if expr.Operator == nil {
 				// then it's a declaration
 				call.State = append(call.State, expr.Outputs[0])
@@ -1353,6 +1310,9 @@ func (prgrm *CXProgram) RunCompiled() error {
 			mainCall := MakeCall(fn, nil, nil, mod, mod.Program)
 			prgrm.CallStack[0] = mainCall
 			prgrm.Stacks[0].StackPointer = fn.Size
+			if prgrm.Tracer != nil {
+				prgrm.Tracer.enter(fn, nil)
+			}
 
 			var err error
 
@@ -1363,6 +1323,10 @@ func (prgrm *CXProgram) RunCompiled() error {
 					return err
 				}
 			}
+			if prgrm.Exited {
+				// os.Exit was called by the initialization of a global
+				return nil
+			}
 			// we reset call state
 			prgrm.Terminated = false
 			prgrm.CallCounter = 0
@@ -1381,6 +1345,9 @@ func (prgrm *CXProgram) RunCompiled() error {
 			prgrm.CallStack[0] = mainCall
 			// prgrm.Stacks = append(prgrm.Stacks, MakeStack(1024))
 			prgrm.Stacks[0].StackPointer = fn.Size
+			if prgrm.Tracer != nil {
+				prgrm.Tracer.enter(fn, nil)
+			}
 
 			var err error
 
@@ -1391,6 +1358,11 @@ func (prgrm *CXProgram) RunCompiled() error {
 					return err
 				}
 			}
+
+			// main's i32 output is the program's exit status
+			if !prgrm.Exited && len(fn.Outputs) == 1 && fn.Outputs[0].Type == TYPE_I32 {
+				prgrm.ExitCode = int(ReadI32(&prgrm.Stacks[0], 0, fn.Outputs[0]))
+			}
 			
 			// debugging memory
 			// fmt.Println("prgrm.Stack", prgrm.Stacks[0].Stack)
@@ -1405,6 +1377,108 @@ func (prgrm *CXProgram) RunCompiled() error {
 	}
 }
 
+// CallFunction runs `fn` to completion from a native, such as a callback of
+// an HTTP server. The call is made on top of the current call stack, so the
+// running functions are left untouched. `setInputs` writes the inputs of `fn`
+// to its stack frame at `fp`, and `getOutputs` reads its outputs from it
+// before the frame is discarded. If `fn` calls os.Exit its outputs aren't
+// read and the program stays terminated
+func (prgrm *CXProgram) CallFunction(fn *CXFunction, setInputs func(fp int), getOutputs func(fp int)) (err error) {
+	stack := &prgrm.Stacks[0]
+	callCounter, callBase, stackPointer := prgrm.CallCounter, prgrm.CallBase, stack.StackPointer
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: %v", fn.Name, r)
+		}
+		prgrm.CallCounter, prgrm.CallBase, stack.StackPointer = callCounter, callBase, stackPointer
+		prgrm.Terminated = prgrm.Exited
+	}()
+
+	if callCounter+1 >= len(prgrm.CallStack) || stackPointer+fn.Size > len(stack.Stack) {
+		return fmt.Errorf("%s: stack overflow", fn.Name)
+	}
+
+	fp := stackPointer
+	for c := 0; c < fn.Size; c++ {
+		stack.Stack[fp+c] = 0
+	}
+
+	call := MakeCall(fn, nil, nil, fn.Package, prgrm)
+	call.FramePointer = fp
+
+	prgrm.CallCounter++
+	prgrm.CallBase = prgrm.CallCounter
+	prgrm.CallStack[prgrm.CallCounter] = call
+	stack.StackPointer = fp + fn.Size
+	if prgrm.Tracer != nil {
+		prgrm.Tracer.enter(fn, nil)
+	}
+
+	setInputs(fp)
+
+	for !prgrm.Terminated {
+		if err = prgrm.CallStack[prgrm.CallCounter].ccall(prgrm); err != nil {
+			return err
+		}
+	}
+
+	if !prgrm.Exited {
+		getOutputs(fp)
+	}
+
+	return nil
+}
+
+// RunTest initializes the program's globals and runs the test function `fn`,
+// which takes no inputs. The assertions that fail are recorded in
+// prgrm.TestFailures, and a runtime error, including a panic, is returned
+func (prgrm *CXProgram) RunTest(fn *CXFunction) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: %v", fn.Name, r)
+		}
+	}()
+
+	isTesting, isErrorPresent = false, false
+
+	mod, err := prgrm.SelectPackage(MAIN_PKG)
+	if err != nil {
+		return err
+	}
+	initFn, err := mod.SelectFunction(SYS_INIT_FUNC)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range []*CXFunction{initFn, fn} {
+		prgrm.CallStack[0] = MakeCall(f, nil, nil, f.Package, prgrm)
+		prgrm.CallCounter = 0
+		prgrm.Stacks[0].StackPointer = f.Size
+		prgrm.Terminated = false
+
+		for !prgrm.Terminated {
+			call := &prgrm.CallStack[prgrm.CallCounter]
+			if err = call.ccall(prgrm); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// execTestedNative runs a native between test.start and test.stop, where a
+// runtime error doesn't stop the program but is checked by test.error
+func execTestedNative(prgrm *CXProgram) {
+	defer func() {
+		if r := recover(); r != nil {
+			isErrorPresent = true
+		}
+	}()
+	execNative(prgrm)
+}
+
 func (call *CXCall) ccall(prgrm *CXProgram) error {
 	// GetAllObjects(prgrm)
 	// fmt.Println(prgrm.Stacks[0].Stack)
@@ -1413,9 +1487,12 @@ func (call *CXCall) ccall(prgrm *CXProgram) error {
 		/*
 		   popping the stack
 		*/
+		if prgrm.Tracer != nil {
+			prgrm.Tracer.exit()
+		}
 		// going back to the previous call
 		prgrm.CallCounter--
-		if prgrm.CallCounter < 0 {
+		if prgrm.CallCounter < prgrm.CallBase {
 			// then the program finished
 			prgrm.Terminated = true
 		} else {
@@ -1451,12 +1528,25 @@ func (call *CXCall) ccall(prgrm *CXProgram) error {
 		*/
 		fn := call.Operator
 		expr := fn.Expressions[call.Line]
+		if prgrm.Debugger != nil && prgrm.Debugger.pause(prgrm, call, expr) {
+			return nil
+		}
+		if prgrm.Coverage != nil {
+			prgrm.Coverage[expr]++
+		}
+		if prgrm.Profiler != nil {
+			prgrm.Profiler.tick(prgrm)
+		}
 		// if it's a native, then we just process the arguments with execNative
 		if expr.Operator == nil {
 			// then it's a declaration
 			call.Line++
 		} else if expr.Operator.IsNative {
-			execNative(prgrm)
+			if isTesting {
+				execTestedNative(prgrm)
+			} else {
+				execNative(prgrm)
+			}
 			call.Line++
 		} else {
 			/*
@@ -1473,6 +1563,9 @@ func (call *CXCall) ccall(prgrm *CXProgram) error {
 			// the stack pointer is moved to create room for the next call
 			// prgrm.Stacks[0].StackPointer += fn.Size
 			prgrm.Stacks[0].StackPointer += newCall.Operator.Size
+			if prgrm.Tracer != nil {
+				prgrm.Tracer.enter(newCall.Operator, expr)
+			}
 
 			fp := call.FramePointer
 			newFP := newCall.FramePointer
@@ -0,0 +1,85 @@
+package base
+
+// makeTestProgram makes a program with an empty main package, to which the
+// tests add functions assembled by hand, as the parser compiles them
+func makeTestProgram() (*CXProgram, *CXPackage) {
+	prgrm := MakeProgram(CALLSTACK_SIZE, STACK_SIZE, INIT_HEAP_SIZE)
+	main := MakePackage(MAIN_PKG)
+	prgrm.AddPackage(main)
+	main.AddFunction(MakeFunction(SYS_INIT_FUNC))
+	return prgrm, main
+}
+
+// testLocal makes a local variable of type `typ` at `offset` in its frame
+func testLocal(name string, typ int, offset int) *CXArgument {
+	arg := MakeCoreArgument(name, typ)
+	arg.Offset = offset
+	return arg
+}
+
+// testData makes a global variable, or a literal if `name` is empty, stored
+// in the data segment with the bytes `value`
+func testData(prgrm *CXProgram, name string, typ int, value []byte) *CXArgument {
+	arg := MakeCoreArgument(name, typ)
+	arg.Offset = len(prgrm.Data)
+	arg.MemoryRead, arg.MemoryWrite = MEM_DATA, MEM_DATA
+	prgrm.Data = append(prgrm.Data, value...)
+	return arg
+}
+
+// makeAddProgram builds the program of add.cx:
+//
+//	 1	package main
+//	 2
+//	 3	var total i32
+//	 4	var pair [2]i32 // 7, 9
+//	 5	func add(a i32, b i32) (c i32) {
+//	 6		c = a + b
+//	 7	}
+//	 8
+//	 9	func main() {
+//	10		var x i32
+//	11		x = add(2, 3)
+//	12		x = add(x, 1)
+//	13		total = x
+//	14	}
+func makeAddProgram() *CXProgram {
+	prgrm, main := makeTestProgram()
+
+	total := testData(prgrm, "total", TYPE_I32, FromI32(0))
+	main.AddGlobal(total)
+	pair := testData(prgrm, "pair", TYPE_I32, FromI32(7))
+	testData(prgrm, "", TYPE_I32, FromI32(9))
+	pair.DeclarationSpecifiers = []int{DECL_BASIC, DECL_ARRAY}
+	pair.Lengths = []int{2}
+	pair.TotalSize = 2 * pair.Size
+	main.AddGlobal(pair)
+
+	add := MakeFunction("add")
+	main.AddFunction(add)
+	a, b, c := testLocal("a", TYPE_I32, 0), testLocal("b", TYPE_I32, 4), testLocal("c", TYPE_I32, 8)
+	add.Inputs, add.Outputs = []*CXArgument{a, b}, []*CXArgument{c}
+	sum := MakeExpression(Natives[OP_I32_ADD], "add.cx", 6)
+	sum.AddInput(a).AddInput(b).AddOutput(c)
+	add.AddExpression(sum)
+	add.Length, add.Size = len(add.Expressions), 12
+
+	mainFn := MakeFunction(MAIN_FUNC)
+	main.AddFunction(mainFn)
+	x := testLocal("x", TYPE_I32, 0)
+	x.IsLocalDeclaration = true
+	decl := MakeExpression(nil, "add.cx", 10)
+	decl.AddOutput(x)
+	call1 := MakeExpression(add, "add.cx", 11)
+	call1.AddInput(testData(prgrm, "", TYPE_I32, FromI32(2))).AddInput(testData(prgrm, "", TYPE_I32, FromI32(3))).AddOutput(x)
+	call2 := MakeExpression(add, "add.cx", 12)
+	call2.AddInput(x).AddInput(testData(prgrm, "", TYPE_I32, FromI32(1))).AddOutput(x)
+	assign := MakeExpression(Natives[OP_IDENTITY], "add.cx", 13)
+	assign.AddInput(x).AddOutput(total)
+	for _, expr := range []*CXExpression{decl, call1, call2, assign} {
+		mainFn.AddExpression(expr)
+	}
+	mainFn.Length, mainFn.Size = len(mainFn.Expressions), 4
+
+	return prgrm
+}
@@ -0,0 +1,622 @@
+package base
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+// maximum number of pointers followed when formatting a value with its type
+// names. Deeper pointers are printed as addresses, which also stops cycles
+const FORMAT_MAX_DEPTH = 8
+
+// valueType describes the layout of a value in memory, so it can be walked
+// when printing it or comparing it
+type valueType struct {
+	specs   []int // declaration specifiers, from the outermost to the base type
+	lengths []int // lengths of the arrays and slices in specs
+	typ     int
+	strct   *CXStruct
+}
+
+// DeclarationStruct returns the struct type of a declaration. Struct fields
+// have their containing struct as CustomType, so their own type is found by
+// name
+func DeclarationStruct(decl *CXArgument) *CXStruct {
+	strct := decl.CustomType
+	if strct == nil || decl.Typ == "" || strct.Name == decl.Typ || strct.Program == nil || strct.Package == nil {
+		return strct
+	}
+	if fldStrct, err := strct.Program.GetStruct(decl.Typ, strct.Package.Name); err == nil {
+		return fldStrct
+	}
+	return strct
+}
+
+// declValueType builds the layout of a declared variable, parameter or field.
+// Literals and temporaries don't carry declaration specifiers, so their
+// layout is built from their lengths and type
+func declValueType(decl *CXArgument) (t valueType) {
+	for c := len(decl.DeclarationSpecifiers) - 1; c >= 0; c-- {
+		t.specs = append(t.specs, decl.DeclarationSpecifiers[c])
+	}
+
+	if n := len(t.specs); decl.Type == TYPE_STR && n > 1 && t.specs[n-2] == DECL_POINTER && t.specs[n-1] == DECL_BASIC {
+		// the first pass declares strings as pointers to a basic str
+		t.specs = t.specs[:n-1]
+	}
+
+	if len(t.specs) == 0 {
+		for range decl.Lengths {
+			t.specs = append(t.specs, DECL_ARRAY)
+		}
+		if _, isBasic := TypeCodes[decl.Typ]; decl.CustomType != nil && !isBasic {
+			t.specs = append(t.specs, DECL_STRUCT)
+		} else if decl.Type == TYPE_STR {
+			// strings are declared as pointers to their heap object
+			t.specs = append(t.specs, DECL_POINTER)
+		} else {
+			t.specs = append(t.specs, DECL_BASIC)
+		}
+	}
+
+	t.lengths = decl.Lengths
+	t.typ = decl.Type
+	if t.specs[len(t.specs)-1] == DECL_STRUCT {
+		t.strct = DeclarationStruct(decl)
+	}
+
+	return t
+}
+
+// argValueType returns the layout of the value an argument reads, once its
+// field accesses, indexes and dereferences are applied
+func argValueType(arg *CXArgument) valueType {
+	if len(arg.Fields) > 0 {
+		fld := arg.Fields[len(arg.Fields)-1]
+		if fld.CustomType != nil {
+			if def, err := fld.CustomType.GetField(fld.Name); err == nil {
+				t := declValueType(def)
+				for c := 0; c < len(fld.Indexes) && !t.isBase(); c++ {
+					t = t.elem()
+				}
+				return t
+			}
+		}
+	}
+
+	t := declValueType(arg)
+	for c := 0; c < len(arg.Indexes) && !t.isBase(); c++ {
+		t = t.elem()
+	}
+	for c := 0; c < arg.DereferenceLevels && !t.isBase(); c++ {
+		t = t.elem()
+	}
+
+	return t
+}
+
+// isBasic reports whether the value is of a basic type or a string
+func (t valueType) isBasic() bool {
+	return t.isBase() && t.specs[0] != DECL_STRUCT
+}
+
+// isBase reports whether there are no arrays or pointers left to walk
+func (t valueType) isBase() bool {
+	return len(t.specs) < 2
+}
+
+// isComposite reports whether the value is a struct, an array or a slice,
+// which are compared byte by byte
+func (t valueType) isComposite() bool {
+	switch t.specs[0] {
+	case DECL_STRUCT, DECL_ARRAY, DECL_SLICE:
+		return true
+	}
+	return false
+}
+
+// elem returns the layout of the elements of an array or slice, or the layout
+// of the value a pointer points to
+func (t valueType) elem() valueType {
+	elt := t
+	if t.specs[0] == DECL_ARRAY || t.specs[0] == DECL_SLICE {
+		elt.lengths = t.lengths[1:]
+	}
+	elt.specs = t.specs[1:]
+	return elt
+}
+
+// size returns the number of bytes the value occupies
+func (t valueType) size() int {
+	switch t.specs[0] {
+	case DECL_POINTER:
+		return TYPE_POINTER_SIZE
+	case DECL_ARRAY, DECL_SLICE:
+		return t.lengths[0] * t.elem().size()
+	case DECL_STRUCT:
+		if t.strct != nil {
+			return t.strct.Size
+		}
+		return 0
+	default:
+		return GetArgSize(t.typ)
+	}
+}
+
+// name returns the type name as written in a declaration, e.g. []*Point
+func (t valueType) name() string {
+	var name string
+	var arrays int
+	for c, spec := range t.specs {
+		switch spec {
+		case DECL_ARRAY:
+			name += "[" + strconv.Itoa(t.lengths[arrays]) + "]"
+			arrays++
+		case DECL_SLICE:
+			name += "[]"
+			arrays++
+		case DECL_POINTER:
+			if c < len(t.specs)-1 || t.typ != TYPE_STR {
+				name += "*"
+			} else {
+				name += TypeNames[TYPE_STR]
+			}
+		case DECL_STRUCT:
+			if t.strct != nil {
+				name += t.strct.Name
+			}
+		case DECL_BASIC:
+			name += TypeNames[t.typ]
+		}
+	}
+	return name
+}
+
+// compositeEqual compares two structs, arrays or slices element by element
+func compositeEqual(stack *CXStack, fp int, inp1, inp2 *CXArgument) bool {
+	byts1 := ReadMemory(stack, GetFinalOffset(stack, fp, inp1, MEM_READ), inp1)
+	byts2 := ReadMemory(stack, GetFinalOffset(stack, fp, inp2, MEM_READ), inp2)
+	return bytesEqual(stack.Program, byts1, byts2, argValueType(inp1))
+}
+
+// bytesEqual compares the values stored in `byts1` and `byts2` with layout
+// `t`. Strings are compared by value, as equal strings can be stored in
+// different heap objects, and other pointers by address
+func bytesEqual(prgrm *CXProgram, byts1, byts2 []byte, t valueType) bool {
+	if len(byts1) != len(byts2) {
+		return false
+	}
+
+	switch t.specs[0] {
+	case DECL_ARRAY, DECL_SLICE:
+		elt := t.elem()
+		eltSize := elt.size()
+		for c := 0; c < t.lengths[0] && (c+1)*eltSize <= len(byts1); c++ {
+			if !bytesEqual(prgrm, byts1[c*eltSize:(c+1)*eltSize], byts2[c*eltSize:(c+1)*eltSize], elt) {
+				return false
+			}
+		}
+		return true
+	case DECL_STRUCT:
+		if t.strct == nil {
+			return bytes.Equal(byts1, byts2)
+		}
+
+		var offset int
+		for _, fld := range t.strct.Fields {
+			if offset+fld.TotalSize > len(byts1) {
+				break
+			}
+			if !bytesEqual(prgrm, byts1[offset:offset+fld.TotalSize], byts2[offset:offset+fld.TotalSize], declValueType(fld)) {
+				return false
+			}
+			offset += fld.TotalSize
+		}
+		return true
+	case DECL_POINTER:
+		if t.isBase() && t.typ == TYPE_STR && len(byts1) >= TYPE_POINTER_SIZE {
+			return heapStr(prgrm, byts1) == heapStr(prgrm, byts2)
+		}
+	}
+
+	return bytes.Equal(byts1, byts2)
+}
+
+// heapStr reads the string whose heap offset is stored in `byts`. A null
+// string is empty
+func heapStr(prgrm *CXProgram, byts []byte) string {
+	var heapOffset int32
+	encoder.DeserializeAtomic(byts[:TYPE_POINTER_SIZE], &heapOffset)
+	if heapOffset == NULL_HEAP_ADDRESS {
+		return ""
+	}
+	return readStrFromHeap(prgrm, heapOffset)
+}
+
+// isCompositeArg reports whether an argument of == or != reads a struct, an
+// array or a slice
+func isCompositeArg(arg *CXArgument) bool {
+	if len(arg.Fields) == 0 && len(arg.Lengths) == 0 && arg.CustomType == nil {
+		// basic values and strings, which op_equal and op_unequal handle
+		return false
+	}
+	return argValueType(arg).isComposite()
+}
+
+// FormatValue formats the value read by `arg` as the %v verb does. If
+// `withTypes` is true, nested structs, arrays, slices and pointers are printed
+// with their type names and pointers are followed, as %+v does, and if
+// `quoted` is true strings are also quoted, as %#v does
+func FormatValue(stack *CXStack, fp int, arg *CXArgument, withTypes, quoted bool) string {
+	t := argValueType(arg)
+
+	if t.isBase() && t.typ == TYPE_STR && t.specs[0] == DECL_POINTER {
+		return formatStr(ReadStr(stack, fp, arg), quoted)
+	}
+
+	byts := ReadMemory(stack, GetFinalOffset(stack, fp, arg, MEM_READ), arg)
+	var buf bytes.Buffer
+	formatBytes(&buf, stack.Program, byts, t, withTypes, quoted, 0)
+	return buf.String()
+}
+
+func formatStr(str string, quoted bool) string {
+	if quoted {
+		return strconv.Quote(str)
+	}
+	return str
+}
+
+// formatBytes writes to `buf` the value stored in `byts` with layout `t`
+func formatBytes(buf *bytes.Buffer, prgrm *CXProgram, byts []byte, t valueType, withTypes, quoted bool, depth int) {
+	switch t.specs[0] {
+	case DECL_ARRAY, DECL_SLICE:
+		if withTypes {
+			buf.WriteString(t.name())
+			buf.WriteByte('{')
+		} else {
+			buf.WriteByte('[')
+		}
+
+		elt := t.elem()
+		eltSize := elt.size()
+		for c := 0; c < t.lengths[0] && (c+1)*eltSize <= len(byts); c++ {
+			if c > 0 {
+				buf.WriteString(", ")
+			}
+			formatBytes(buf, prgrm, byts[c*eltSize:(c+1)*eltSize], elt, withTypes, quoted, depth)
+		}
+
+		if withTypes {
+			buf.WriteByte('}')
+		} else {
+			buf.WriteByte(']')
+		}
+	case DECL_STRUCT:
+		if t.strct == nil {
+			buf.WriteString("{}")
+			return
+		}
+		if withTypes {
+			buf.WriteString(t.strct.Name)
+		}
+		buf.WriteByte('{')
+
+		var offset int
+		for c, fld := range t.strct.Fields {
+			if c > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(fld.Name)
+			buf.WriteString(": ")
+			if offset+fld.TotalSize <= len(byts) {
+				formatBytes(buf, prgrm, byts[offset:offset+fld.TotalSize], declValueType(fld), withTypes, quoted, depth)
+			}
+			offset += fld.TotalSize
+		}
+
+		buf.WriteByte('}')
+	case DECL_POINTER:
+		var heapOffset int32
+		encoder.DeserializeAtomic(byts[:TYPE_POINTER_SIZE], &heapOffset)
+
+		if t.isBase() && t.typ == TYPE_STR {
+			if heapOffset == NULL_HEAP_ADDRESS {
+				buf.WriteString(formatStr("", quoted))
+			} else {
+				buf.WriteString(formatStr(readStrFromHeap(prgrm, heapOffset), quoted))
+			}
+			return
+		}
+
+		if heapOffset == NULL_HEAP_ADDRESS {
+			buf.WriteString("nil")
+			return
+		}
+
+		pointee := t.elem()
+		start := int(heapOffset) + OBJECT_HEADER_SIZE
+		end := start + pointee.size()
+		if !withTypes || depth >= FORMAT_MAX_DEPTH || end > len(prgrm.Heap.Heap) {
+			buf.WriteString("0x" + strconv.FormatInt(int64(heapOffset), 16))
+			return
+		}
+
+		buf.WriteByte('&')
+		if pointee.isBase() && pointee.specs[0] == DECL_BASIC {
+			buf.WriteString(pointee.name())
+			buf.WriteByte('(')
+			formatBytes(buf, prgrm, prgrm.Heap.Heap[start:end], pointee, withTypes, quoted, depth+1)
+			buf.WriteByte(')')
+		} else {
+			formatBytes(buf, prgrm, prgrm.Heap.Heap[start:end], pointee, withTypes, quoted, depth+1)
+		}
+	default:
+		buf.WriteString(formatBasic(byts, t.typ))
+	}
+}
+
+// formatBasic formats the bytes of a value of a basic type
+func formatBasic(byts []byte, typ int) string {
+	if len(byts) < GetArgSize(typ) {
+		return ""
+	}
+
+	switch typ {
+	case TYPE_BOOL:
+		var b bool
+		encoder.DeserializeRaw(byts[:1], &b)
+		return strconv.FormatBool(b)
+	case TYPE_BYTE:
+		return strconv.FormatUint(uint64(byts[0]), 10)
+	case TYPE_I8:
+		return strconv.FormatInt(int64(int8(byts[0])), 10)
+	case TYPE_UI8:
+		return strconv.FormatUint(uint64(byts[0]), 10)
+	case TYPE_I16:
+		var n int16
+		encoder.DeserializeAtomic(byts[:2], &n)
+		return strconv.FormatInt(int64(n), 10)
+	case TYPE_UI16:
+		var n uint16
+		encoder.DeserializeAtomic(byts[:2], &n)
+		return strconv.FormatUint(uint64(n), 10)
+	case TYPE_I32:
+		var n int32
+		encoder.DeserializeAtomic(byts[:4], &n)
+		return strconv.FormatInt(int64(n), 10)
+	case TYPE_UI32:
+		var n uint32
+		encoder.DeserializeAtomic(byts[:4], &n)
+		return strconv.FormatUint(uint64(n), 10)
+	case TYPE_I64:
+		var n int64
+		encoder.DeserializeAtomic(byts[:8], &n)
+		return strconv.FormatInt(n, 10)
+	case TYPE_UI64:
+		var n uint64
+		encoder.DeserializeAtomic(byts[:8], &n)
+		return strconv.FormatUint(n, 10)
+	case TYPE_F32:
+		var f float32
+		encoder.DeserializeRaw(byts[:4], &f)
+		return strconv.FormatFloat(float64(f), 'g', -1, 32)
+	case TYPE_F64:
+		var f float64
+		encoder.DeserializeRaw(byts[:8], &f)
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// verbs accepted by the values of each basic type, besides %v and %T
+var formatVerbs = map[int]string{
+	TYPE_BOOL: "t",
+	TYPE_STR:  "sqxX",
+	TYPE_BYTE: "bcdoqxX",
+	TYPE_I8:   "bcdoqxX",
+	TYPE_I16:  "bcdoqxX",
+	TYPE_I32:  "bcdoqxX",
+	TYPE_I64:  "bcdoqxX",
+	TYPE_UI8:  "bcdoqxX",
+	TYPE_UI16: "bcdoqxX",
+	TYPE_UI32: "bcdoqxX",
+	TYPE_UI64: "bcdoqxX",
+	TYPE_F32:  "eEfFgG",
+	TYPE_F64:  "eEfFgG",
+}
+
+// formatDirective is a piece of a printf format string: either literal text
+// or a verb with its flags, width and precision
+type formatDirective struct {
+	text      []byte
+	isVerb    bool
+	flags     string
+	width     string
+	precision string // including its leading '.'
+	verb      byte   // 0 if the format ends before the verb
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// parseFormat splits a printf format string into its literal text and its
+// verbs. As string literals don't support escape sequences, \n is read as a
+// newline and both \% and %% as a percent sign
+func parseFormat(fmtStr string) (dirs []formatDirective) {
+	var text []byte
+	for c := 0; c < len(fmtStr); c++ {
+		ch := fmtStr[c]
+		if ch == '\\' && c+1 < len(fmtStr) && (fmtStr[c+1] == '%' || fmtStr[c+1] == 'n') {
+			c++
+			if fmtStr[c] == 'n' {
+				text = append(text, '\n')
+			} else {
+				text = append(text, '%')
+			}
+			continue
+		}
+		if ch != '%' {
+			text = append(text, ch)
+			continue
+		}
+		if c+1 < len(fmtStr) && fmtStr[c+1] == '%' {
+			text = append(text, '%')
+			c++
+			continue
+		}
+
+		if len(text) > 0 {
+			dirs = append(dirs, formatDirective{text: text})
+			text = nil
+		}
+
+		dir := formatDirective{isVerb: true}
+		c++
+		start := c
+		for c < len(fmtStr) && strings.IndexByte("+-# 0", fmtStr[c]) >= 0 {
+			c++
+		}
+		dir.flags = fmtStr[start:c]
+		start = c
+		for c < len(fmtStr) && isDigit(fmtStr[c]) {
+			c++
+		}
+		dir.width = fmtStr[start:c]
+		if c < len(fmtStr) && fmtStr[c] == '.' {
+			start = c
+			c++
+			for c < len(fmtStr) && isDigit(fmtStr[c]) {
+				c++
+			}
+			dir.precision = fmtStr[start:c]
+		}
+		if c < len(fmtStr) {
+			dir.verb = fmtStr[c]
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(text) > 0 {
+		dirs = append(dirs, formatDirective{text: text})
+	}
+	return dirs
+}
+
+// readBasicValue reads the value of an argument of a basic type, so it can be
+// formatted by the fmt package
+func readBasicValue(stack *CXStack, fp int, inp *CXArgument, typ int) interface{} {
+	switch typ {
+	case TYPE_BOOL:
+		return ReadBool(stack, fp, inp)
+	case TYPE_STR:
+		return ReadStr(stack, fp, inp)
+	case TYPE_BYTE:
+		return ReadByte(stack, fp, inp)
+	case TYPE_I8:
+		return ReadI8(stack, fp, inp)
+	case TYPE_I16:
+		return ReadI16(stack, fp, inp)
+	case TYPE_I32:
+		return ReadI32(stack, fp, inp)
+	case TYPE_I64:
+		return ReadI64(stack, fp, inp)
+	case TYPE_UI8:
+		return ReadUI8(stack, fp, inp)
+	case TYPE_UI16:
+		return ReadUI16(stack, fp, inp)
+	case TYPE_UI32:
+		return ReadUI32(stack, fp, inp)
+	case TYPE_UI64:
+		return ReadUI64(stack, fp, inp)
+	case TYPE_F32:
+		return ReadF32(stack, fp, inp)
+	case TYPE_F64:
+		return ReadF64(stack, fp, inp)
+	}
+	return nil
+}
+
+// formatArgument formats `inp` as the verb `dir` says. Missing arguments and
+// verbs that don't apply to the argument's type are printed as fmt does, e.g.
+// %!d(MISSING)
+func formatArgument(stack *CXStack, fp int, dir formatDirective, inp *CXArgument) string {
+	if dir.verb == 0 {
+		return "%!(NOVERB)"
+	}
+	if inp == nil {
+		return "%!" + string(dir.verb) + "(MISSING)"
+	}
+
+	t := argValueType(inp)
+	if dir.verb == 'T' {
+		return fmt.Sprintf("%"+dir.flags+dir.width+dir.precision+"s", t.name())
+	}
+
+	if !t.isBasic() {
+		if dir.verb != 'v' {
+			return "%!" + string(dir.verb) + "(" + t.name() + ")"
+		}
+		withTypes := strings.ContainsAny(dir.flags, "+#")
+		str := FormatValue(stack, fp, inp, withTypes, strings.Contains(dir.flags, "#"))
+		if strings.Contains(dir.flags, "-") {
+			return fmt.Sprintf("%-"+dir.width+"s", str)
+		}
+		return fmt.Sprintf("%"+dir.width+"s", str)
+	}
+
+	val := readBasicValue(stack, fp, inp, t.typ)
+	if str, ok := val.(string); ok && (dir.verb == 's' || dir.verb == 'v') {
+		val = string(checkForEscapedChars(str))
+	}
+	return fmt.Sprintf("%"+dir.flags+dir.width+dir.precision+string(dir.verb), val)
+}
+
+// CheckFormat reports the first mismatch between the verbs of a printf format
+// string and the arguments that follow it, so calls with a literal format can
+// be checked at compile time
+func CheckFormat(fmtStr string, args []*CXArgument) error {
+	var argIdx int
+	for _, dir := range parseFormat(fmtStr) {
+		if !dir.isVerb {
+			continue
+		}
+		if dir.verb == 0 {
+			return errors.New("format ends with an incomplete verb")
+		}
+		if !strings.ContainsRune("vTtbcdoqxXeEfFgGs", rune(dir.verb)) {
+			return errors.New(fmt.Sprintf("unknown verb %%%c", dir.verb))
+		}
+		if argIdx >= len(args) {
+			return errors.New(fmt.Sprintf("verb %%%c is missing its argument", dir.verb))
+		}
+
+		arg := args[argIdx]
+		argIdx++
+		if dir.verb == 'v' || dir.verb == 'T' {
+			continue
+		}
+
+		t := argValueType(arg)
+		verbs, isKnown := formatVerbs[t.typ]
+		if t.isBasic() && !isKnown {
+			// type not resolved yet, nothing to check
+			continue
+		}
+		if !t.isBasic() || strings.IndexByte(verbs, dir.verb) < 0 {
+			return errors.New(fmt.Sprintf("verb %%%c can't format argument %d of type %s", dir.verb, argIdx, t.name()))
+		}
+	}
+
+	if argIdx < len(args) {
+		return errors.New(fmt.Sprintf("format has %d verbs but received %d arguments", argIdx, len(args)))
+	}
+
+	return nil
+}
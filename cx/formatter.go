@@ -0,0 +1,575 @@
+package base
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// kinds of the tokens of CX source code
+const (
+	SRC_NEWLINE = iota
+	SRC_COMMENT
+	SRC_STRING
+	SRC_NUMBER
+	SRC_WORD  // identifiers and keywords
+	SRC_PUNCT // operators and delimiters
+	SRC_OTHER // characters the lexer has no token for
+)
+
+// srcToken is a token of CX source code, as the lexer of cxgo reads it, but
+// keeping the comments
+type srcToken struct {
+	kind  int
+	text  string
+	space bool // whitespace separates it from the token before it
+}
+
+// the keywords of the lexer, which aren't identifiers
+var srcKeywords = map[string]bool{
+	"bool": true, "byte": true, "break": true, "case": true, "const": true, "continue": true,
+	"default": true, "else": true, "enum": true, "f32": true, "f64": true, "for": true,
+	"goto": true, "i8": true, "i16": true, "i32": true, "i64": true, "if": true,
+	"new": true, "return": true, "str": true, "struct": true, "switch": true, "type": true,
+	"ui8": true, "ui16": true, "ui32": true, "ui64": true, "union": true, "package": true,
+	"func": true, "clauses": true, "expr": true, "def": true, "field": true, "input": true,
+	"output": true, "import": true, "var": true, "true": true, "false": true,
+}
+
+// the keywords that are types, which end operands as identifiers do
+var srcTypeKeywords = map[string]bool{
+	"bool": true, "byte": true, "str": true, "f32": true, "f64": true,
+	"i8": true, "i16": true, "i32": true, "i64": true,
+	"ui8": true, "ui16": true, "ui32": true, "ui64": true,
+}
+
+// the operators and delimiters of the lexer, the longest first
+var srcPuncts = []string{
+	":dProgram", ":dLocals", ":package", ":dStack", ":struct", ":tStep", ":pStep",
+	":func", ":step", ":rem", ":aff", ":dl", ":ds", ":dp",
+	">>=", "<<=", "...",
+	"->", "+=", "-=", "*=", "/=", "%=", "&=", "^=", "|=", ">>", "<<", "++", "--",
+	"&&", "||", "<=", ">=", "==", "!=", "&^", ":=",
+	"&", "+", "-", "*", "/", "%", ">", "<", ";", ":", "!", "[", "]", "(", ")",
+	"{", "}", ".", ",", "=", "|", "^",
+}
+
+// the operators that are always binary, and spaced on both sides
+var srcBinaryOps = map[string]bool{
+	"=": true, ":=": true, "+=": true, "-=": true, "*=": true, "/=": true, "%=": true,
+	"&=": true, "^=": true, "|=": true, ">>=": true, "<<=": true,
+	"==": true, "!=": true, "<": true, ">": true, "<=": true, ">=": true,
+	"&&": true, "||": true, "<<": true, ">>": true, "/": true, "%": true, "|": true, "&^": true,
+}
+
+// the operators that are binary or unary depending on what's before them
+var srcUnaryOps = map[string]bool{
+	"-": true, "+": true, "*": true, "&": true, "^": true, "!": true,
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// scanNumber returns the length of the number literal at the start of
+// `s`, or 0, trying the literals of the lexer and keeping the longest
+func scanNumber(s string) int {
+	c := 0
+	if c < len(s) && s[c] == '-' {
+		c++
+	}
+	digits := c
+	for c < len(s) && isDigit(s[c]) {
+		c++
+	}
+	if c == digits {
+		return 0
+	}
+
+	longest := c
+	if c < len(s) && s[c] == '.' {
+		// -?[0-9]+\.[0-9]*D?
+		f := c + 1
+		for f < len(s) && isDigit(s[f]) {
+			f++
+		}
+		if f < len(s) && s[f] == 'D' {
+			f++
+		}
+		longest = f
+	}
+
+	suffixes := []string{"B", "SB", "H", "L"}
+	if s[0] != '-' {
+		suffixes = append(suffixes, "UB", "UH", "U", "UL")
+	}
+	for _, suffix := range suffixes {
+		if strings.HasPrefix(s[c:], suffix) && c+len(suffix) > longest {
+			longest = c + len(suffix)
+		}
+	}
+	return longest
+}
+
+// srcTokens splits CX source code into tokens as the lexer of cxgo does,
+// keeping the comments and the newlines
+func srcTokens(src string) (toks []srcToken, err error) {
+	line := 1
+	space := false
+	for c := 0; c < len(src); {
+		tok := srcToken{space: space}
+		rest := src[c:]
+		switch ch := src[c]; {
+		case ch == ' ' || ch == '\t':
+			space = true
+			c++
+			continue
+		case ch == '\r' || ch == '\n':
+			tok.kind, tok.text = SRC_NEWLINE, "\n"
+			if strings.HasPrefix(rest, "\r\n") {
+				c++
+			}
+			c++
+			line++
+			toks = append(toks, tok)
+			space = false
+			continue
+		case strings.HasPrefix(rest, "//"):
+			end := strings.IndexAny(rest, "\r\n")
+			if end < 0 {
+				end = len(rest)
+			}
+			tok.kind, tok.text = SRC_COMMENT, rest[:end]
+		case strings.HasPrefix(rest, "/*") && strings.Contains(rest[2:], "*/"):
+			tok.kind, tok.text = SRC_COMMENT, rest[:strings.Index(rest[2:], "*/")+4]
+		case ch == '"':
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("%d: string literal not terminated", line)
+			}
+			tok.kind, tok.text = SRC_STRING, rest[:end+2]
+		case scanNumber(rest) > 0:
+			tok.kind, tok.text = SRC_NUMBER, rest[:scanNumber(rest)]
+		case isLetter(ch):
+			end := 1
+			for end < len(rest) && (isLetter(rest[end]) || isDigit(rest[end])) {
+				end++
+			}
+			tok.kind, tok.text = SRC_WORD, rest[:end]
+		default:
+			tok.kind, tok.text = SRC_OTHER, rest[:1]
+			for _, punct := range srcPuncts {
+				if strings.HasPrefix(rest, punct) {
+					tok.kind, tok.text = SRC_PUNCT, punct
+					break
+				}
+			}
+		}
+
+		line += strings.Count(tok.text, "\n")
+		c += len(tok.text)
+		toks = append(toks, tok)
+		space = false
+	}
+	return toks, nil
+}
+
+// srcInsertsSemicolon reports whether a newline after `tok` ends a statement,
+// as the lexer then reads a semicolon
+func srcInsertsSemicolon(tok srcToken) bool {
+	switch tok.kind {
+	case SRC_STRING, SRC_NUMBER:
+		return true
+	case SRC_WORD:
+		return !srcKeywords[tok.text] || srcTypeKeywords[tok.text] || tok.text == "return" || tok.text == "true" || tok.text == "false"
+	case SRC_PUNCT:
+		return tok.text == ")" || tok.text == "]" || tok.text == "}"
+	}
+	return false
+}
+
+// srcParsed returns the tokens the parser reads from `toks`: the comments
+// and newlines are skipped, except the newlines read as semicolons
+func srcParsed(toks []srcToken) (parsed []string) {
+	insert := false
+	for _, tok := range toks {
+		switch tok.kind {
+		case SRC_COMMENT:
+		case SRC_NEWLINE:
+			if insert {
+				parsed = append(parsed, ";")
+			}
+			insert = false
+		default:
+			parsed = append(parsed, tok.text)
+			insert = srcInsertsSemicolon(tok)
+		}
+	}
+	return parsed
+}
+
+// isOperand reports whether `tok` can end an operand, so an operator after
+// it is binary
+func isOperand(tok *srcToken) bool {
+	if tok == nil {
+		return false
+	}
+	switch tok.kind {
+	case SRC_STRING, SRC_NUMBER:
+		return true
+	case SRC_WORD:
+		return !srcKeywords[tok.text] || srcTypeKeywords[tok.text] || tok.text == "true" || tok.text == "false"
+	case SRC_PUNCT:
+		return tok.text == ")" || tok.text == "]" || tok.text == "}"
+	}
+	return false
+}
+
+// srcFormatter prints the lines of CX source code in the canonical style
+type srcFormatter struct {
+	out bytes.Buffer
+
+	// the braces, brackets and parentheses left open, with the lines they
+	// were opened in
+	groups     []string
+	groupLines []int
+	// whether the open braces are the braces of a literal, e.g. []i32{1, 2},
+	// rather than of a block
+	literals []bool
+
+	// a statement with a block, e.g. an if statement, is being printed, and
+	// how many groups were open when it started. The name of a declared
+	// function is followed by a space in its header, as in func main () ()
+	control      bool
+	header       bool
+	controlDepth int
+
+	// the last line ended with an operator, so the next line continues its
+	// expression
+	continued bool
+}
+
+// open returns how many groups are open after the groups that `toks`
+// closes first
+func (f *srcFormatter) open(toks []srcToken) int {
+	open := len(f.groups)
+	for _, tok := range toks {
+		if open == 0 || tok.kind != SRC_PUNCT || !srcCloses(f.groups[open-1], tok.text) {
+			break
+		}
+		open--
+	}
+	return open
+}
+
+// indent returns the indentation of line `n`, made of `toks`: one level for
+// every previous line with a group still open when the line starts, and one
+// more if the line continues an expression. The groups the line closes first
+// don't count
+func (f *srcFormatter) indent(n int, toks []srcToken) int {
+	open := f.open(toks)
+	indent := 0
+	if f.continued {
+		indent++
+	}
+	for g := 0; g < open; g++ {
+		if f.groupLines[g] != n && (g == 0 || f.groupLines[g] != f.groupLines[g-1]) {
+			indent++
+		}
+	}
+	return indent
+}
+
+func srcCloses(open, close string) bool {
+	return open == "(" && close == ")" || open == "[" && close == "]" || open == "{" && close == "}"
+}
+
+// line prints line `n`, made of `toks`
+func (f *srcFormatter) line(n int, toks []srcToken) {
+	indent := f.indent(n, toks)
+	// labels are outdented by a level
+	if len(toks) >= 2 && toks[0].kind == SRC_WORD && !srcKeywords[toks[0].text] && toks[1].text == ":" &&
+		(len(toks) == 2 || toks[2].kind == SRC_COMMENT) && indent > 0 {
+		indent--
+	}
+	f.out.WriteString(strings.Repeat("\t", indent))
+
+	first := toks[0]
+	if first.kind == SRC_PUNCT && first.text == "}" && len(toks) > 1 {
+		first = toks[1]
+	}
+	if first.kind == SRC_WORD {
+		switch first.text {
+		case "func", "if", "for", "else", "switch", "type", "struct":
+			f.control, f.header, f.controlDepth = true, first.text == "func", f.open(toks)
+		}
+	}
+
+	var prev *srcToken
+	prevUnary := false
+	for c := range toks {
+		tok := &toks[c]
+		var next *srcToken
+		if c+1 < len(toks) {
+			next = &toks[c+1]
+		}
+
+		unary := false
+		space := prev != nil
+		if prev != nil {
+			space, unary = f.space(prev, tok, next, prevUnary)
+			if !space && !srcLexesApart(prev.text, tok.text) {
+				space = true
+			}
+		}
+		if space {
+			f.out.WriteString(" ")
+		}
+
+		text := tok.text
+		if tok.kind == SRC_COMMENT && strings.HasPrefix(text, "//") {
+			text = strings.TrimRight(text, " \t")
+		}
+		f.out.WriteString(text)
+
+		if tok.kind == SRC_PUNCT {
+			switch tok.text {
+			case "(", "[", "{":
+				if tok.text == "{" && f.block() {
+					f.control, f.header = false, false
+				}
+				f.groups = append(f.groups, tok.text)
+				f.groupLines = append(f.groupLines, n)
+				f.literals = append(f.literals, tok.text == "{" && !space && prev != nil)
+			case ")", "]", "}":
+				if open := len(f.groups); open > 0 && srcCloses(f.groups[open-1], tok.text) {
+					f.groups, f.groupLines, f.literals = f.groups[:open-1], f.groupLines[:open-1], f.literals[:open-1]
+				}
+			}
+		}
+
+		if tok.kind != SRC_COMMENT {
+			f.continued = tok.kind == SRC_PUNCT && (srcBinaryOps[tok.text] || srcUnaryOps[tok.text] && !unary)
+		}
+		prev, prevUnary = tok, unary
+	}
+	f.out.WriteString("\n")
+}
+
+// block reports whether a brace opened now is the brace of the block of a
+// statement
+func (f *srcFormatter) block() bool {
+	return f.control && len(f.groups) == f.controlDepth
+}
+
+// literal reports whether the innermost open group is the brace of a literal
+func (f *srcFormatter) literal() bool {
+	open := len(f.groups)
+	return open > 0 && f.groups[open-1] == "{" && f.literals[open-1]
+}
+
+// space reports whether a space goes between `prev` and `tok`, and whether
+// `tok` is a unary operator. Where the tokens don't tell, e.g. between a
+// name and the bracket of an index or of an array type, the space of the
+// source code is kept
+func (f *srcFormatter) space(prev, tok, next *srcToken, prevUnary bool) (space, unary bool) {
+	isPunct := func(t *srcToken, texts ...string) bool {
+		if t == nil || t.kind != SRC_PUNCT {
+			return false
+		}
+		for _, text := range texts {
+			if t.text == text {
+				return true
+			}
+		}
+		return false
+	}
+	name := prev.kind == SRC_WORD && (!srcKeywords[prev.text] || srcTypeKeywords[prev.text])
+
+	switch {
+	case tok.kind == SRC_COMMENT || prev.kind == SRC_COMMENT:
+		return true, false
+	case tok.kind == SRC_OTHER || prev.kind == SRC_OTHER:
+		return tok.space, false
+	case tok.kind == SRC_PUNCT && srcUnaryOps[tok.text]:
+		// an operator is unary if nothing it could apply to is before it,
+		// or if it's written as unary, e.g. the pointer type in var p *i32
+		unary = !isOperand(prev) || tok.text == "!" ||
+			(name || isPunct(prev, "]")) && (tok.space || isPunct(prev, "]")) && next != nil && !next.space
+		if unary {
+			return !isPunct(prev, "(", "[", ".", "]") && !prevUnary && !isPunct(prev, "->"), true
+		}
+		return true, false
+	case isPunct(tok, ";") && prev.text == "for":
+		return true, false
+	case isPunct(tok, ",", ";", ")", "]", ".", ":", "++", "--"):
+		return false, false
+	case tok.kind == SRC_PUNCT && srcBinaryOps[tok.text]:
+		return true, false
+	case prevUnary:
+		return false, false
+	case isPunct(prev, "(", "[", ".", "->"):
+		return false, false
+	case isPunct(prev, ",", ";", ":"):
+		return true, false
+	case isPunct(tok, "...") || isPunct(prev, "..."):
+		return tok.space, false
+	case tok.kind == SRC_PUNCT && srcBinaryOps[tok.text] || prev.kind == SRC_PUNCT && srcBinaryOps[prev.text]:
+		return true, false
+	case isPunct(tok, "("):
+		if name || isPunct(prev, ")") {
+			return f.header, false
+		}
+		return !isPunct(prev, "]", "}"), false
+	case isPunct(tok, "["):
+		if name || isPunct(prev, ")", "]") {
+			return tok.space, false
+		}
+		return true, false
+	case isPunct(tok, "{"):
+		// the braces of literals, e.g. Point{x: 1}, are written against
+		// their types
+		return !((name || isPunct(prev, "]")) && !f.block()), false
+	case isPunct(tok, "}"):
+		return !isPunct(prev, "{") && !f.literal(), false
+	case isPunct(prev, "{"):
+		return !f.literal(), false
+	case isPunct(prev, "]"):
+		return false, false
+	}
+	return true, false
+}
+
+// srcLexesApart reports whether the lexer reads `a` and `b` as two tokens
+// when they are written together
+func srcLexesApart(a, b string) bool {
+	toks, err := srcTokens(a + b)
+	return err == nil && len(toks) == 2 && toks[0].text == a && toks[1].text == b
+}
+
+// FormatSource formats CX source code in the canonical style: lines are
+// indented with tabs, one for every level of braces, brackets and
+// parentheses left open by the lines before, tokens are spaced as in
+// `func main () () {` and `x = i32.add(a, -b)`, and consecutive blank lines
+// are merged. The line breaks and the comments of the source code are kept.
+//
+// Formatting never changes the program: the tokens the parser reads from
+// the formatted code are checked to be the tokens it reads from `src`, and
+// an error is returned otherwise
+func FormatSource(src []byte) ([]byte, error) {
+	toks, err := srcTokens(string(src))
+	if err != nil {
+		return nil, err
+	}
+
+	var f srcFormatter
+	var line []srcToken
+	blank := false
+	n := 0
+	flush := func() {
+		if len(line) == 0 {
+			blank = f.out.Len() > 0
+			return
+		}
+		if blank {
+			f.out.WriteString("\n")
+			blank = false
+		}
+		f.line(n, line)
+		line = nil
+	}
+	for _, tok := range toks {
+		if tok.kind == SRC_NEWLINE {
+			flush()
+			n++
+			continue
+		}
+		line = append(line, tok)
+	}
+	flush()
+
+	formatted := f.out.Bytes()
+	formattedToks, err := srcTokens(string(formatted))
+	if err != nil || !equalStrings(srcParsed(toks), srcParsed(formattedToks)) {
+		return nil, fmt.Errorf("formatting would change the program")
+	}
+	return formatted, nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for c := range a {
+		if a[c] != b[c] {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffSource returns the changes from `a` to `b` as a unified diff, without
+// context lines, between files named `name` and `name` (formatted), or ""
+// if they are equal
+func DiffSource(name string, a, b []byte) string {
+	if bytes.Equal(a, b) {
+		return ""
+	}
+	aLines := strings.SplitAfter(string(a), "\n")
+	bLines := strings.SplitAfter(string(b), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// aLines[i:] and bLines[j:]
+	lcs := make([][]int, len(aLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bLines)+1)
+	}
+	for i := len(aLines) - 1; i >= 0; i-- {
+		for j := len(bLines) - 1; j >= 0; j-- {
+			if aLines[i] == bLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff bytes.Buffer
+	fmt.Fprintf(&diff, "--- %s\n+++ %s (formatted)\n", name, name)
+	var hunk bytes.Buffer
+	hunkA, hunkB, lenA, lenB := 0, 0, 0, 0
+	writeHunk := func() {
+		if lenA+lenB > 0 {
+			fmt.Fprintf(&diff, "@@ -%d,%d +%d,%d @@\n", hunkA+1, lenA, hunkB+1, lenB)
+			diff.Write(hunk.Bytes())
+		}
+		hunk.Reset()
+		lenA, lenB = 0, 0
+	}
+	line := func(prefix, text string) {
+		hunk.WriteString(prefix + strings.TrimSuffix(text, "\n") + "\n")
+	}
+
+	i, j := 0, 0
+	for i < len(aLines) || j < len(bLines) {
+		switch {
+		case i < len(aLines) && j < len(bLines) && aLines[i] == bLines[j]:
+			writeHunk()
+			i++
+			j++
+			hunkA, hunkB = i, j
+		case j == len(bLines) || i < len(aLines) && lcs[i+1][j] >= lcs[i][j+1]:
+			line("-", aLines[i])
+			i++
+			lenA++
+		default:
+			line("+", bLines[j])
+			j++
+			lenB++
+		}
+	}
+	writeHunk()
+	return diff.String()
+}
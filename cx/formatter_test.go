@@ -0,0 +1,74 @@
+package base
+
+import "testing"
+
+func TestFormatSource(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{
+			"indentation",
+			"package main\nfunc main() () {\n    var x i32\n  if x > 0 {\nx = 1\n        }\n}\n",
+			"package main\nfunc main () () {\n\tvar x i32\n\tif x > 0 {\n\t\tx = 1\n\t}\n}\n",
+		},
+		{
+			"spacing",
+			"func add(a i32,b i32)(c i32){\n\tc=i32.add( a,-b )\n\tc = - c\n\tvar p *i32\n\tp=&c\n}\n",
+			"func add (a i32, b i32) (c i32) {\n\tc = i32.add(a, -b)\n\tc = -c\n\tvar p *i32\n\tp = &c\n}\n",
+		},
+		{
+			"literals and indexes",
+			"func main () () {\n\tvar a [2]i32\n\ta = [2]i32{ 1,2 }\n\tp := Point {x:1, y:a[0]}\n\tfor c := 0;c < 2;c++{\n\t}\n}\n",
+			"func main () () {\n\tvar a [2]i32\n\ta = [2]i32{1, 2}\n\tp := Point{x: 1, y: a[0]}\n\tfor c := 0; c < 2; c++ {\n\t}\n}\n",
+		},
+		{
+			"continuation lines",
+			"func main () () {\n\tif a &&\nb {\n\t\tf(1,\n2)\n\t}\n}\n",
+			"func main () () {\n\tif a &&\n\t\tb {\n\t\tf(1,\n\t\t\t2)\n\t}\n}\n",
+		},
+		{
+			"comments and blank lines",
+			"\n\npackage main   // the main package\n\n\n\n/* globals */ var x i32\nfunc main () () {\n// first\nx = 1 /* one */\n  }\n\n\n",
+			"package main // the main package\n\n/* globals */ var x i32\nfunc main () () {\n\t// first\n\tx = 1 /* one */\n}\n",
+		},
+		{
+			"labels",
+			"func main () () {\n\tgoto end\nend:\n\tstr.print(\"end\")\n}\n",
+			"func main () () {\n\tgoto end\nend:\n\tstr.print(\"end\")\n}\n",
+		},
+		{
+			// without the space, - and 1 would be read as -1
+			"tokens kept apart",
+			"func main () () {\n\tx = - 1\n\ty = x -1\n}\n",
+			"func main () () {\n\tx = - 1\n\ty = x -1\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		got, err := FormatSource([]byte(test.src))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.name, got, test.want)
+		}
+	}
+
+	if _, err := FormatSource([]byte("str.print(\"unterminated)\n")); err == nil {
+		t.Errorf("formatting an unterminated string succeeded")
+	}
+}
+
+func TestDiffSource(t *testing.T) {
+	a := "package main\nvar x i32\nfunc main () () {\n    x = 1\n}\n"
+	b := "package main\nvar x i32\nfunc main () () {\n\tx = 1\n}\n"
+
+	want := "--- a.cx\n+++ a.cx (formatted)\n@@ -4,1 +4,1 @@\n-    x = 1\n+\tx = 1\n"
+	if diff := DiffSource("a.cx", []byte(a), []byte(b)); diff != want {
+		t.Errorf("diff = %q, want %q", diff, want)
+	}
+	if diff := DiffSource("a.cx", []byte(a), []byte(a)); diff != "" {
+		t.Errorf("diff of equal sources = %q, want none", diff)
+	}
+}
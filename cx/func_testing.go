@@ -2,18 +2,17 @@ package base
 
 import (
 	"errors"
-	"fmt"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
 )
 
-func test_error(message *CXArgument, isErrorPresent bool, expr *CXExpression) error {
+func test_error(prgrm *CXProgram, message *CXArgument, isErrorPresent bool, expr *CXExpression) error {
 	if !isErrorPresent {
 		var _message string
 		encoder.DeserializeRaw(*message.Value, &_message)
 		if _message == "" {
-			fmt.Println(fmt.Sprintf("%s: %d: an error was expected and did not occur", expr.FileName, expr.FileLine))
+			prgrm.addTestFailure(expr.FileName, expr.FileLine, "an error was expected and did not occur")
 		} else {
-			fmt.Println(fmt.Sprintf("%s: %d: %s", expr.FileName, expr.FileLine, _message))
+			prgrm.addTestFailure(expr.FileName, expr.FileLine, _message)
 		}
 
 		return nil
@@ -22,9 +21,9 @@ func test_error(message *CXArgument, isErrorPresent bool, expr *CXExpression) er
 	}
 }
 
-func test_value(result *CXArgument, expected *CXArgument, message *CXArgument, expr *CXExpression) error {
+func test_value(prgrm *CXProgram, result *CXArgument, expected *CXArgument, message *CXArgument, expr *CXExpression) error {
 	if result.Typ != expected.Typ {
-		fmt.Println(fmt.Sprintf("%s: %d: result and expected value are not of the same type", expr.FileName, expr.FileLine))
+		prgrm.addTestFailure(expr.FileName, expr.FileLine, "result and expected value are not of the same type")
 		return nil
 	}
 
@@ -47,10 +46,10 @@ func test_value(result *CXArgument, expected *CXArgument, message *CXArgument, e
 
 	if !equal {
 		if _message == "" {
-			fmt.Printf("%s: %d: result was not equal to the expected value\n", expr.FileName, expr.FileLine)
+			prgrm.addTestFailure(expr.FileName, expr.FileLine, "result was not equal to the expected value")
 			return errors.New("")
 		} else {
-			fmt.Println(fmt.Sprintf("%s: %d: result was not equal to the expected value; %s\n", expr.FileName, expr.FileLine, _message))
+			prgrm.addTestFailure(expr.FileName, expr.FileLine, "result was not equal to the expected value; "+_message)
 			return errors.New("")
 		}
 	}
@@ -1,147 +1,492 @@
 package base
 
 import (
-	// "fmt"
-	"time"
-	"net/http"
+	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/skycoin/skycoin/src/cipher/encoder"
 )
 
-const (
-	// StatusCode = 4
-	// Body 4
-	RESPONSE_SIZE = 8
-		
-)
+// timeout used by requests that don't set one, in milliseconds
+const HTTP_DEFAULT_TIMEOUT = 10000
+
+// the VM is single-threaded, so httpServeLock makes the servers process one
+// request at a time
+var httpServeLock sync.Mutex
+
+// httpRequest holds the fields of a CX http.Request
+type httpRequest struct {
+	Method  string
+	URL     string
+	Header  string // "Key: Value" lines
+	Body    string
+	Timeout int32 // in milliseconds
+}
+
+// httpResponse holds the fields of a CX http.Response
+type httpResponse struct {
+	Status     string // e.g. "200 OK"
+	StatusCode int32  // e.g. 200
+	Proto      string // e.g. "HTTP/1.0"
+	ProtoMajor int32  // e.g. 1
+	ProtoMinor int32  // e.g. 0
+	Header     string // "Key: Value" lines, sorted by key
+	Body       string
+	Error      string // empty if the request succeeded
+}
+
+// addHTTPDeclarations declares the structs and functions of the http core
+// package
+func addHTTPDeclarations(pkg *CXPackage) {
+	req := MakeStruct("Request")
+	pkg.AddStruct(req)
+	addCoreFields(req,
+		"Method", TYPE_STR,
+		"URL", TYPE_STR,
+		"Header", TYPE_STR,
+		"Body", TYPE_STR,
+		"Timeout", TYPE_I32)
+
+	resp := MakeStruct("Response")
+	pkg.AddStruct(resp)
+	addCoreFields(resp,
+		"Status", TYPE_STR,
+		"StatusCode", TYPE_I32,
+		"Proto", TYPE_STR,
+		"ProtoMajor", TYPE_I32,
+		"ProtoMinor", TYPE_I32,
+		"Header", TYPE_STR,
+		"Body", TYPE_STR,
+		"Error", TYPE_STR)
+
+	pkg.AddFunction(MakeCoreFunction("Get", OP_HTTP_GET,
+		[]*CXArgument{MakeCoreArgument("url", TYPE_STR)},
+		[]*CXArgument{MakeCoreStructArgument("resp", resp)}))
+	pkg.AddFunction(MakeCoreFunction("Post", OP_HTTP_POST,
+		[]*CXArgument{
+			MakeCoreArgument("url", TYPE_STR),
+			MakeCoreArgument("contentType", TYPE_STR),
+			MakeCoreArgument("body", TYPE_STR)},
+		[]*CXArgument{MakeCoreStructArgument("resp", resp)}))
+	pkg.AddFunction(MakeCoreFunction("Do", OP_HTTP_DO,
+		[]*CXArgument{MakeCoreStructArgument("req", req)},
+		[]*CXArgument{MakeCoreStructArgument("resp", resp)}))
+	pkg.AddFunction(MakeCoreFunction("Handle", OP_HTTP_HANDLE,
+		[]*CXArgument{
+			MakeCoreArgument("path", TYPE_STR),
+			MakeCoreArgument("handlerFn", TYPE_STR)},
+		[]*CXArgument{MakeCoreArgument("err", TYPE_STR)}))
+	pkg.AddFunction(MakeCoreFunction("ListenAndServe", OP_HTTP_LISTEN_AND_SERVE,
+		[]*CXArgument{MakeCoreArgument("addr", TYPE_STR)},
+		[]*CXArgument{MakeCoreArgument("err", TYPE_STR)}))
+}
+
+// parseHeader parses "Key: Value" lines. Empty and malformed lines are ignored
+func parseHeader(header string) http.Header {
+	hdr := http.Header{}
+	for _, line := range strings.Split(header, "\n") {
+		idx := strings.Index(line, ":")
+		if idx < 1 {
+			continue
+		}
+		hdr.Add(strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+1:]))
+	}
+	return hdr
+}
+
+// formatHeader writes `hdr` as "Key: Value" lines sorted by key
+func formatHeader(hdr http.Header) string {
+	var keys []string
+	for key := range hdr {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, key := range keys {
+		for _, val := range hdr[key] {
+			lines = append(lines, key+": "+val)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// httpDo performs `req`. Network errors are reported in the response's Error
+// field instead of stopping the program
+func httpDo(req httpRequest) (resp httpResponse) {
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = HTTP_DEFAULT_TIMEOUT
+	}
+
+	var body io.Reader
+	if req.Body != "" {
+		body = strings.NewReader(req.Body)
+	}
 
+	netReq, err := http.NewRequest(method, req.URL, body)
+	if err != nil {
+		resp.Error = err.Error()
+		return
+	}
+	netReq.Header = parseHeader(req.Header)
+
+	netClient := &http.Client{
+		Timeout: time.Duration(timeout) * time.Millisecond,
+	}
+	netResp, err := netClient.Do(netReq)
+	if err != nil {
+		resp.Error = err.Error()
+		return
+	}
+	defer netResp.Body.Close()
+
+	resp.Status = netResp.Status
+	resp.StatusCode = int32(netResp.StatusCode)
+	resp.Proto = netResp.Proto
+	resp.ProtoMajor = int32(netResp.ProtoMajor)
+	resp.ProtoMinor = int32(netResp.ProtoMinor)
+	resp.Header = formatHeader(netResp.Header)
+
+	contents, err := ioutil.ReadAll(netResp.Body)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	resp.Body = string(contents)
+
+	return
+}
+
+// writeHTTPResponse writes `resp` to the http.Response output of `expr`
+func writeHTTPResponse(expr *CXExpression, stack *CXStack, fp int, resp httpResponse) {
+	writeStructFields(stack, fp, expr.Outputs[0], map[string]interface{}{
+		"Status":     resp.Status,
+		"StatusCode": resp.StatusCode,
+		"Proto":      resp.Proto,
+		"ProtoMajor": resp.ProtoMajor,
+		"ProtoMinor": resp.ProtoMinor,
+		"Header":     resp.Header,
+		"Body":       resp.Body,
+		"Error":      resp.Error,
+	})
+}
+
+// op_http_get. The Get built-in function sends a GET request to a URL
 func op_http_get(expr *CXExpression, stack *CXStack, fp int) {
-	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
-	out1Offset := GetFinalOffset(stack, fp, out1, MEM_WRITE)
+	inp1 := expr.Inputs[0]
+	resp := httpDo(httpRequest{
+		Method: http.MethodGet,
+		URL:    ReadStr(stack, fp, inp1),
+	})
+	writeHTTPResponse(expr, stack, fp, resp)
+}
+
+// op_http_post. The Post built-in function sends a POST request with a body of
+// the given content type to a URL
+func op_http_post(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2]
+	resp := httpDo(httpRequest{
+		Method: http.MethodPost,
+		URL:    ReadStr(stack, fp, inp1),
+		Header: "Content-Type: " + ReadStr(stack, fp, inp2),
+		Body:   ReadStr(stack, fp, inp3),
+	})
+	writeHTTPResponse(expr, stack, fp, resp)
+}
+
+// op_http_do. The Do built-in function sends an http.Request, which sets the
+// method, URL, headers, body and timeout of the request
+func op_http_do(expr *CXExpression, stack *CXStack, fp int) {
+	flds := readStructFields(stack, fp, expr.Inputs[0])
+
+	var req httpRequest
+	req.Method, _ = flds["Method"].(string)
+	req.URL, _ = flds["URL"].(string)
+	req.Header, _ = flds["Header"].(string)
+	req.Body, _ = flds["Body"].(string)
+	req.Timeout, _ = flds["Timeout"].(int32)
+
+	writeHTTPResponse(expr, stack, fp, httpDo(req))
+}
 
-	var err error
-	var resp *http.Response
-	var contents []byte
+// isHTTPStruct checks if `arg` is of type http.`name`
+func isHTTPStruct(arg *CXArgument, name string) bool {
+	strct := arg.CustomType
+	return strct != nil && strct.Name == name && strct.Package != nil && strct.Package.Name == "http"
+}
+
+// addHTTPHandler registers the CX function `fn`, which receives an
+// http.Request and returns an http.Response, as the program's handler of the
+// requests to `path`
+func addHTTPHandler(prgrm *CXProgram, path string, fn *CXFunction) (err error) {
+	if len(fn.Inputs) != 1 || !isHTTPStruct(fn.Inputs[0], "Request") ||
+		len(fn.Outputs) != 1 || !isHTTPStruct(fn.Outputs[0], "Response") {
+		return fmt.Errorf("handler '%s' must receive an http.Request and return an http.Response", fn.Name)
+	}
 
-	var netClient = &http.Client{
-		Timeout: time.Second * 10,
+	if _, found := prgrm.HTTPHandlers[path]; found {
+		return fmt.Errorf("a handler for '%s' is already registered", path)
 	}
-	resp, err = netClient.Get(ReadStr(stack, fp, inp1))
-	
-	// resp, err = http.Get(ReadStr(stack, fp, inp1))
 
+	// ServeMux panics on invalid patterns, so they're checked with a mux
+	// that's thrown away
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	http.NewServeMux().HandleFunc(path, http.NotFound)
+
+	if prgrm.HTTPHandlers == nil {
+		prgrm.HTTPHandlers = make(map[string]*CXFunction)
+	}
+	prgrm.HTTPHandlers[path] = fn
+
+	return nil
+}
+
+// newHTTPServeMux makes a mux that answers the requests with the handlers
+// registered by the program
+func newHTTPServeMux(prgrm *CXProgram) *http.ServeMux {
+	mux := http.NewServeMux()
+	for path, fn := range prgrm.HTTPHandlers {
+		fn := fn
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			serveHTTP(prgrm, fn, w, r)
+		})
+	}
+	return mux
+}
+
+// serveHTTP answers `r` with the response returned by the handler `fn`.
+// If the handler fails or calls os.Exit, the client receives an internal
+// server error
+func serveHTTP(prgrm *CXProgram, fn *CXFunction, w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		panic(err)
-	}
-
-	defer resp.Body.Close()
-        contents, err = ioutil.ReadAll(resp.Body)
-        if err != nil {
-		panic(err)
-        }
-
-	byts := encoder.Serialize(string(contents))
-	length := len(byts)
-	heapOffset := AllocateSeq(stack.Program, length+OBJECT_HEADER_SIZE)
-	size := encoder.Serialize(int32(len(byts)))
-
-	var header []byte = make([]byte, OBJECT_HEADER_SIZE, OBJECT_HEADER_SIZE)
-	for c := 5; c < OBJECT_HEADER_SIZE; c++ {
-		header[c] = size[c-5]
-	}
-
-	obj := append(header, byts...)
-
-	WriteToHeap(&stack.Program.Heap, heapOffset, obj)
-
-	off := encoder.SerializeAtomic(int32(heapOffset))
-
-	WriteToStack(stack, out1Offset, off)
-}
-
-
-
-// type Response struct {
-//         Status     string // e.g. "200 OK"
-//         StatusCode int    // e.g. 200
-//         Proto      string // e.g. "HTTP/1.0"
-//         ProtoMajor int    // e.g. 1
-//         ProtoMinor int    // e.g. 0
-
-//         // Header maps header keys to values. If the response had multiple
-//         // headers with the same key, they may be concatenated, with comma
-//         // delimiters.  (Section 4.2 of RFC 2616 requires that multiple headers
-//         // be semantically equivalent to a comma-delimited sequence.) When
-//         // Header values are duplicated by other fields in this struct (e.g.,
-//         // ContentLength, TransferEncoding, Trailer), the field values are
-//         // authoritative.
-//         //
-//         // Keys in the map are canonicalized (see CanonicalHeaderKey).
-//         Header Header
-
-//         // Body represents the response body.
-//         //
-//         // The response body is streamed on demand as the Body field
-//         // is read. If the network connection fails or the server
-//         // terminates the response, Body.Read calls return an error.
-//         //
-//         // The http Client and Transport guarantee that Body is always
-//         // non-nil, even on responses without a body or responses with
-//         // a zero-length body. It is the caller's responsibility to
-//         // close Body. The default HTTP client's Transport may not
-//         // reuse HTTP/1.x "keep-alive" TCP connections if the Body is
-//         // not read to completion and closed.
-//         //
-//         // The Body is automatically dechunked if the server replied
-//         // with a "chunked" Transfer-Encoding.
-//         Body io.ReadCloser
-
-//         // ContentLength records the length of the associated content. The
-//         // value -1 indicates that the length is unknown. Unless Request.Method
-//         // is "HEAD", values >= 0 indicate that the given number of bytes may
-//         // be read from Body.
-//         ContentLength int64
-
-//         // Contains transfer encodings from outer-most to inner-most. Value is
-//         // nil, means that "identity" encoding is used.
-//         TransferEncoding []string
-
-//         // Close records whether the header directed that the connection be
-//         // closed after reading Body. The value is advice for clients: neither
-//         // ReadResponse nor Response.Write ever closes a connection.
-//         Close bool
-
-//         // Uncompressed reports whether the response was sent compressed but
-//         // was decompressed by the http package. When true, reading from
-//         // Body yields the uncompressed content instead of the compressed
-//         // content actually set from the server, ContentLength is set to -1,
-//         // and the "Content-Length" and "Content-Encoding" fields are deleted
-//         // from the responseHeader. To get the original response from
-//         // the server, set Transport.DisableCompression to true.
-//         Uncompressed bool
-
-//         // Trailer maps trailer keys to values in the same
-//         // format as Header.
-//         //
-//         // The Trailer initially contains only nil values, one for
-//         // each key specified in the server's "Trailer" header
-//         // value. Those values are not added to Header.
-//         //
-//         // Trailer must not be accessed concurrently with Read calls
-//         // on the Body.
-//         //
-//         // After Body.Read has returned io.EOF, Trailer will contain
-//         // any trailer values sent by the server.
-//         Trailer Header
-
-//         // Request is the request that was sent to obtain this Response.
-//         // Request's Body is nil (having already been consumed).
-//         // This is only populated for Client requests.
-//         Request *Request
-
-//         // TLS contains information about the TLS connection on which the
-//         // response was received. It is nil for unencrypted responses.
-//         // The pointer is shared between responses and should not be
-//         // modified.
-//         TLS *tls.ConnectionState
-// }
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reqFlds := map[string]interface{}{
+		"Method": r.Method,
+		"URL":    r.URL.String(),
+		"Header": formatHeader(r.Header),
+		"Body":   string(body),
+	}
+	var respFlds map[string]interface{}
+
+	httpServeLock.Lock()
+	exited := prgrm.Exited
+	if !exited {
+		stack := &prgrm.Stacks[0]
+		err = prgrm.CallFunction(fn, func(fp int) {
+			writeStructFields(stack, fp, fn.Inputs[0], reqFlds)
+		}, func(fp int) {
+			respFlds = readStructFields(stack, fp, fn.Outputs[0])
+		})
+		exited = prgrm.Exited
+	}
+	httpServeLock.Unlock()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if exited {
+		http.Error(w, "the program exited", http.StatusInternalServerError)
+		return
+	}
+
+	header, _ := respFlds["Header"].(string)
+	for key, vals := range parseHeader(header) {
+		for _, val := range vals {
+			w.Header().Add(key, val)
+		}
+	}
+
+	statusCode, _ := respFlds["StatusCode"].(int32)
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(int(statusCode))
+
+	respBody, _ := respFlds["Body"].(string)
+	io.WriteString(w, respBody)
+}
+
+// op_http_handle. The Handle built-in function registers the function named
+// `handlerFn` as the handler of the requests to `path`. The function must
+// receive an http.Request and return an http.Response. The optional output
+// describes why the handler couldn't be registered
+func op_http_handle(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	prgrm := stack.Program
+
+	path := ReadStr(stack, fp, inp1)
+	fnName := ReadStr(stack, fp, inp2)
+
+	pkgName := expr.Package.Name
+	if idx := strings.LastIndex(fnName, "."); idx >= 0 {
+		pkgName, fnName = fnName[:idx], fnName[idx+1:]
+	}
+
+	var errMsg string
+	if fn, err := prgrm.GetFunction(fnName, pkgName); err != nil {
+		errMsg = err.Error()
+	} else if err := addHTTPHandler(prgrm, path, fn); err != nil {
+		errMsg = err.Error()
+	}
+
+	if len(expr.Outputs) > 0 {
+		WriteString(stack, fp, expr.Outputs[0], errMsg)
+	}
+}
+
+// serveHTTPHandlers serves the handlers registered by the program on `ln`.
+// It returns when the server fails, or when a handler calls os.Exit, which
+// shuts the server down
+func serveHTTPHandlers(prgrm *CXProgram, ln net.Listener) error {
+	mux := newHTTPServeMux(prgrm)
+	srv := &http.Server{}
+	srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+
+		httpServeLock.Lock()
+		exited := prgrm.Exited
+		httpServeLock.Unlock()
+		if exited {
+			// Shutdown waits for this handler to return
+			go srv.Shutdown(context.Background())
+		}
+	})
+
+	err := srv.Serve(ln)
+	if err == http.ErrServerClosed && prgrm.Exited {
+		return nil
+	}
+	return err
+}
+
+// op_http_listen_and_serve. The ListenAndServe built-in function serves the
+// registered handlers at the network address `addr`, such as ":8080". It only
+// returns if the server fails, with the error as its optional output, or if a
+// handler calls os.Exit, which terminates the program
+func op_http_listen_and_serve(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+
+	addr := ReadStr(stack, fp, inp1)
+	if addr == "" {
+		addr = ":http"
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err == nil {
+		err = serveHTTPHandlers(stack.Program, ln)
+	}
+
+	if err != nil && len(expr.Outputs) > 0 {
+		WriteString(stack, fp, expr.Outputs[0], err.Error())
+	}
+}
+
+// readStructFields reads the basic fields of the struct `inp`, keyed by name.
+// Fields of other types are skipped
+func readStructFields(stack *CXStack, fp int, inp *CXArgument) map[string]interface{} {
+	flds := make(map[string]interface{})
+	if inp.CustomType == nil {
+		return flds
+	}
+
+	byts := ReadMemory(stack, GetFinalOffset(stack, fp, inp, MEM_READ), inp)
+
+	var offset int
+	for _, fld := range inp.CustomType.Fields {
+		if offset+fld.TotalSize > len(byts) {
+			break
+		}
+		fldByts := byts[offset : offset+fld.TotalSize]
+		offset += fld.TotalSize
+
+		if len(fld.Lengths) > 0 {
+			continue
+		}
+
+		switch fld.Type {
+		case TYPE_STR:
+			var heapOffset int32
+			encoder.DeserializeAtomic(fldByts[:TYPE_POINTER_SIZE], &heapOffset)
+			if heapOffset == NULL_HEAP_ADDRESS {
+				flds[fld.Name] = ""
+			} else {
+				flds[fld.Name] = readStrFromHeap(stack.Program, heapOffset)
+			}
+		case TYPE_BOOL:
+			flds[fld.Name] = fldByts[0] == 1
+		case TYPE_I32:
+			var val int32
+			encoder.DeserializeAtomic(fldByts, &val)
+			flds[fld.Name] = val
+		case TYPE_I64:
+			var val int64
+			encoder.DeserializeRaw(fldByts, &val)
+			flds[fld.Name] = val
+		case TYPE_F32:
+			var val float32
+			encoder.DeserializeRaw(fldByts, &val)
+			flds[fld.Name] = val
+		case TYPE_F64:
+			var val float64
+			encoder.DeserializeRaw(fldByts, &val)
+			flds[fld.Name] = val
+		}
+	}
+
+	return flds
+}
+
+// writeStructFields writes `flds` to the fields with the same name of the
+// struct `out`. Strings are allocated in the heap and fields not in `flds` are
+// set to their zero value
+func writeStructFields(stack *CXStack, fp int, out *CXArgument, flds map[string]interface{}) {
+	if out.CustomType == nil {
+		return
+	}
+
+	var byts []byte
+	for _, fld := range out.CustomType.Fields {
+		fldByts := make([]byte, fld.TotalSize)
+
+		switch val := flds[fld.Name].(type) {
+		case string:
+			heapOffset := WriteObject(stack.Program, encoder.Serialize(val))
+			copy(fldByts, encoder.SerializeAtomic(int32(heapOffset)))
+		case bool:
+			copy(fldByts, FromBool(val))
+		case int32:
+			copy(fldByts, FromI32(val))
+		case int64:
+			copy(fldByts, FromI64(val))
+		case float32:
+			copy(fldByts, FromF32(val))
+		case float64:
+			copy(fldByts, FromF64(val))
+		}
+
+		byts = append(byts, fldByts...)
+	}
+
+	WriteMemory(stack, GetFinalOffset(stack, fp, out, MEM_WRITE), out, byts)
+}
@@ -0,0 +1,335 @@
+package base
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Token", r.Header.Get("X-Token"))
+		w.Write([]byte("Hello, CX!"))
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestHTTPGet(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	resp := httpDo(httpRequest{URL: srv.URL + "/hello", Header: "X-Token: abc"})
+	if resp.Error != "" {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	if resp.StatusCode != 200 || resp.Status != "200 OK" {
+		t.Errorf("status = %d %q, want 200 \"200 OK\"", resp.StatusCode, resp.Status)
+	}
+	if resp.Proto != "HTTP/1.1" || resp.ProtoMajor != 1 || resp.ProtoMinor != 1 {
+		t.Errorf("proto = %q %d.%d, want HTTP/1.1", resp.Proto, resp.ProtoMajor, resp.ProtoMinor)
+	}
+	if resp.Body != "Hello, CX!" {
+		t.Errorf("body = %q, want %q", resp.Body, "Hello, CX!")
+	}
+
+	hdr := parseHeader(resp.Header)
+	if hdr.Get("X-Method") != "GET" || hdr.Get("X-Token") != "abc" {
+		t.Errorf("header = %q, want the method and token echoed", resp.Header)
+	}
+}
+
+func TestHTTPPost(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	resp := httpDo(httpRequest{
+		Method: "post",
+		URL:    srv.URL + "/echo",
+		Header: "Content-Type: application/json",
+		Body:   `{"a": 1}`,
+	})
+	if resp.Error != "" {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("status code = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	if resp.Body != `{"a": 1}` {
+		t.Errorf("body = %q, want the request's body", resp.Body)
+	}
+	if parseHeader(resp.Header).Get("Content-Type") != "application/json" {
+		t.Errorf("header = %q, want the content type echoed", resp.Header)
+	}
+}
+
+func TestHTTPErrors(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	resp := httpDo(httpRequest{URL: srv.URL + "/slow", Timeout: 50})
+	if resp.Error == "" {
+		t.Errorf("expected a timeout error")
+	}
+
+	resp = httpDo(httpRequest{URL: srv.URL + "/missing"})
+	if resp.Error != "" || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("got %d %q, want a 404 without error", resp.StatusCode, resp.Error)
+	}
+
+	resp = httpDo(httpRequest{URL: "http://127.0.0.1:0/"})
+	if resp.Error == "" || resp.StatusCode != 0 {
+		t.Errorf("expected a connection error")
+	}
+
+	resp = httpDo(httpRequest{Method: "BAD METHOD", URL: srv.URL})
+	if resp.Error == "" {
+		t.Errorf("expected an invalid method error")
+	}
+}
+
+func TestHTTPHeader(t *testing.T) {
+	hdr := parseHeader("Accept: text/plain\n\nmalformed\nX-A:  1 \nx-a: 2")
+	if hdr.Get("Accept") != "text/plain" {
+		t.Errorf("Accept = %q", hdr.Get("Accept"))
+	}
+	if vals := hdr["X-A"]; len(vals) != 2 || vals[0] != "1" || vals[1] != "2" {
+		t.Errorf("X-A = %q, want [1 2]", vals)
+	}
+	if out := formatHeader(hdr); out != "Accept: text/plain\nX-A: 1\nX-A: 2" {
+		t.Errorf("formatHeader = %q", out)
+	}
+}
+
+func TestHTTPPackage(t *testing.T) {
+	prgrm := MakeProgram(CALLSTACK_SIZE, STACK_SIZE, INIT_HEAP_SIZE)
+	main := MakePackage(MAIN_PKG)
+	prgrm.AddPackage(main)
+
+	pkg, err := prgrm.AddCorePackage("http")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prgrm.CurrentPackage != main {
+		t.Errorf("current package changed to %s", prgrm.CurrentPackage.Name)
+	}
+	if _, err := prgrm.AddCorePackage("nonexistent"); err == nil {
+		t.Errorf("expected an error for an unknown package")
+	}
+
+	resp, err := prgrm.GetStruct("Response", pkg.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Size != 8*4 {
+		t.Errorf("Response size = %d, want %d", resp.Size, 8*4)
+	}
+	for _, name := range []string{"Get", "Post", "Do"} {
+		fn, err := prgrm.GetFunction(name, pkg.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fn.IsNative || fn.Outputs[0].CustomType != resp {
+			t.Errorf("%s should be a native returning an http.Response", name)
+		}
+	}
+
+	// the fields written to a Response can be read back
+	out := MakeCoreStructArgument("resp", resp)
+	out.MemoryRead = MEM_STACK
+	out.MemoryWrite = MEM_STACK
+	out.Program = prgrm
+
+	stack := &prgrm.Stacks[0]
+	writeStructFields(stack, 0, out, map[string]interface{}{
+		"StatusCode": int32(404),
+		"Body":       "not found",
+	})
+	flds := readStructFields(stack, 0, out)
+	if flds["StatusCode"] != int32(404) || flds["Body"] != "not found" || flds["Status"] != "" {
+		t.Errorf("fields = %v", flds)
+	}
+}
+
+// fieldArgument returns the field `name` of the struct argument `arg`
+func fieldArgument(arg *CXArgument, name string) *CXArgument {
+	var offset int
+	for _, fld := range arg.CustomType.Fields {
+		if fld.Name == name {
+			fldArg := *fld
+			fldArg.Offset = offset
+
+			out := *arg
+			out.Fields = []*CXArgument{&fldArg}
+			out.DereferenceOperations = []int{DEREF_FIELD}
+			out.Size = fld.Size
+			out.TotalSize = fld.TotalSize
+			return &out
+		}
+		offset += fld.TotalSize
+	}
+	return nil
+}
+
+// makeEchoHandler makes the equivalent of:
+//
+//	func echo(req http.Request) (resp http.Response) {
+//		resp.Body = req.Body
+//	}
+func makeEchoHandler(prgrm *CXProgram, pkg *CXPackage) *CXFunction {
+	httpPkg, _ := prgrm.GetPackage("http")
+	reqStrct, _ := prgrm.GetStruct("Request", httpPkg.Name)
+	respStrct, _ := prgrm.GetStruct("Response", httpPkg.Name)
+
+	req := MakeCoreStructArgument("req", reqStrct)
+	resp := MakeCoreStructArgument("resp", respStrct)
+	resp.Offset = req.TotalSize
+
+	fn := MakeFunction("echo")
+	pkg.AddFunction(fn)
+	fn.Inputs = []*CXArgument{req}
+	fn.Outputs = []*CXArgument{resp}
+	fn.Size = req.TotalSize + resp.TotalSize
+
+	expr := MakeExpression(Natives[OP_IDENTITY], "", 0)
+	expr.AddInput(fieldArgument(req, "Body"))
+	expr.AddOutput(fieldArgument(resp, "Body"))
+	fn.AddExpression(expr)
+	fn.Length = len(fn.Expressions)
+
+	return fn
+}
+
+func TestHTTPServer(t *testing.T) {
+	prgrm := MakeProgram(CALLSTACK_SIZE, STACK_SIZE, INIT_HEAP_SIZE)
+	main := MakePackage(MAIN_PKG)
+	prgrm.AddPackage(main)
+	if _, err := prgrm.AddCorePackage("http"); err != nil {
+		t.Fatal(err)
+	}
+
+	fn := makeEchoHandler(prgrm, main)
+	if err := addHTTPHandler(prgrm, "/echo", fn); err != nil {
+		t.Fatal(err)
+	}
+	if err := addHTTPHandler(prgrm, "/echo", fn); err == nil {
+		t.Errorf("expected an error registering a path twice")
+	}
+	if err := addHTTPHandler(prgrm, "/bad", MakeFunction("bad")); err == nil {
+		t.Errorf("expected an error registering a handler with a wrong signature")
+	}
+	if err := addHTTPHandler(prgrm, "", fn); err == nil {
+		t.Errorf("expected an error registering an invalid path")
+	}
+
+	// handlers are registered per program
+	other := MakeProgram(CALLSTACK_SIZE, STACK_SIZE, INIT_HEAP_SIZE)
+	if err := addHTTPHandler(other, "/echo", fn); err != nil {
+		t.Errorf("registering a path in another program: %v", err)
+	}
+
+	srv := httptest.NewServer(newHTTPServeMux(prgrm))
+	defer srv.Close()
+
+	// requests are served one at a time, so concurrent requests can't
+	// overwrite each other's stack frames
+	var wg sync.WaitGroup
+	for c := 0; c < 10; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+
+			body := fmt.Sprintf("request %d", c)
+			resp, err := http.Post(srv.URL+"/echo", "text/plain", strings.NewReader(body))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer resp.Body.Close()
+
+			echo, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusOK || string(echo) != body {
+				t.Errorf("got %d %q, want 200 %q", resp.StatusCode, echo, body)
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	if prgrm.CallCounter != 0 || prgrm.CallBase != 0 || prgrm.Stacks[0].StackPointer != 0 {
+		t.Errorf("the call state wasn't restored after the handlers ran")
+	}
+}
+
+// makeExitHandler makes the equivalent of:
+//
+//	func exit(req http.Request) (resp http.Response) {
+//		os.Exit(4)
+//		resp.Body = req.Body
+//	}
+func makeExitHandler(prgrm *CXProgram, pkg *CXPackage) *CXFunction {
+	fn := makeEchoHandler(prgrm, pkg)
+	fn.Name = "exit"
+
+	exit := MakeExpression(Natives[OP_OS_EXIT], "", 0)
+	exit.AddInput(testData(prgrm, "", TYPE_I32, FromI32(4)))
+	fn.Expressions = append([]*CXExpression{exit}, fn.Expressions...)
+	fn.Length = len(fn.Expressions)
+
+	return fn
+}
+
+func TestHTTPServerExit(t *testing.T) {
+	prgrm, main := makeTestProgram()
+	if _, err := prgrm.AddCorePackage("http"); err != nil {
+		t.Fatal(err)
+	}
+	if err := addHTTPHandler(prgrm, "/exit", makeExitHandler(prgrm, main)); err != nil {
+		t.Fatal(err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- serveHTTPHandlers(prgrm, ln)
+	}()
+
+	resp, err := http.Post("http://"+ln.Addr().String()+"/exit", "text/plain", strings.NewReader("body"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("status code = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server didn't shut down after os.Exit")
+	}
+	if !prgrm.Exited || !prgrm.Terminated || prgrm.ExitCode != 4 {
+		t.Errorf("exited = %v, terminated = %v with %d, want true, true with 4", prgrm.Exited, prgrm.Terminated, prgrm.ExitCode)
+	}
+}
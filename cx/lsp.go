@@ -0,0 +1,921 @@
+package base
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// error codes of the JSON-RPC responses
+const (
+	LSP_PARSE_ERROR      = -32700
+	LSP_INVALID_REQUEST  = -32600
+	LSP_METHOD_NOT_FOUND = -32601
+	LSP_INVALID_PARAMS   = -32602
+	LSP_INTERNAL_ERROR   = -32603
+)
+
+// kinds of the completion items
+const (
+	LSP_COMPLETION_FUNCTION = 3
+	LSP_COMPLETION_FIELD    = 5
+	LSP_COMPLETION_VARIABLE = 6
+	LSP_COMPLETION_MODULE   = 9
+	LSP_COMPLETION_STRUCT   = 22
+)
+
+// kinds of the document symbols
+const (
+	LSP_SYMBOL_FIELD    = 8
+	LSP_SYMBOL_FUNCTION = 12
+	LSP_SYMBOL_VARIABLE = 13
+	LSP_SYMBOL_STRUCT   = 23
+)
+
+// CompileError is an error found compiling a program, at a line of one of
+// its files
+type CompileError struct {
+	FileName string
+	FileLine int
+	Message  string
+}
+
+func (err CompileError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", err.FileName, err.FileLine, err.Message)
+}
+
+// LSPCompileFunc compiles the program the file `fileName` belongs to, reading
+// the files open in the editor from `open`, by file name, instead of from
+// disk. A program with errors is returned with the declarations compiled
+// before the errors stopped it
+type LSPCompileFunc func(fileName string, open map[string]string) (*CXProgram, []CompileError)
+
+// LSPServer speaks the Language Server Protocol, so editors can show the
+// errors of CX programs as they are edited, and navigate and complete their
+// declarations. It reads requests from `in` and writes responses and
+// notifications to `out`. Every change to a document compiles its program
+// again with the parser of cx
+type LSPServer struct {
+	in      *bufio.Reader
+	out     io.Writer
+	compile LSPCompileFunc
+
+	docs      map[string]*lspDocument // open documents, by URI
+	diagnosed map[string][]string     // documents with errors found compiling a document, by URI
+	shutdown  bool
+	done      bool
+}
+
+// lspDocument is a document open in the editor, with the last program it
+// compiled to. A program with errors only replaces one without them, as
+// it's missing the declarations after the errors
+type lspDocument struct {
+	uri      string
+	fileName string
+	text     string
+	prgrm    *CXProgram
+	failed   bool // the program has errors
+}
+
+// lspDecl is a declaration found in a program
+type lspDecl struct {
+	name     string
+	detail   string // the declaration as written, e.g. func foo(a i32) (b i32)
+	kind     int    // kind of its completion item
+	fileName string
+	fileLine int
+}
+
+type lspRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type lspResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result"`
+}
+
+type lspErrorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   *lspError       `json:"error"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err *lspError) Error() string {
+	return err.Message
+}
+
+type lspNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspTextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type lspDidOpenParams struct {
+	TextDocument struct {
+		URI  string `json:"uri"`
+		Text string `json:"text"`
+	} `json:"textDocument"`
+}
+
+type lspDidChangeParams struct {
+	TextDocument   lspTextDocumentIdentifier `json:"textDocument"`
+	ContentChanges []struct {
+		Range *lspRange `json:"range"`
+		Text  string    `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspDocumentParams struct {
+	TextDocument lspTextDocumentIdentifier `json:"textDocument"`
+}
+
+type lspPositionParams struct {
+	TextDocument lspTextDocumentIdentifier `json:"textDocument"`
+	Position     lspPosition               `json:"position"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspPublishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+type lspMarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspHover struct {
+	Contents lspMarkupContent `json:"contents"`
+}
+
+type lspCompletionItem struct {
+	Label  string `json:"label"`
+	Kind   int    `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type lspCompletionList struct {
+	IsIncomplete bool                `json:"isIncomplete"`
+	Items        []lspCompletionItem `json:"items"`
+}
+
+type lspDocumentSymbol struct {
+	Name           string              `json:"name"`
+	Detail         string              `json:"detail,omitempty"`
+	Kind           int                 `json:"kind"`
+	Range          lspRange            `json:"range"`
+	SelectionRange lspRange            `json:"selectionRange"`
+	Children       []lspDocumentSymbol `json:"children,omitempty"`
+}
+
+// NewLSPServer returns a server reading requests from `r` and writing to
+// `w`, which compiles the programs of the documents with `compile`
+func NewLSPServer(r io.Reader, w io.Writer, compile LSPCompileFunc) *LSPServer {
+	return &LSPServer{
+		in:        bufio.NewReader(r),
+		out:       w,
+		compile:   compile,
+		docs:      make(map[string]*lspDocument),
+		diagnosed: make(map[string][]string),
+	}
+}
+
+// Serve handles requests until the client asks the server to exit, or closes
+// the input. It returns an error if the client exits without shutting the
+// server down first
+func (srv *LSPServer) Serve() error {
+	for !srv.done {
+		body, err := readProtocolMessage(srv.in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req lspRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeProtocolMessage(srv.out, lspErrorResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &lspError{Code: LSP_PARSE_ERROR, Message: err.Error()}})
+			continue
+		}
+		srv.handle(&req)
+	}
+
+	if !srv.shutdown {
+		return errors.New("exit before shutdown")
+	}
+	return nil
+}
+
+// handle responds to a request, or runs a notification, which has no id.
+// Panics are reported as internal errors, so a bug doesn't stop the server
+func (srv *LSPServer) handle(req *lspRequest) {
+	var result interface{}
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &lspError{Code: LSP_INTERNAL_ERROR, Message: fmt.Sprintf("%v", r)}
+			}
+		}()
+		result, err = srv.dispatch(req)
+	}()
+
+	if len(req.ID) == 0 || string(req.ID) == "null" {
+		return
+	}
+	if err != nil {
+		rpcErr, ok := err.(*lspError)
+		if !ok {
+			rpcErr = &lspError{Code: LSP_INVALID_PARAMS, Message: err.Error()}
+		}
+		writeProtocolMessage(srv.out, lspErrorResponse{JSONRPC: "2.0", ID: req.ID, Error: rpcErr})
+		return
+	}
+	writeProtocolMessage(srv.out, lspResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func (srv *LSPServer) notify(method string, params interface{}) {
+	writeProtocolMessage(srv.out, lspNotification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (srv *LSPServer) dispatch(req *lspRequest) (interface{}, error) {
+	if srv.shutdown && req.Method != "exit" {
+		return nil, &lspError{Code: LSP_INVALID_REQUEST, Message: "the server was shut down"}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				// the changes are sent incrementally
+				"textDocumentSync":       map[string]interface{}{"openClose": true, "change": 2},
+				"definitionProvider":     true,
+				"hoverProvider":          true,
+				"completionProvider":     map[string]interface{}{"triggerCharacters": []string{"."}},
+				"documentSymbolProvider": true,
+			},
+			"serverInfo": map[string]string{"name": "cx"},
+		}, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		srv.shutdown = true
+		return nil, nil
+	case "exit":
+		srv.done = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params lspDidOpenParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		doc := &lspDocument{uri: params.TextDocument.URI, fileName: lspFileName(params.TextDocument.URI), text: params.TextDocument.Text}
+		srv.docs[doc.uri] = doc
+		srv.check(doc)
+		return nil, nil
+	case "textDocument/didChange":
+		var params lspDidChangeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		doc, err := srv.document(params.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range params.ContentChanges {
+			if change.Range == nil {
+				doc.text = change.Text
+				continue
+			}
+			start, end := lspOffset(doc.text, change.Range.Start), lspOffset(doc.text, change.Range.End)
+			if end < start {
+				start, end = end, start
+			}
+			doc.text = doc.text[:start] + change.Text + doc.text[end:]
+		}
+		srv.check(doc)
+		return nil, nil
+	case "textDocument/didClose":
+		var params lspDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(srv.docs, params.TextDocument.URI)
+		srv.notify("textDocument/publishDiagnostics", lspPublishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []lspDiagnostic{}})
+		return nil, nil
+	case "textDocument/didSave":
+		return nil, nil
+	case "textDocument/definition":
+		return srv.definition(req.Params)
+	case "textDocument/hover":
+		return srv.hover(req.Params)
+	case "textDocument/completion":
+		return srv.completion(req.Params)
+	case "textDocument/documentSymbol":
+		return srv.documentSymbols(req.Params)
+	}
+
+	return nil, &lspError{Code: LSP_METHOD_NOT_FOUND, Message: fmt.Sprintf("unsupported method %q", req.Method)}
+}
+
+// document returns an open document
+func (srv *LSPServer) document(uri string) (*lspDocument, error) {
+	doc, found := srv.docs[uri]
+	if !found {
+		return nil, fmt.Errorf("document %s is not open", uri)
+	}
+	return doc, nil
+}
+
+// check compiles the program of a document and publishes the errors found
+// in each of its files. The files that had errors the last time the document
+// was checked and don't anymore get their errors cleared
+func (srv *LSPServer) check(doc *lspDocument) {
+	open := make(map[string]string)
+	for _, d := range srv.docs {
+		open[d.fileName] = d.text
+	}
+
+	prgrm, errs := srv.compile(doc.fileName, open)
+	if prgrm != nil && (len(errs) == 0 || doc.prgrm == nil || doc.failed) {
+		doc.prgrm, doc.failed = prgrm, len(errs) > 0
+	}
+
+	diags := map[string][]lspDiagnostic{doc.uri: {}}
+	for _, err := range errs {
+		fileName := err.FileName
+		if fileName == "" {
+			fileName = doc.fileName
+		}
+		uri := lspURI(fileName)
+		if _, found := srv.docs[uri]; !found && lspSameFile(fileName, doc.fileName) {
+			uri = doc.uri
+		}
+		diags[uri] = append(diags[uri], lspDiagnostic{
+			Range:    srv.lineRange(fileName, err.FileLine),
+			Severity: 1,
+			Source:   "cx",
+			Message:  err.Message,
+		})
+	}
+	for _, uri := range srv.diagnosed[doc.uri] {
+		if _, found := diags[uri]; !found {
+			diags[uri] = []lspDiagnostic{}
+		}
+	}
+
+	uris := make([]string, 0, len(diags))
+	for uri := range diags {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	srv.diagnosed[doc.uri] = nil
+	for _, uri := range uris {
+		srv.notify("textDocument/publishDiagnostics", lspPublishDiagnosticsParams{URI: uri, Diagnostics: diags[uri]})
+		if len(diags[uri]) > 0 {
+			srv.diagnosed[doc.uri] = append(srv.diagnosed[doc.uri], uri)
+		}
+	}
+}
+
+// text returns the text of a file, from the editor if it's open
+func (srv *LSPServer) text(fileName string) string {
+	for _, doc := range srv.docs {
+		if lspSameFile(doc.fileName, fileName) {
+			return doc.text
+		}
+	}
+	byts, _ := ioutil.ReadFile(fileName)
+	return string(byts)
+}
+
+// lineRange returns the range of the text of a line, counted from 1, without
+// its indentation
+func (srv *LSPServer) lineRange(fileName string, fileLine int) lspRange {
+	line := fileLine - 1
+	if line < 0 {
+		line = 0
+	}
+	text := lspLine(srv.text(fileName), line)
+	indent := len(text) - len(strings.TrimLeft(text, " \t"))
+	return lspRange{
+		Start: lspPosition{Line: line, Character: lspLength(text[:indent])},
+		End:   lspPosition{Line: line, Character: lspLength(strings.TrimRight(text, " \t\r"))},
+	}
+}
+
+// location returns where a declaration is, at its name
+func (srv *LSPServer) location(decl lspDecl) *lspLocation {
+	if decl.fileName == "" || decl.fileLine < 1 {
+		return nil
+	}
+	line := lspLine(srv.text(decl.fileName), decl.fileLine-1)
+	var char int
+	for idx := 0; idx+len(decl.name) <= len(line); idx++ {
+		end := idx + len(decl.name)
+		if line[idx:end] == decl.name && (idx == 0 || !isIdentByte(line[idx-1])) && (end == len(line) || !isIdentByte(line[end])) {
+			char = lspLength(line[:idx])
+			break
+		}
+	}
+	pos := lspPosition{Line: decl.fileLine - 1, Character: char}
+	end := pos
+	end.Character += lspLength(decl.name)
+	return &lspLocation{URI: lspURI(decl.fileName), Range: lspRange{Start: pos, End: end}}
+}
+
+// positionParams returns the document and the position of a request
+func (srv *LSPServer) positionParams(raw json.RawMessage) (*lspDocument, lspPosition, error) {
+	var params lspPositionParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, params.Position, err
+	}
+	doc, err := srv.document(params.TextDocument.URI)
+	return doc, params.Position, err
+}
+
+// resolve finds the declaration of the identifier at a position of a
+// document
+func (srv *LSPServer) resolve(doc *lspDocument, pos lspPosition) (lspDecl, bool) {
+	if doc.prgrm == nil {
+		return lspDecl{}, false
+	}
+	qualifier, ident := lspIdentAt(doc.text, lspOffset(doc.text, pos), false)
+	if ident == "" {
+		return lspDecl{}, false
+	}
+
+	var decls []lspDecl
+	if qualifier != "" {
+		decls = lspMembers(doc.prgrm, doc, pos.Line+1, qualifier)
+	} else {
+		decls = lspScope(doc.prgrm, doc, pos.Line+1)
+	}
+	for _, decl := range decls {
+		if decl.name == ident {
+			return decl, true
+		}
+	}
+	return lspDecl{}, false
+}
+
+func (srv *LSPServer) definition(raw json.RawMessage) (interface{}, error) {
+	doc, pos, err := srv.positionParams(raw)
+	if err != nil {
+		return nil, err
+	}
+	if decl, found := srv.resolve(doc, pos); found {
+		if loc := srv.location(decl); loc != nil {
+			return loc, nil
+		}
+	}
+	return nil, nil
+}
+
+func (srv *LSPServer) hover(raw json.RawMessage) (interface{}, error) {
+	doc, pos, err := srv.positionParams(raw)
+	if err != nil {
+		return nil, err
+	}
+	if decl, found := srv.resolve(doc, pos); found {
+		return lspHover{Contents: lspMarkupContent{Kind: "markdown", Value: "```cx\n" + decl.detail + "\n```"}}, nil
+	}
+	return nil, nil
+}
+
+// completion lists the declarations the identifier being written can be: the
+// members of a package or the fields of a struct after a period, or what's
+// in scope
+func (srv *LSPServer) completion(raw json.RawMessage) (interface{}, error) {
+	doc, pos, err := srv.positionParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	list := lspCompletionList{Items: []lspCompletionItem{}}
+	if doc.prgrm == nil {
+		return list, nil
+	}
+
+	qualifier, partial := lspIdentAt(doc.text, lspOffset(doc.text, pos), true)
+	var decls []lspDecl
+	if qualifier != "" {
+		decls = lspMembers(doc.prgrm, doc, pos.Line+1, qualifier)
+	} else {
+		decls = lspScope(doc.prgrm, doc, pos.Line+1)
+	}
+
+	seen := make(map[string]bool)
+	for _, decl := range decls {
+		if seen[decl.name] || !strings.HasPrefix(decl.name, partial) {
+			continue
+		}
+		seen[decl.name] = true
+		list.Items = append(list.Items, lspCompletionItem{Label: decl.name, Kind: decl.kind, Detail: decl.detail})
+	}
+	return list, nil
+}
+
+// documentSymbols lists the functions, structs and globals declared in a
+// document, in the order they are declared
+func (srv *LSPServer) documentSymbols(raw json.RawMessage) (interface{}, error) {
+	var params lspDocumentParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, err
+	}
+	doc, err := srv.document(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := []lspDocumentSymbol{}
+	if doc.prgrm == nil {
+		return symbols, nil
+	}
+
+	// the range of a symbol spans the lines of its declaration
+	symbol := func(decl lspDecl, kind int, lastLine int) lspDocumentSymbol {
+		sym := lspDocumentSymbol{Name: decl.name, Detail: decl.detail, Kind: kind}
+		sym.SelectionRange = srv.lineRange(doc.fileName, decl.fileLine)
+		sym.Range = sym.SelectionRange
+		if lastLine > decl.fileLine {
+			sym.Range.End = srv.lineRange(doc.fileName, lastLine).End
+		}
+		return sym
+	}
+
+	for _, pkg := range doc.prgrm.Packages {
+		for _, fn := range pkg.Functions {
+			if fn.FileLine > 0 && lspSameFile(fn.FileName, doc.fileName) {
+				symbols = append(symbols, symbol(lspFunctionDecl(fn), LSP_SYMBOL_FUNCTION, lspLastLine(fn)))
+			}
+		}
+		for _, strct := range pkg.Structs {
+			if strct.FileLine < 1 || !lspSameFile(strct.FileName, doc.fileName) {
+				continue
+			}
+			lastLine := strct.FileLine
+			var fields []lspDocumentSymbol
+			for _, fld := range strct.Fields {
+				if fld.FileLine > lastLine {
+					lastLine = fld.FileLine
+				}
+				fields = append(fields, symbol(lspFieldDecl(fld), LSP_SYMBOL_FIELD, 0))
+			}
+			sym := symbol(lspStructDecl(strct), LSP_SYMBOL_STRUCT, lastLine)
+			sym.Children = fields
+			symbols = append(symbols, sym)
+		}
+		for _, glbl := range pkg.Globals {
+			if glbl.Name != "" && glbl.FileLine > 0 && lspSameFile(glbl.FileName, doc.fileName) {
+				symbols = append(symbols, symbol(lspVariableDecl(glbl), LSP_SYMBOL_VARIABLE, 0))
+			}
+		}
+	}
+
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return symbols[i].SelectionRange.Start.Line < symbols[j].SelectionRange.Start.Line
+	})
+	return symbols, nil
+}
+
+// lspFileName returns the file of a file:// URI
+func lspFileName(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	path := u.Path
+	// drive letters of Windows paths, e.g. /C:/main.cx
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// lspURI returns the file:// URI of a file
+func lspURI(fileName string) string {
+	if abs, err := filepath.Abs(fileName); err == nil {
+		fileName = abs
+	}
+	path := filepath.ToSlash(fileName)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// lspSameFile reports whether two file names are the same file
+func lspSameFile(fileName1, fileName2 string) bool {
+	if fileName1 == fileName2 {
+		return true
+	}
+	abs1, err1 := filepath.Abs(fileName1)
+	abs2, err2 := filepath.Abs(fileName2)
+	return err1 == nil && err2 == nil && abs1 == abs2
+}
+
+// lspLength returns the length of `s` in UTF-16 code units, the unit of the
+// characters of the positions
+func lspLength(s string) (n int) {
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// lspOffset returns the byte offset of a position in `text`
+func lspOffset(text string, pos lspPosition) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		idx := strings.IndexByte(text[offset:], '\n')
+		if idx < 0 {
+			return len(text)
+		}
+		offset += idx + 1
+	}
+	for units := 0; units < pos.Character && offset < len(text) && text[offset] != '\n'; {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		units += lspLength(string(r))
+		offset += size
+	}
+	return offset
+}
+
+// lspLine returns a line of `text`, counted from 0
+func lspLine(text string, line int) string {
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return strings.TrimSuffix(lines[line], "\r")
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isDigit(c)
+}
+
+// lspIdentAt returns the identifier at `offset` of `text`, with the
+// identifier before it if they are joined by a period, e.g. pkg in pkg.foo.
+// If `partial` is set, the identifier ends at `offset`, as when it's being
+// completed
+func lspIdentAt(text string, offset int, partial bool) (qualifier, ident string) {
+	start, end := offset, offset
+	for start > 0 && isIdentByte(text[start-1]) {
+		start--
+	}
+	if !partial {
+		for end < len(text) && isIdentByte(text[end]) {
+			end++
+		}
+	}
+	ident = text[start:end]
+	if ident != "" && isDigit(ident[0]) {
+		return "", ""
+	}
+
+	if start > 0 && text[start-1] == '.' {
+		qualEnd := start - 1
+		qualStart := qualEnd
+		for qualStart > 0 && isIdentByte(text[qualStart-1]) {
+			qualStart--
+		}
+		qualifier = text[qualStart:qualEnd]
+		if qualifier != "" && isDigit(qualifier[0]) {
+			return "", ""
+		}
+	}
+	return qualifier, ident
+}
+
+var lspPackageRegexp = regexp.MustCompile(`(?m)^\s*package\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+// lspPackage returns the package a document declares
+func lspPackage(prgrm *CXProgram, doc *lspDocument) *CXPackage {
+	name := MAIN_PKG
+	if match := lspPackageRegexp.FindStringSubmatch(doc.text); match != nil {
+		name = match[1]
+	}
+	pkg, _ := prgrm.GetPackage(name)
+	return pkg
+}
+
+// lspLastLine returns the last line of a function with code
+func lspLastLine(fn *CXFunction) int {
+	lastLine := fn.FileLine
+	for _, expr := range fn.Expressions {
+		if expr.FileLine > lastLine && expr.FileName == fn.FileName {
+			lastLine = expr.FileLine
+		}
+	}
+	return lastLine
+}
+
+// lspEnclosingFunction returns the function whose code is at a line of a
+// document, counted from 1, or nil if it's outside functions
+func lspEnclosingFunction(prgrm *CXProgram, doc *lspDocument, line int) *CXFunction {
+	var enclosing *CXFunction
+	for _, pkg := range prgrm.Packages {
+		for _, fn := range pkg.Functions {
+			// the closing brace is the line after the code
+			if fn.FileLine < 1 || line < fn.FileLine || line > lspLastLine(fn)+1 || !lspSameFile(fn.FileName, doc.fileName) {
+				continue
+			}
+			if enclosing == nil || fn.FileLine > enclosing.FileLine {
+				enclosing = fn
+			}
+		}
+	}
+	return enclosing
+}
+
+// lspScope returns the declarations visible at a line of a document: the
+// variables of the function it's in, the members of its package, and the
+// packages it imports
+func lspScope(prgrm *CXProgram, doc *lspDocument, line int) (decls []lspDecl) {
+	if fn := lspEnclosingFunction(prgrm, doc, line); fn != nil {
+		for _, decl := range debugVariables(fn) {
+			decls = append(decls, lspVariableDecl(decl))
+		}
+	}
+	pkg := lspPackage(prgrm, doc)
+	if pkg == nil {
+		return decls
+	}
+	decls = append(decls, lspPackageDecls(pkg)...)
+	for _, imp := range pkg.Imports {
+		if imp != pkg {
+			decls = append(decls, lspDecl{name: imp.Name, detail: "package " + imp.Name, kind: LSP_COMPLETION_MODULE})
+		}
+	}
+	return decls
+}
+
+// lspMembers returns the declarations that can follow `qualifier` and a
+// period at a line of a document: the fields of a struct variable, the
+// members of a package or the natives of a type, such as i32.add
+func lspMembers(prgrm *CXProgram, doc *lspDocument, line int, qualifier string) (decls []lspDecl) {
+	var scope []*CXArgument
+	if fn := lspEnclosingFunction(prgrm, doc, line); fn != nil {
+		scope = debugVariables(fn)
+	}
+	if pkg := lspPackage(prgrm, doc); pkg != nil {
+		scope = append(scope, pkg.Globals...)
+	}
+	for _, decl := range scope {
+		if decl.Name != qualifier {
+			continue
+		}
+		t := declValueType(decl)
+		for t.specs[0] == DECL_POINTER && !t.isBase() {
+			t = t.elem()
+		}
+		if t.specs[0] == DECL_STRUCT && t.strct != nil {
+			for _, fld := range t.strct.Fields {
+				decls = append(decls, lspFieldDecl(fld))
+			}
+		}
+		return decls
+	}
+
+	if pkg, err := prgrm.GetPackage(qualifier); err == nil {
+		return lspPackageDecls(pkg)
+	}
+
+	prefix := qualifier + "."
+	for opCode, name := range OpNames {
+		if fn, found := Natives[opCode]; found && strings.HasPrefix(name, prefix) {
+			decl := lspFunctionDecl(fn)
+			decl.name = strings.TrimPrefix(name, prefix)
+			decls = append(decls, decl)
+		}
+	}
+	sort.Slice(decls, func(i, j int) bool { return decls[i].name < decls[j].name })
+	return decls
+}
+
+// lspPackageDecls returns the functions, structs and globals of a package
+func lspPackageDecls(pkg *CXPackage) (decls []lspDecl) {
+	for _, fn := range pkg.Functions {
+		if fn.Name != SYS_INIT_FUNC {
+			decls = append(decls, lspFunctionDecl(fn))
+		}
+	}
+	for _, strct := range pkg.Structs {
+		decls = append(decls, lspStructDecl(strct))
+	}
+	for _, glbl := range pkg.Globals {
+		if glbl.Name != "" {
+			decls = append(decls, lspVariableDecl(glbl))
+		}
+	}
+	return decls
+}
+
+// lspTypeName returns the type of a declaration as written
+func lspTypeName(decl *CXArgument) string {
+	t := declValueType(decl)
+	if decl.IsVariadic && t.specs[0] == DECL_SLICE {
+		return "..." + t.elem().name()
+	}
+	return t.name()
+}
+
+// lspParameters formats the parameters or results of a function
+func lspParameters(params []*CXArgument) string {
+	var formatted []string
+	for _, param := range params {
+		if strings.HasPrefix(param.Name, VARIADIC_LEN_PREFIX) {
+			continue
+		}
+		if param.Name == "" {
+			formatted = append(formatted, lspTypeName(param))
+		} else {
+			formatted = append(formatted, param.Name+" "+lspTypeName(param))
+		}
+	}
+	return "(" + strings.Join(formatted, ", ") + ")"
+}
+
+func lspFunctionDecl(fn *CXFunction) lspDecl {
+	name := fn.Name
+	if native, found := OpNames[fn.OpCode]; fn.IsNative && found {
+		name = native
+	}
+	detail := "func " + name + lspParameters(fn.Inputs)
+	if len(fn.Outputs) > 0 {
+		detail += " " + lspParameters(fn.Outputs)
+	}
+	return lspDecl{name: fn.Name, detail: detail, kind: LSP_COMPLETION_FUNCTION, fileName: fn.FileName, fileLine: fn.FileLine}
+}
+
+func lspStructDecl(strct *CXStruct) lspDecl {
+	detail := "type " + strct.Name + " struct {"
+	for _, fld := range strct.Fields {
+		detail += "\n\t" + fld.Name + " " + lspTypeName(fld)
+	}
+	if len(strct.Fields) > 0 {
+		detail += "\n"
+	}
+	detail += "}"
+	return lspDecl{name: strct.Name, detail: detail, kind: LSP_COMPLETION_STRUCT, fileName: strct.FileName, fileLine: strct.FileLine}
+}
+
+func lspFieldDecl(fld *CXArgument) lspDecl {
+	return lspDecl{name: fld.Name, detail: "field " + fld.Name + " " + lspTypeName(fld), kind: LSP_COMPLETION_FIELD, fileName: fld.FileName, fileLine: fld.FileLine}
+}
+
+func lspVariableDecl(decl *CXArgument) lspDecl {
+	return lspDecl{name: decl.Name, detail: "var " + decl.Name + " " + lspTypeName(decl), kind: LSP_COMPLETION_VARIABLE, fileName: decl.FileName, fileLine: decl.FileLine}
+}
@@ -0,0 +1,343 @@
+package base
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const lspSource = `package main
+
+type Point struct {
+	x i32
+	y i32
+}
+
+var total i32
+
+func add(a i32, b i32) (c i32) {
+	c = i32.add(a, b)
+}
+
+func main() {
+	var p Point
+	p.x = add(2, 3)
+	total = p.x
+}
+`
+
+// makeLSPProgram builds the declarations of lspSource, in the file
+// `fileName`, as the parser compiles them
+func makeLSPProgram(fileName string) *CXProgram {
+	prgrm := MakeProgram(CALLSTACK_SIZE, STACK_SIZE, INIT_HEAP_SIZE)
+	main := MakePackage(MAIN_PKG)
+	prgrm.AddPackage(main)
+
+	i32 := func(name string, fileLine int) *CXArgument {
+		arg := MakeCoreArgument(name, TYPE_I32)
+		arg.FileName, arg.FileLine = fileName, fileLine
+		return arg
+	}
+
+	point := MakeStruct("Point")
+	point.FileName, point.FileLine = fileName, 3
+	main.AddStruct(point)
+	point.AddField(MakeField("x", TYPE_I32, fileName, 4))
+	point.AddField(MakeField("y", TYPE_I32, fileName, 5))
+	for _, fld := range point.Fields {
+		fld.DeclarationSpecifiers = []int{DECL_BASIC}
+		fld.Size, fld.TotalSize = 4, 4
+	}
+	point.Size = 8
+
+	main.AddGlobal(i32("total", 8))
+
+	add := MakeFunction("add")
+	add.FileName, add.FileLine = fileName, 10
+	main.AddFunction(add)
+	a, b, c := i32("a", 10), i32("b", 10), i32("c", 10)
+	add.Inputs, add.Outputs = []*CXArgument{a, b}, []*CXArgument{c}
+	sum := MakeExpression(Natives[OP_I32_ADD], fileName, 11)
+	sum.AddInput(a).AddInput(b).AddOutput(c)
+	add.AddExpression(sum)
+
+	mainFn := MakeFunction(MAIN_FUNC)
+	mainFn.FileName, mainFn.FileLine = fileName, 14
+	main.AddFunction(mainFn)
+	p := MakeCoreStructArgument("p", point)
+	p.FileName, p.FileLine = fileName, 15
+	p.IsLocalDeclaration = true
+	decl := MakeExpression(nil, fileName, 15)
+	decl.AddOutput(p)
+	call := MakeExpression(add, fileName, 16)
+	call.AddOutput(p)
+	assign := MakeExpression(Natives[OP_IDENTITY], fileName, 17)
+	assign.AddOutput(main.Globals[0])
+	for _, expr := range []*CXExpression{decl, call, assign} {
+		mainFn.AddExpression(expr)
+	}
+
+	return prgrm
+}
+
+// lspClient sends requests to an LSPServer and reads its messages in order
+type lspClient struct {
+	t  *testing.T
+	w  io.Writer
+	r  *bufio.Reader
+	id int
+}
+
+type lspMessage struct {
+	ID     int             `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *lspError       `json:"error"`
+}
+
+// startLSP serves a client with a server compiling lspSource, or reporting
+// an error at each line of a document where oops is used
+func startLSP(t *testing.T, fileName string) (*lspClient, *map[string]string, chan error) {
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+
+	compiled := new(map[string]string)
+	srv := NewLSPServer(reqR, respW, func(name string, open map[string]string) (*CXProgram, []CompileError) {
+		*compiled = open
+		var errs []CompileError
+		for i, line := range strings.Split(open[name], "\n") {
+			if strings.Contains(line, "oops") {
+				errs = append(errs, CompileError{FileName: name, FileLine: i + 1, Message: "identifier 'oops' does not exist"})
+			}
+		}
+		return makeLSPProgram(fileName), errs
+	})
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Serve()
+		respW.Close()
+	}()
+
+	return &lspClient{t: t, w: reqW, r: bufio.NewReader(respR)}, compiled, done
+}
+
+func (client *lspClient) write(msg map[string]interface{}) {
+	msg["jsonrpc"] = "2.0"
+	body, _ := json.Marshal(msg)
+	fmt.Fprintf(client.w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func (client *lspClient) notify(method string, params interface{}) {
+	client.write(map[string]interface{}{"method": method, "params": params})
+}
+
+func (client *lspClient) next() *lspMessage {
+	client.t.Helper()
+	header, err := client.r.ReadString('\n')
+	if err != nil {
+		client.t.Fatalf("reading a message: %v", err)
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+	if err != nil {
+		client.t.Fatalf("invalid header %q", header)
+	}
+	client.r.ReadString('\n')
+	body := make([]byte, length)
+	if _, err := io.ReadFull(client.r, body); err != nil {
+		client.t.Fatal(err)
+	}
+	var msg lspMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		client.t.Fatal(err)
+	}
+	return &msg
+}
+
+// request sends a request and decodes the result of its response, which must
+// be the next message, into `result`
+func (client *lspClient) request(method string, params interface{}, result interface{}) *lspMessage {
+	client.t.Helper()
+	client.id++
+	client.write(map[string]interface{}{"id": client.id, "method": method, "params": params})
+	msg := client.next()
+	if msg.Method != "" || msg.ID != client.id {
+		client.t.Fatalf("got %s %d, want the response to %s", msg.Method, msg.ID, method)
+	}
+	if msg.Error == nil && result != nil {
+		if err := json.Unmarshal(msg.Result, result); err != nil {
+			client.t.Fatal(err)
+		}
+	}
+	return msg
+}
+
+// diagnostics reads the next message, which must publish the diagnostics of
+// `uri`
+func (client *lspClient) diagnostics(uri string) []lspDiagnostic {
+	client.t.Helper()
+	msg := client.next()
+	var params lspPublishDiagnosticsParams
+	json.Unmarshal(msg.Params, &params)
+	if msg.Method != "textDocument/publishDiagnostics" || params.URI != uri {
+		client.t.Fatalf("got %s for %s, want the diagnostics of %s", msg.Method, params.URI, uri)
+	}
+	return params.Diagnostics
+}
+
+func (client *lspClient) change(uri string, start, end lspPosition, text string) {
+	client.notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": client.id},
+		"contentChanges": []interface{}{map[string]interface{}{"range": lspRange{Start: start, End: end}, "text": text}},
+	})
+}
+
+func (client *lspClient) hover(uri string, line, char int) string {
+	client.t.Helper()
+	var hover *lspHover
+	client.request("textDocument/hover", lspPositionParams{TextDocument: lspTextDocumentIdentifier{URI: uri}, Position: lspPosition{Line: line, Character: char}}, &hover)
+	if hover == nil {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(hover.Contents.Value, "```cx\n"), "\n```")
+}
+
+func (client *lspClient) definition(uri string, line, char int) *lspLocation {
+	client.t.Helper()
+	var loc *lspLocation
+	client.request("textDocument/definition", lspPositionParams{TextDocument: lspTextDocumentIdentifier{URI: uri}, Position: lspPosition{Line: line, Character: char}}, &loc)
+	return loc
+}
+
+func (client *lspClient) completion(uri string, line, char int) (labels []string) {
+	client.t.Helper()
+	var list lspCompletionList
+	client.request("textDocument/completion", lspPositionParams{TextDocument: lspTextDocumentIdentifier{URI: uri}, Position: lspPosition{Line: line, Character: char}}, &list)
+	for _, item := range list.Items {
+		labels = append(labels, item.Label)
+	}
+	return labels
+}
+
+func TestLSPSession(t *testing.T) {
+	fileName, _ := filepath.Abs("lsp.cx")
+	uri := lspURI(fileName)
+	client, compiled, done := startLSP(t, fileName)
+
+	var init struct {
+		Capabilities struct {
+			TextDocumentSync struct{ Change int }
+		}
+	}
+	client.request("initialize", map[string]interface{}{"processId": nil}, &init)
+	if init.Capabilities.TextDocumentSync.Change != 2 {
+		t.Errorf("document sync = %d, want incremental", init.Capabilities.TextDocumentSync.Change)
+	}
+	client.notify("initialized", map[string]interface{}{})
+
+	client.notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri, "languageId": "cx", "version": 1, "text": lspSource},
+	})
+	if diags := client.diagnostics(uri); len(diags) != 0 {
+		t.Errorf("diagnostics = %+v, want none", diags)
+	}
+
+	// total = p.x becomes total = oops
+	client.change(uri, lspPosition{Line: 16, Character: 9}, lspPosition{Line: 16, Character: 12}, "oops")
+	diags := client.diagnostics(uri)
+	want := lspRange{Start: lspPosition{Line: 16, Character: 1}, End: lspPosition{Line: 16, Character: 13}}
+	if len(diags) != 1 || diags[0].Range != want || diags[0].Severity != 1 || !strings.Contains(diags[0].Message, "oops") {
+		t.Errorf("diagnostics = %+v, want one at %+v", diags, want)
+	}
+	client.change(uri, lspPosition{Line: 16, Character: 9}, lspPosition{Line: 16, Character: 13}, "p.x")
+	if diags := client.diagnostics(uri); len(diags) != 0 {
+		t.Errorf("diagnostics after the fix = %+v, want none", diags)
+	}
+
+	// the characters of the positions are UTF-16 code units
+	client.change(uri, lspPosition{Line: 1, Character: 0}, lspPosition{Line: 1, Character: 0}, "// 𝛑 π")
+	client.diagnostics(uri)
+	client.change(uri, lspPosition{Line: 1, Character: 6}, lspPosition{Line: 1, Character: 6}, "!")
+	client.diagnostics(uri)
+	if line := lspLine((*compiled)[fileName], 1); line != "// 𝛑 !π" {
+		t.Errorf("line 2 = %q after the changes, want %q", line, "// 𝛑 !π")
+	}
+
+	hovers := []struct {
+		line, char int
+		want       string
+	}{
+		{15, 8, "func add(a i32, b i32) (c i32)"},
+		{10, 10, "func i32.add(i32, i32) (i32)"},
+		{15, 1, "var p Point"},
+		{15, 3, "field x i32"},
+		{16, 3, "var total i32"},
+		{14, 8, "type Point struct {\n\tx i32\n\ty i32\n}"},
+		{15, 6, ""},
+	}
+	for _, hover := range hovers {
+		if got := client.hover(uri, hover.line, hover.char); got != hover.want {
+			t.Errorf("hover at %d:%d = %q, want %q", hover.line, hover.char, got, hover.want)
+		}
+	}
+
+	definitions := []struct {
+		line, char int
+		want       lspPosition
+	}{
+		{15, 8, lspPosition{Line: 9, Character: 5}},
+		{16, 11, lspPosition{Line: 3, Character: 1}},
+		{16, 2, lspPosition{Line: 7, Character: 4}},
+		{10, 1, lspPosition{Line: 9, Character: 24}},
+	}
+	for _, def := range definitions {
+		loc := client.definition(uri, def.line, def.char)
+		if loc == nil || loc.URI != uri || loc.Range.Start != def.want {
+			t.Errorf("definition at %d:%d = %+v, want %+v", def.line, def.char, loc, def.want)
+		}
+	}
+	if loc := client.definition(uri, 10, 10); loc != nil {
+		t.Errorf("definition of a native = %+v, want none", loc)
+	}
+
+	if labels := client.completion(uri, 15, 3); strings.Join(labels, " ") != "x y" {
+		t.Errorf("completion of p. = %v, want x and y", labels)
+	}
+	if labels := client.completion(uri, 15, 9); strings.Join(labels, " ") != "add" {
+		t.Errorf("completion of ad = %v, want add", labels)
+	}
+
+	var symbols []lspDocumentSymbol
+	client.request("textDocument/documentSymbol", lspDocumentParams{TextDocument: lspTextDocumentIdentifier{URI: uri}}, &symbols)
+	var names []string
+	for _, sym := range symbols {
+		names = append(names, sym.Name)
+	}
+	if strings.Join(names, " ") != "Point total add main" {
+		t.Fatalf("symbols = %v, want Point, total, add and main", names)
+	}
+	if point := symbols[0]; point.Kind != LSP_SYMBOL_STRUCT || len(point.Children) != 2 || point.Range.End.Line != 4 {
+		t.Errorf("Point = %+v, want a struct of two fields on lines 2 to 4", point)
+	}
+	if main := symbols[3]; main.Kind != LSP_SYMBOL_FUNCTION || main.Range.Start.Line != 13 || main.Range.End.Line != 16 {
+		t.Errorf("main = %+v, want a function on lines 13 to 16", main)
+	}
+
+	if msg := client.request("textDocument/formatting", nil, nil); msg.Error == nil || msg.Error.Code != LSP_METHOD_NOT_FOUND {
+		t.Errorf("an unsupported request got %+v, want an error", msg.Error)
+	}
+
+	client.notify("textDocument/didClose", lspDocumentParams{TextDocument: lspTextDocumentIdentifier{URI: uri}})
+	client.diagnostics(uri)
+
+	client.request("shutdown", nil, nil)
+	client.notify("exit", nil)
+	if err := <-done; err != nil {
+		t.Errorf("Serve returned %v", err)
+	}
+}
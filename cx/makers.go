@@ -24,6 +24,10 @@ func MakeProgram(callStackSize int, stackSize int, initialHeapSize int) *CXProgr
 		Heap:      MakeHeap(initialHeapSize),
 	}
 
+	// the generated names only need to be unique in a program, and so a
+	// program gets the same names every time it's compiled
+	genSymCounter = 0
+
 	newPrgrm.Stacks[0] = MakeStack(stackSize)
 	newPrgrm.Stacks[0].Program = newPrgrm
 
@@ -113,6 +117,55 @@ func MakeFunction(name string) *CXFunction {
 	return &CXFunction{Name: name}
 }
 
+// MakeCoreFunction makes a function of a core package, such as http.Get,
+// which is executed by the native `opCode`
+func MakeCoreFunction(name string, opCode int, inputs []*CXArgument, outputs []*CXArgument) *CXFunction {
+	fn := MakeFunction(name)
+	fn.OpCode = opCode
+	fn.IsNative = true
+	fn.Inputs = inputs
+	fn.Outputs = outputs
+
+	offset := 0
+	for _, arg := range append(inputs, outputs...) {
+		arg.Offset = offset
+		offset += arg.TotalSize
+	}
+
+	return fn
+}
+
+// MakeCoreArgument makes a parameter or a struct field of basic type `typ`
+// for a core package, declared as the parser would declare it
+func MakeCoreArgument(name string, typ int) *CXArgument {
+	arg := MakeArgument(name, "", 0).AddType(TypeNames[typ])
+
+	if typ == TYPE_STR {
+		arg.DeclarationSpecifiers = []int{DECL_POINTER}
+		arg.IsPointer = true
+		arg.PointeeSize = arg.Size
+		arg.Size = TYPE_POINTER_SIZE
+		arg.TotalSize = TYPE_POINTER_SIZE
+		arg.IndirectionLevels = 1
+	} else {
+		arg.DeclarationSpecifiers = []int{DECL_BASIC}
+	}
+
+	return arg
+}
+
+// MakeCoreStructArgument makes a parameter of a core package whose type is
+// the struct `strct`
+func MakeCoreStructArgument(name string, strct *CXStruct) *CXArgument {
+	arg := MakeArgument(name, "", 0).AddType(strct.Name)
+	arg.DeclarationSpecifiers = []int{DECL_STRUCT}
+	arg.CustomType = strct
+	arg.Size = strct.Size
+	arg.TotalSize = strct.Size
+	arg.Package = strct.Package
+	return arg
+}
+
 func MakeNative(opCode int, inputs []int, outputs []int) *CXFunction {
 	fn := &CXFunction{
 		OpCode:   opCode,
@@ -2,6 +2,8 @@ package base
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/skycoin/skycoin/src/cipher/encoder"
 )
 
@@ -44,7 +46,9 @@ func GetFinalOffset(stack *CXStack, fp int, arg *CXArgument, opType int) int {
 					subSize *= len
 				}
 
-				if arg.CustomType != nil {
+				if elt == arg && arg.CustomType != nil {
+					// a field's CustomType is its containing struct, and
+					// its size is already the size of its elements
 					finalOffset += int(ReadI32(stack, fp, idxArg)) * subSize * arg.CustomType.Size
 				} else {
 					finalOffset += int(ReadI32(stack, fp, idxArg)) * subSize * elt.Size
@@ -148,6 +152,10 @@ func Mark(prgrm *CXProgram) {
 }
 
 func MarkAndCompact(prgrm *CXProgram) {
+	if prgrm.Tracer != nil {
+		defer prgrm.Tracer.gc(prgrm, time.Now(), prgrm.Heap.HeapPointer)
+	}
+
 	var fp int
 	var faddr int32 = NULL_HEAP_ADDRESS_OFFSET
 
@@ -242,6 +250,32 @@ func WriteMemory(stack *CXStack, offset int, arg *CXArgument, byts []byte) {
 	}
 }
 
+// WriteObject allocates a new object in the heap with `byts` as its
+// content and returns the object's heap offset.
+func WriteObject(prgrm *CXProgram, byts []byte) int {
+	size := encoder.Serialize(int32(len(byts)))
+	heapOffset := AllocateSeq(prgrm, len(byts)+OBJECT_HEADER_SIZE)
+
+	var header []byte = make([]byte, OBJECT_HEADER_SIZE, OBJECT_HEADER_SIZE)
+	for c := 5; c < OBJECT_HEADER_SIZE; c++ {
+		header[c] = size[c-5]
+	}
+
+	obj := append(header, byts...)
+
+	WriteToHeap(&prgrm.Heap, heapOffset, obj)
+
+	return heapOffset
+}
+
+// WriteString allocates `str` in the heap and writes its heap offset to
+// the memory pointed by `out`.
+func WriteString(stack *CXStack, fp int, out *CXArgument, str string) {
+	outOffset := GetFinalOffset(stack, fp, out, MEM_WRITE)
+	heapOffset := WriteObject(stack.Program, encoder.Serialize(str))
+	WriteMemory(stack, outOffset, out, encoder.SerializeAtomic(int32(heapOffset)))
+}
+
 // Utilities
 
 func FromBool(in bool) []byte {
@@ -264,6 +298,18 @@ func FromI8 (in int8) []byte {
 	return encoder.SerializeAtomic(in)
 }
 
+func FromI16(in int16) []byte {
+	return encoder.SerializeAtomic(in)
+}
+
+func FromUI8(in uint8) []byte {
+	return encoder.SerializeAtomic(in)
+}
+
+func FromUI16(in uint16) []byte {
+	return encoder.SerializeAtomic(in)
+}
+
 func FromI32(in int32) []byte {
 	return encoder.SerializeAtomic(in)
 }
@@ -276,6 +322,10 @@ func FromI64(in int64) []byte {
 	return encoder.Serialize(in)
 }
 
+func FromUI64(in uint64) []byte {
+	return encoder.SerializeAtomic(in)
+}
+
 func FromF32(in float32) []byte {
 	return encoder.Serialize(in)
 }
@@ -359,6 +409,30 @@ func ReadI8 (stack *CXStack, fp int, inp *CXArgument) (out int8) {
 	return
 }
 
+func ReadI16(stack *CXStack, fp int, inp *CXArgument) (out int16) {
+	offset := GetFinalOffset(stack, fp, inp, MEM_READ)
+	encoder.DeserializeAtomic(ReadMemory(stack, offset, inp), &out)
+	return
+}
+
+func ReadUI8(stack *CXStack, fp int, inp *CXArgument) (out uint8) {
+	offset := GetFinalOffset(stack, fp, inp, MEM_READ)
+	encoder.DeserializeAtomic(ReadMemory(stack, offset, inp), &out)
+	return
+}
+
+func ReadUI16(stack *CXStack, fp int, inp *CXArgument) (out uint16) {
+	offset := GetFinalOffset(stack, fp, inp, MEM_READ)
+	encoder.DeserializeAtomic(ReadMemory(stack, offset, inp), &out)
+	return
+}
+
+func ReadUI32(stack *CXStack, fp int, inp *CXArgument) (out uint32) {
+	offset := GetFinalOffset(stack, fp, inp, MEM_READ)
+	encoder.DeserializeAtomic(ReadMemory(stack, offset, inp), &out)
+	return
+}
+
 func ReadI32(stack *CXStack, fp int, inp *CXArgument) (out int32) {
 	offset := GetFinalOffset(stack, fp, inp, MEM_READ)
 	encoder.DeserializeAtomic(ReadMemory(stack, offset, inp), &out)
@@ -371,6 +445,12 @@ func ReadI64(stack *CXStack, fp int, inp *CXArgument) (out int64) {
 	return
 }
 
+func ReadUI64(stack *CXStack, fp int, inp *CXArgument) (out uint64) {
+	offset := GetFinalOffset(stack, fp, inp, MEM_READ)
+	encoder.DeserializeAtomic(ReadMemory(stack, offset, inp), &out)
+	return
+}
+
 func ReadF32(stack *CXStack, fp int, inp *CXArgument) (out float32) {
 	offset := GetFinalOffset(stack, fp, inp, MEM_READ)
 	encoder.DeserializeRaw(ReadMemory(stack, offset, inp), &out)
@@ -2,7 +2,7 @@ package base
 
 import (
 	"fmt"
-	// "strconv"
+	"strconv"
 	// "math"
 	// "math/rand"
 )
@@ -10,18 +10,32 @@ import (
 func op_byte_byte(expr *CXExpression, stack *CXStack, fp int) {
 	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
 	out1Offset := GetFinalOffset(stack, fp, out1, MEM_WRITE)
+
 	switch out1.Type {
 	case TYPE_BYTE:
-		WriteMemory(stack, out1Offset, out1, FromByte(byte(ReadI32(stack, fp, inp1))))
-	// case TYPE_STR: WriteMemory(stack, out1Offset, out1, FromStr(strconv.Itoa(ReadI32(stack, fp, inp1))))
+		WriteMemory(stack, out1Offset, out1, FromByte(byte(ReadByte(stack, fp, inp1))))
+	case TYPE_STR:
+		WriteString(stack, fp, out1, strconv.FormatUint(uint64(ReadByte(stack, fp, inp1)), 10))
+	case TYPE_I8:
+		WriteMemory(stack, out1Offset, out1, FromI8(int8(ReadByte(stack, fp, inp1))))
+	case TYPE_I16:
+		WriteMemory(stack, out1Offset, out1, FromI16(int16(ReadByte(stack, fp, inp1))))
 	case TYPE_I32:
-		WriteMemory(stack, out1Offset, out1, FromI32(ReadI32(stack, fp, inp1)))
+		WriteMemory(stack, out1Offset, out1, FromI32(int32(ReadByte(stack, fp, inp1))))
 	case TYPE_I64:
-		WriteMemory(stack, out1Offset, out1, FromI64(int64(ReadI32(stack, fp, inp1))))
+		WriteMemory(stack, out1Offset, out1, FromI64(int64(ReadByte(stack, fp, inp1))))
+	case TYPE_UI8:
+		WriteMemory(stack, out1Offset, out1, FromUI8(uint8(ReadByte(stack, fp, inp1))))
+	case TYPE_UI16:
+		WriteMemory(stack, out1Offset, out1, FromUI16(uint16(ReadByte(stack, fp, inp1))))
+	case TYPE_UI32:
+		WriteMemory(stack, out1Offset, out1, FromUI32(uint32(ReadByte(stack, fp, inp1))))
+	case TYPE_UI64:
+		WriteMemory(stack, out1Offset, out1, FromUI64(uint64(ReadByte(stack, fp, inp1))))
 	case TYPE_F32:
-		WriteMemory(stack, out1Offset, out1, FromF32(float32(ReadI32(stack, fp, inp1))))
+		WriteMemory(stack, out1Offset, out1, FromF32(float32(ReadByte(stack, fp, inp1))))
 	case TYPE_F64:
-		WriteMemory(stack, out1Offset, out1, FromF64(float64(ReadI32(stack, fp, inp1))))
+		WriteMemory(stack, out1Offset, out1, FromF64(float64(ReadByte(stack, fp, inp1))))
 	}
 }
 
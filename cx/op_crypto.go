@@ -0,0 +1,199 @@
+package base
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/cipher/base58"
+)
+
+// readData reads the first `n` bytes of the []byte input `inp`, where `n` is
+// read from the input `nInp`
+func readData(stack *CXStack, fp int, inp, nInp *CXArgument) []byte {
+	byts := ReadMemory(stack, GetFinalOffset(stack, fp, inp, MEM_READ), inp)
+	return firstBytes(byts, ReadI32(stack, fp, nInp))
+}
+
+// readHash reads a SHA256 digest from the []byte input `inp`. It returns false
+// if the input is shorter than a digest
+func readHash(stack *CXStack, fp int, inp *CXArgument) (hash cipher.SHA256, ok bool) {
+	byts := ReadMemory(stack, GetFinalOffset(stack, fp, inp, MEM_READ), inp)
+	if len(byts) < len(hash) {
+		return hash, false
+	}
+	copy(hash[:], byts)
+	return hash, true
+}
+
+// op_crypto_SHA256. The SHA256 built-in function returns the SHA256 digest of
+// the first `n` bytes of `data`
+func op_crypto_SHA256(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	sum := sha256.Sum256(readData(stack, fp, inp1, inp2))
+	writeBytes(stack, fp, out1, sum[:])
+}
+
+// op_crypto_RIPEMD160. The RIPEMD160 built-in function returns the RIPEMD160
+// digest of the first `n` bytes of `data`
+func op_crypto_RIPEMD160(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	sum := cipher.HashRipemd160(readData(stack, fp, inp1, inp2))
+	writeBytes(stack, fp, out1, sum[:])
+}
+
+// writeDecoded writes the bytes decoded by an encoding's Decode function to the
+// []byte output of `expr`, and optionally their number and whether `s` could
+// be decoded. Bytes that don't fit in the output make the decoding fail
+func writeDecoded(expr *CXExpression, stack *CXStack, fp int, byts []byte, err error) {
+	out1 := expr.Outputs[0]
+	if err != nil {
+		byts = nil
+	}
+	n := writeBytes(stack, fp, out1, byts)
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+	writeOptionalOutput(expr, stack, fp, 2, FromBool(err == nil && n == len(byts)))
+}
+
+// op_hex_Encode. The Encode built-in function returns the hexadecimal encoding
+// of the first `n` bytes of `data`
+func op_hex_Encode(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	WriteString(stack, fp, out1, hex.EncodeToString(readData(stack, fp, inp1, inp2)))
+}
+
+// op_hex_Decode. The Decode built-in function returns the bytes represented
+// by the hexadecimal string `s`, their number and whether `s` is valid
+func op_hex_Decode(expr *CXExpression, stack *CXStack, fp int) {
+	byts, err := hex.DecodeString(ReadStr(stack, fp, expr.Inputs[0]))
+	writeDecoded(expr, stack, fp, byts, err)
+}
+
+// op_base58_Encode. The Encode built-in function returns the base58 encoding
+// of the first `n` bytes of `data`, with the alphabet used by Skycoin and
+// Bitcoin addresses
+func op_base58_Encode(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	WriteString(stack, fp, out1, base58.Hex2Base58String(readData(stack, fp, inp1, inp2)))
+}
+
+// op_base58_Decode. The Decode built-in function returns the bytes represented
+// by the base58 string `s`, their number and whether `s` is valid
+func op_base58_Decode(expr *CXExpression, stack *CXStack, fp int) {
+	byts, err := base58.Base582Hex(ReadStr(stack, fp, expr.Inputs[0]))
+	writeDecoded(expr, stack, fp, byts, err)
+}
+
+// op_base64_Encode. The Encode built-in function returns the standard base64
+// encoding of the first `n` bytes of `data`
+func op_base64_Encode(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	WriteString(stack, fp, out1, base64.StdEncoding.EncodeToString(readData(stack, fp, inp1, inp2)))
+}
+
+// op_base64_Decode. The Decode built-in function returns the bytes represented
+// by the standard base64 string `s`, their number and whether `s` is valid
+func op_base64_Decode(expr *CXExpression, stack *CXStack, fp int) {
+	byts, err := base64.StdEncoding.DecodeString(ReadStr(stack, fp, expr.Inputs[0]))
+	writeDecoded(expr, stack, fp, byts, err)
+}
+
+// writeKeyPair writes the hexadecimal public and secret keys of a key pair to
+// the outputs of `expr`
+func writeKeyPair(expr *CXExpression, stack *CXStack, fp int, pub cipher.PubKey, sec cipher.SecKey) {
+	WriteString(stack, fp, expr.Outputs[0], pub.Hex())
+	if len(expr.Outputs) > 1 {
+		WriteString(stack, fp, expr.Outputs[1], sec.Hex())
+	}
+}
+
+// readSecKey reads a hexadecimal secret key and checks it's valid, as the
+// cipher functions panic with invalid keys
+func readSecKey(stack *CXStack, fp int, inp *CXArgument) (cipher.SecKey, bool) {
+	sec, err := cipher.SecKeyFromHex(ReadStr(stack, fp, inp))
+	return sec, err == nil && sec.Verify() == nil
+}
+
+// op_cipher_GenerateKeyPair. The GenerateKeyPair built-in function returns a
+// random public key and its secret key, encoded in hexadecimal
+func op_cipher_GenerateKeyPair(expr *CXExpression, stack *CXStack, fp int) {
+	pub, sec := cipher.GenerateKeyPair()
+	writeKeyPair(expr, stack, fp, pub, sec)
+}
+
+// op_cipher_GenerateDeterministicKeyPair. The GenerateDeterministicKeyPair
+// built-in function returns the public and secret keys derived from `seed`,
+// encoded in hexadecimal. The same seed always derives the same keys
+func op_cipher_GenerateDeterministicKeyPair(expr *CXExpression, stack *CXStack, fp int) {
+	pub, sec := cipher.GenerateDeterministicKeyPair([]byte(ReadStr(stack, fp, expr.Inputs[0])))
+	writeKeyPair(expr, stack, fp, pub, sec)
+}
+
+// op_cipher_PubKeyFromSecKey. The PubKeyFromSecKey built-in function returns
+// the public key of the hexadecimal secret key `sec`, and false if `sec` is
+// invalid
+func op_cipher_PubKeyFromSecKey(expr *CXExpression, stack *CXStack, fp int) {
+	var pubHex string
+	sec, ok := readSecKey(stack, fp, expr.Inputs[0])
+	if ok {
+		pub := cipher.PubKeyFromSecKey(sec)
+		pubHex = pub.Hex()
+	}
+	WriteString(stack, fp, expr.Outputs[0], pubHex)
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(ok))
+}
+
+// op_cipher_AddressFromPubKey. The AddressFromPubKey built-in function returns
+// the base58 Skycoin address of the hexadecimal public key `pub`, and false if
+// `pub` is invalid
+func op_cipher_AddressFromPubKey(expr *CXExpression, stack *CXStack, fp int) {
+	var addr string
+	pub, err := cipher.PubKeyFromHex(ReadStr(stack, fp, expr.Inputs[0]))
+	ok := err == nil && pub.Verify() == nil
+	if ok {
+		addr = cipher.AddressFromPubKey(pub).String()
+	}
+	WriteString(stack, fp, expr.Outputs[0], addr)
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(ok))
+}
+
+// op_cipher_SignHash. The SignHash built-in function signs the SHA256 digest
+// `hash` with the hexadecimal secret key `sec`. It returns the signature in
+// hexadecimal, and false if the digest or the key are invalid
+func op_cipher_SignHash(expr *CXExpression, stack *CXStack, fp int) {
+	var sigHex string
+	hash, ok := readHash(stack, fp, expr.Inputs[0])
+	sec, secOk := readSecKey(stack, fp, expr.Inputs[1])
+	ok = ok && secOk
+	if ok {
+		sig := cipher.SignHash(hash, sec)
+		sigHex = sig.Hex()
+	}
+	WriteString(stack, fp, expr.Outputs[0], sigHex)
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(ok))
+}
+
+// op_cipher_VerifySignature. The VerifySignature built-in function reports
+// whether the hexadecimal signature `sig` of the SHA256 digest `hash` was made
+// with the secret key of the hexadecimal public key `pub`
+func op_cipher_VerifySignature(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2]
+	pub, pubErr := cipher.PubKeyFromHex(ReadStr(stack, fp, inp1))
+	sig, sigErr := cipher.SigFromHex(ReadStr(stack, fp, inp2))
+	hash, ok := readHash(stack, fp, inp3)
+	ok = ok && pubErr == nil && sigErr == nil && cipher.VerifySignature(pub, sig, hash) == nil
+	writeOptionalOutput(expr, stack, fp, 0, FromBool(ok))
+}
+
+// op_cipher_ChkSig. The ChkSig built-in function reports whether the
+// hexadecimal signature `sig` of the SHA256 digest `hash` was made with the
+// secret key of the base58 Skycoin address `addr`
+func op_cipher_ChkSig(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2]
+	addr, addrErr := cipher.DecodeBase58Address(ReadStr(stack, fp, inp1))
+	hash, ok := readHash(stack, fp, inp2)
+	sig, sigErr := cipher.SigFromHex(ReadStr(stack, fp, inp3))
+	ok = ok && addrErr == nil && sigErr == nil && cipher.ChkSig(addr, hash, sig) == nil
+	writeOptionalOutput(expr, stack, fp, 0, FromBool(ok))
+}
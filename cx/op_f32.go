@@ -3,6 +3,7 @@ package base
 import (
 	"fmt"
 	"math"
+	"strconv"
 )
 
 func op_f32_f32(expr *CXExpression, stack *CXStack, fp int) {
@@ -12,6 +13,20 @@ func op_f32_f32(expr *CXExpression, stack *CXStack, fp int) {
 	switch out1.Type {
 	case TYPE_BYTE:
 		WriteMemory(stack, out1Offset, out1, FromByte(byte(ReadF32(stack, fp, inp1))))
+	case TYPE_STR:
+		WriteString(stack, fp, out1, strconv.FormatFloat(float64(ReadF32(stack, fp, inp1)), 'f', -1, 32))
+	case TYPE_I8:
+		WriteMemory(stack, out1Offset, out1, FromI8(int8(ReadF32(stack, fp, inp1))))
+	case TYPE_I16:
+		WriteMemory(stack, out1Offset, out1, FromI16(int16(ReadF32(stack, fp, inp1))))
+	case TYPE_UI8:
+		WriteMemory(stack, out1Offset, out1, FromUI8(uint8(ReadF32(stack, fp, inp1))))
+	case TYPE_UI16:
+		WriteMemory(stack, out1Offset, out1, FromUI16(uint16(ReadF32(stack, fp, inp1))))
+	case TYPE_UI32:
+		WriteMemory(stack, out1Offset, out1, FromUI32(uint32(ReadF32(stack, fp, inp1))))
+	case TYPE_UI64:
+		WriteMemory(stack, out1Offset, out1, FromUI64(uint64(ReadF32(stack, fp, inp1))))
 	case TYPE_I32:
 		WriteMemory(stack, out1Offset, out1, FromI32(int32(ReadF32(stack, fp, inp1))))
 	case TYPE_I64:
@@ -189,3 +204,13 @@ func op_f32_min(expr *CXExpression, stack *CXStack, fp int) {
 	outB1 := FromF32(float32(math.Min(float64(ReadF32(stack, fp, inp1)), float64(ReadF32(stack, fp, inp2)))))
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
 }
+
+// op_f32_format. The format built-in function returns the string
+// representation of x with y digits after the decimal point. The second
+// output is true if y isn't between 0 and FORMAT_MAX_PRECISION
+func op_f32_format(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	writeNumberFormat(expr, stack, fp, 0, FORMAT_MAX_PRECISION, func(prec int) string {
+		return strconv.FormatFloat(float64(ReadF32(stack, fp, inp1)), 'f', prec, 32)
+	})
+}
@@ -3,8 +3,41 @@ package base
 import (
 	"fmt"
 	"math"
+	"strconv"
 )
 
+func op_f64_f64(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	out1Offset := GetFinalOffset(stack, fp, out1, MEM_WRITE)
+
+	switch out1.Type {
+	case TYPE_BYTE:
+		WriteMemory(stack, out1Offset, out1, FromByte(byte(ReadF64(stack, fp, inp1))))
+	case TYPE_STR:
+		WriteString(stack, fp, out1, strconv.FormatFloat(ReadF64(stack, fp, inp1), 'f', -1, 64))
+	case TYPE_I8:
+		WriteMemory(stack, out1Offset, out1, FromI8(int8(ReadF64(stack, fp, inp1))))
+	case TYPE_I16:
+		WriteMemory(stack, out1Offset, out1, FromI16(int16(ReadF64(stack, fp, inp1))))
+	case TYPE_I32:
+		WriteMemory(stack, out1Offset, out1, FromI32(int32(ReadF64(stack, fp, inp1))))
+	case TYPE_I64:
+		WriteMemory(stack, out1Offset, out1, FromI64(int64(ReadF64(stack, fp, inp1))))
+	case TYPE_UI8:
+		WriteMemory(stack, out1Offset, out1, FromUI8(uint8(ReadF64(stack, fp, inp1))))
+	case TYPE_UI16:
+		WriteMemory(stack, out1Offset, out1, FromUI16(uint16(ReadF64(stack, fp, inp1))))
+	case TYPE_UI32:
+		WriteMemory(stack, out1Offset, out1, FromUI32(uint32(ReadF64(stack, fp, inp1))))
+	case TYPE_UI64:
+		WriteMemory(stack, out1Offset, out1, FromUI64(uint64(ReadF64(stack, fp, inp1))))
+	case TYPE_F32:
+		WriteMemory(stack, out1Offset, out1, FromF32(float32(ReadF64(stack, fp, inp1))))
+	case TYPE_F64:
+		WriteMemory(stack, out1Offset, out1, FromF64(float64(ReadF64(stack, fp, inp1))))
+	}
+}
+
 // op_f64_print. The print built-in function formats its arguments in an
 // implementation-specific
 
@@ -174,3 +207,13 @@ func op_f64_min(expr *CXExpression, stack *CXStack, fp int) {
 	outB1 := FromF64(math.Min(ReadF64(stack, fp, inp1), ReadF64(stack, fp, inp2)))
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
 }
+
+// op_f64_format. The format built-in function returns the string
+// representation of x with y digits after the decimal point. The second
+// output is true if y isn't between 0 and FORMAT_MAX_PRECISION
+func op_f64_format(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	writeNumberFormat(expr, stack, fp, 0, FORMAT_MAX_PRECISION, func(prec int) string {
+		return strconv.FormatFloat(float64(ReadF64(stack, fp, inp1)), 'f', prec, 64)
+	})
+}
@@ -2,9 +2,9 @@ package base
 
 import (
 	"fmt"
-	// "strconv"
 	"math"
 	"math/rand"
+	"strconv"
 )
 
 func op_i32_i32(expr *CXExpression, stack *CXStack, fp int) {
@@ -14,7 +14,20 @@ func op_i32_i32(expr *CXExpression, stack *CXStack, fp int) {
 	switch out1.Type {
 	case TYPE_BYTE:
 		WriteMemory(stack, out1Offset, out1, FromByte(byte(ReadI32(stack, fp, inp1))))
-		// case TYPE_STR: WriteMemory(stack, out1Offset, out1, FromStr(strconv.Itoa(ReadI32(stack, fp, inp1))))
+	case TYPE_STR:
+		WriteString(stack, fp, out1, strconv.FormatInt(int64(ReadI32(stack, fp, inp1)), 10))
+	case TYPE_I8:
+		WriteMemory(stack, out1Offset, out1, FromI8(int8(ReadI32(stack, fp, inp1))))
+	case TYPE_I16:
+		WriteMemory(stack, out1Offset, out1, FromI16(int16(ReadI32(stack, fp, inp1))))
+	case TYPE_UI8:
+		WriteMemory(stack, out1Offset, out1, FromUI8(uint8(ReadI32(stack, fp, inp1))))
+	case TYPE_UI16:
+		WriteMemory(stack, out1Offset, out1, FromUI16(uint16(ReadI32(stack, fp, inp1))))
+	case TYPE_UI32:
+		WriteMemory(stack, out1Offset, out1, FromUI32(uint32(ReadI32(stack, fp, inp1))))
+	case TYPE_UI64:
+		WriteMemory(stack, out1Offset, out1, FromUI64(uint64(ReadI32(stack, fp, inp1))))
 	case TYPE_I32:
 		WriteMemory(stack, out1Offset, out1, FromI32(ReadI32(stack, fp, inp1)))
 	case TYPE_I64:
@@ -232,3 +245,13 @@ func op_i32_min(expr *CXExpression, stack *CXStack, fp int) {
 	outB1 := FromI32(int32(math.Min(float64(ReadI32(stack, fp, inp1)), float64(ReadI32(stack, fp, inp2)))))
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
 }
+
+// op_i32_format. The format built-in function returns the string
+// representation of x in base y. The second output is true if y isn't
+// between 2 and 36
+func op_i32_format(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	writeNumberFormat(expr, stack, fp, 2, 36, func(base int) string {
+		return strconv.FormatInt(int64(ReadI32(stack, fp, inp1)), base)
+	})
+}
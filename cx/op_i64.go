@@ -4,8 +4,41 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"strconv"
 )
 
+func op_i64_i64(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	out1Offset := GetFinalOffset(stack, fp, out1, MEM_WRITE)
+
+	switch out1.Type {
+	case TYPE_BYTE:
+		WriteMemory(stack, out1Offset, out1, FromByte(byte(ReadI64(stack, fp, inp1))))
+	case TYPE_STR:
+		WriteString(stack, fp, out1, strconv.FormatInt(ReadI64(stack, fp, inp1), 10))
+	case TYPE_I8:
+		WriteMemory(stack, out1Offset, out1, FromI8(int8(ReadI64(stack, fp, inp1))))
+	case TYPE_I16:
+		WriteMemory(stack, out1Offset, out1, FromI16(int16(ReadI64(stack, fp, inp1))))
+	case TYPE_I32:
+		WriteMemory(stack, out1Offset, out1, FromI32(int32(ReadI64(stack, fp, inp1))))
+	case TYPE_I64:
+		WriteMemory(stack, out1Offset, out1, FromI64(int64(ReadI64(stack, fp, inp1))))
+	case TYPE_UI8:
+		WriteMemory(stack, out1Offset, out1, FromUI8(uint8(ReadI64(stack, fp, inp1))))
+	case TYPE_UI16:
+		WriteMemory(stack, out1Offset, out1, FromUI16(uint16(ReadI64(stack, fp, inp1))))
+	case TYPE_UI32:
+		WriteMemory(stack, out1Offset, out1, FromUI32(uint32(ReadI64(stack, fp, inp1))))
+	case TYPE_UI64:
+		WriteMemory(stack, out1Offset, out1, FromUI64(uint64(ReadI64(stack, fp, inp1))))
+	case TYPE_F32:
+		WriteMemory(stack, out1Offset, out1, FromF32(float32(ReadI64(stack, fp, inp1))))
+	case TYPE_F64:
+		WriteMemory(stack, out1Offset, out1, FromF64(float64(ReadI64(stack, fp, inp1))))
+	}
+}
+
 // op_i64_print. The print built-in function formats its arguments in an
 // implementation-specific
 
@@ -225,3 +258,13 @@ func op_i64_min(expr *CXExpression, stack *CXStack, fp int) {
 	outB1 := FromI64(int64(math.Min(float64(ReadI64(stack, fp, inp1)), float64(ReadI64(stack, fp, inp2)))))
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
 }
+
+// op_i64_format. The format built-in function returns the string
+// representation of x in base y. The second output is true if y isn't
+// between 2 and 36
+func op_i64_format(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	writeNumberFormat(expr, stack, fp, 2, 36, func(base int) string {
+		return strconv.FormatInt(int64(ReadI64(stack, fp, inp1)), base)
+	})
+}
@@ -1,6 +1,9 @@
 package base
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 
 func op_i8_print (expr *CXExpression, stack *CXStack, fp int) {
@@ -91,3 +94,87 @@ func op_i8_bitclear (expr *CXExpression, stack *CXStack, fp int) {
 	outB1 := FromI8(ReadI8(stack, fp, inp1) &^ ReadI8(stack, fp, inp2))
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
 }
+
+func op_i8_i8(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	out1Offset := GetFinalOffset(stack, fp, out1, MEM_WRITE)
+
+	switch out1.Type {
+	case TYPE_BYTE:
+		WriteMemory(stack, out1Offset, out1, FromByte(byte(ReadI8(stack, fp, inp1))))
+	case TYPE_STR:
+		WriteString(stack, fp, out1, strconv.FormatInt(int64(ReadI8(stack, fp, inp1)), 10))
+	case TYPE_I8:
+		WriteMemory(stack, out1Offset, out1, FromI8(int8(ReadI8(stack, fp, inp1))))
+	case TYPE_I16:
+		WriteMemory(stack, out1Offset, out1, FromI16(int16(ReadI8(stack, fp, inp1))))
+	case TYPE_I32:
+		WriteMemory(stack, out1Offset, out1, FromI32(int32(ReadI8(stack, fp, inp1))))
+	case TYPE_I64:
+		WriteMemory(stack, out1Offset, out1, FromI64(int64(ReadI8(stack, fp, inp1))))
+	case TYPE_UI8:
+		WriteMemory(stack, out1Offset, out1, FromUI8(uint8(ReadI8(stack, fp, inp1))))
+	case TYPE_UI16:
+		WriteMemory(stack, out1Offset, out1, FromUI16(uint16(ReadI8(stack, fp, inp1))))
+	case TYPE_UI32:
+		WriteMemory(stack, out1Offset, out1, FromUI32(uint32(ReadI8(stack, fp, inp1))))
+	case TYPE_UI64:
+		WriteMemory(stack, out1Offset, out1, FromUI64(uint64(ReadI8(stack, fp, inp1))))
+	case TYPE_F32:
+		WriteMemory(stack, out1Offset, out1, FromF32(float32(ReadI8(stack, fp, inp1))))
+	case TYPE_F64:
+		WriteMemory(stack, out1Offset, out1, FromF64(float64(ReadI8(stack, fp, inp1))))
+	}
+}
+
+func op_i8_mod(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromI8(ReadI8(stack, fp, inp1) % ReadI8(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_i8_abs(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	in1 := ReadI8(stack, fp, inp1)
+	if in1 < 0 {
+		in1 = -in1
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromI8(in1))
+}
+
+func op_i8_bitshl(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromI8(int8(uint8(ReadI8(stack, fp, inp1)) << uint8(ReadI8(stack, fp, inp2))))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_i8_bitshr(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromI8(int8(uint8(ReadI8(stack, fp, inp1)) >> uint8(ReadI8(stack, fp, inp2))))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_i8_max(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	in1, in2 := ReadI8(stack, fp, inp1), ReadI8(stack, fp, inp2)
+	if in2 > in1 {
+		in1 = in2
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromI8(in1))
+}
+
+func op_i8_min(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	in1, in2 := ReadI8(stack, fp, inp1), ReadI8(stack, fp, inp2)
+	if in2 < in1 {
+		in1 = in2
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromI8(in1))
+}
+
+func op_i8_format(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	writeNumberFormat(expr, stack, fp, 2, 36, func(base int) string {
+		return strconv.FormatInt(int64(ReadI8(stack, fp, inp1)), base)
+	})
+}
@@ -0,0 +1,547 @@
+package base
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+// naming styles of the JSON keys of struct fields without an explicit key
+const (
+	JSON_NAMING_EXACT = "exact" // the field name, e.g. UserName
+	JSON_NAMING_LOWER = "lower" // the field name in lower camel case, e.g. userName
+	JSON_NAMING_SNAKE = "snake" // the field name in snake case, e.g. user_name
+)
+
+// jsonKey returns the JSON key of the field `fld` of `strct`. An empty key
+// means the field is skipped
+func jsonKey(prgrm *CXProgram, strct *CXStruct, fld *CXArgument) string {
+	if key, found := prgrm.JSONFieldNames[strct.Name+"."+fld.Name]; found {
+		if key == "-" {
+			return ""
+		}
+		return key
+	}
+
+	switch prgrm.JSONNaming {
+	case JSON_NAMING_LOWER:
+		runes := []rune(fld.Name)
+		for c := 0; c < len(runes) && unicode.IsUpper(runes[c]); c++ {
+			// acronyms are lowered too, e.g. URLPath is urlPath
+			if c > 0 && c+1 < len(runes) && unicode.IsLower(runes[c+1]) {
+				break
+			}
+			runes[c] = unicode.ToLower(runes[c])
+		}
+		return string(runes)
+	case JSON_NAMING_SNAKE:
+		var buf bytes.Buffer
+		runes := []rune(fld.Name)
+		for c, r := range runes {
+			if unicode.IsUpper(r) {
+				if c > 0 && (unicode.IsLower(runes[c-1]) || c+1 < len(runes) && unicode.IsLower(runes[c+1])) {
+					buf.WriteByte('_')
+				}
+				r = unicode.ToLower(r)
+			}
+			buf.WriteRune(r)
+		}
+		return buf.String()
+	default:
+		return fld.Name
+	}
+}
+
+// jsonString quotes `str` as a JSON string
+func jsonString(str string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(str)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// marshalJSON writes the value stored in `byts`, whose layout is `t`, as
+// JSON. Null pointers are written as null, and so are pointers nested deeper
+// than FORMAT_MAX_DEPTH, which stops cycles
+func marshalJSON(buf *bytes.Buffer, prgrm *CXProgram, byts []byte, t valueType, depth int) {
+	switch t.specs[0] {
+	case DECL_ARRAY, DECL_SLICE:
+		buf.WriteByte('[')
+		elt := t.elem()
+		eltSize := elt.size()
+		for c := 0; c < t.lengths[0] && (c+1)*eltSize <= len(byts); c++ {
+			eltByts := byts[c*eltSize : (c+1)*eltSize]
+			if t.specs[0] == DECL_SLICE && elt.isBase() && elt.typ == TYPE_STR && bytes.Equal(eltByts, make([]byte, eltSize)) {
+				// a null string marks the end of a []str
+				break
+			}
+			if c > 0 {
+				buf.WriteByte(',')
+			}
+			marshalJSON(buf, prgrm, eltByts, elt, depth)
+		}
+		buf.WriteByte(']')
+	case DECL_STRUCT:
+		buf.WriteByte('{')
+		if t.strct != nil {
+			var offset int
+			var written bool
+			for _, fld := range t.strct.Fields {
+				fldByts := byts[offset:]
+				offset += fld.TotalSize
+
+				key := jsonKey(prgrm, t.strct, fld)
+				if key == "" || len(fldByts) < fld.TotalSize {
+					continue
+				}
+				if written {
+					buf.WriteByte(',')
+				}
+				written = true
+
+				buf.WriteString(jsonString(key))
+				buf.WriteByte(':')
+				marshalJSON(buf, prgrm, fldByts[:fld.TotalSize], declValueType(fld), depth)
+			}
+		}
+		buf.WriteByte('}')
+	case DECL_POINTER:
+		var heapOffset int32
+		encoder.DeserializeAtomic(byts[:TYPE_POINTER_SIZE], &heapOffset)
+
+		if t.isBase() && t.typ == TYPE_STR {
+			if heapOffset == NULL_HEAP_ADDRESS {
+				buf.WriteString(`""`)
+			} else {
+				buf.WriteString(jsonString(readStrFromHeap(prgrm, heapOffset)))
+			}
+			return
+		}
+
+		pointee := t.elem()
+		start := int(heapOffset) + OBJECT_HEADER_SIZE
+		end := start + pointee.size()
+		if heapOffset == NULL_HEAP_ADDRESS || depth >= FORMAT_MAX_DEPTH || end > len(prgrm.Heap.Heap) {
+			buf.WriteString("null")
+			return
+		}
+		marshalJSON(buf, prgrm, prgrm.Heap.Heap[start:end], pointee, depth+1)
+	default:
+		str := formatBasic(byts, t.typ)
+		if t.typ == TYPE_F32 || t.typ == TYPE_F64 {
+			if f, err := strconv.ParseFloat(str, 64); err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+				// JSON has no NaN or infinities
+				str = "null"
+			}
+		}
+		buf.WriteString(str)
+	}
+}
+
+// jsonBasicBytes converts the JSON value `val` to the bytes of a value of the
+// basic type `typ`
+func jsonBasicBytes(val interface{}, typ int) ([]byte, bool) {
+	if typ == TYPE_BOOL {
+		b, ok := val.(bool)
+		return FromBool(b), ok
+	}
+
+	num, ok := val.(json.Number)
+	if !ok {
+		return nil, false
+	}
+
+	switch typ {
+	case TYPE_F32:
+		f, err := strconv.ParseFloat(string(num), 32)
+		return FromF32(float32(f)), err == nil
+	case TYPE_F64:
+		f, err := strconv.ParseFloat(string(num), 64)
+		return FromF64(f), err == nil
+	case TYPE_BYTE, TYPE_UI8:
+		n, err := strconv.ParseUint(string(num), 10, 8)
+		return []byte{byte(n)}, err == nil
+	case TYPE_UI16:
+		n, err := strconv.ParseUint(string(num), 10, 16)
+		return encoder.SerializeAtomic(uint16(n)), err == nil
+	case TYPE_UI32:
+		n, err := strconv.ParseUint(string(num), 10, 32)
+		return encoder.SerializeAtomic(uint32(n)), err == nil
+	case TYPE_UI64:
+		n, err := strconv.ParseUint(string(num), 10, 64)
+		return encoder.SerializeAtomic(n), err == nil
+	case TYPE_I8:
+		n, err := strconv.ParseInt(string(num), 10, 8)
+		return []byte{byte(int8(n))}, err == nil
+	case TYPE_I16:
+		n, err := strconv.ParseInt(string(num), 10, 16)
+		return encoder.SerializeAtomic(int16(n)), err == nil
+	case TYPE_I32:
+		n, err := strconv.ParseInt(string(num), 10, 32)
+		return FromI32(int32(n)), err == nil
+	case TYPE_I64:
+		n, err := strconv.ParseInt(string(num), 10, 64)
+		return FromI64(n), err == nil
+	}
+
+	return nil, false
+}
+
+// unmarshalJSON stores the decoded JSON value `val` in `byts`, whose layout is
+// `t`, as encoding/json does: null leaves the value untouched, missing object
+// keys leave their fields untouched and array elements without a value are
+// zeroed. It returns false if part of `val` doesn't match `t`, but the parts
+// that match are still stored
+func unmarshalJSON(prgrm *CXProgram, byts []byte, t valueType, val interface{}, depth int) bool {
+	if val == nil {
+		return true
+	}
+
+	switch t.specs[0] {
+	case DECL_ARRAY, DECL_SLICE:
+		arr, ok := val.([]interface{})
+		if !ok {
+			return false
+		}
+
+		elt := t.elem()
+		eltSize := elt.size()
+		for c := 0; c < t.lengths[0] && (c+1)*eltSize <= len(byts); c++ {
+			eltByts := byts[c*eltSize : (c+1)*eltSize]
+			if c < len(arr) {
+				ok = unmarshalJSON(prgrm, eltByts, elt, arr[c], depth) && ok
+			} else {
+				copy(eltByts, make([]byte, eltSize))
+			}
+		}
+		return ok && len(arr) <= t.lengths[0]
+	case DECL_STRUCT:
+		obj, ok := val.(map[string]interface{})
+		if !ok || t.strct == nil {
+			return false
+		}
+
+		for key, fldVal := range obj {
+			var offset int
+			var fld *CXArgument
+			var fldOffset int
+			for _, f := range t.strct.Fields {
+				fldKey := jsonKey(prgrm, t.strct, f)
+				if fldKey == key || fld == nil && fldKey != "" && strings.EqualFold(fldKey, key) {
+					fld, fldOffset = f, offset
+				}
+				offset += f.TotalSize
+			}
+
+			if fld != nil && fldOffset+fld.TotalSize <= len(byts) {
+				ok = unmarshalJSON(prgrm, byts[fldOffset:fldOffset+fld.TotalSize], declValueType(fld), fldVal, depth) && ok
+			}
+		}
+		return ok
+	case DECL_POINTER:
+		var heapOffset int32
+		encoder.DeserializeAtomic(byts[:TYPE_POINTER_SIZE], &heapOffset)
+
+		if t.isBase() && t.typ == TYPE_STR {
+			str, ok := val.(string)
+			if ok {
+				copy(byts, encoder.SerializeAtomic(int32(WriteObject(prgrm, encoder.Serialize(str)))))
+			}
+			return ok
+		}
+
+		if depth >= FORMAT_MAX_DEPTH {
+			return false
+		}
+
+		pointee := t.elem()
+		if heapOffset == NULL_HEAP_ADDRESS {
+			heapOffset = int32(WriteObject(prgrm, make([]byte, pointee.size())))
+			copy(byts, encoder.SerializeAtomic(heapOffset))
+		}
+
+		start := int(heapOffset) + OBJECT_HEADER_SIZE
+		return unmarshalJSON(prgrm, prgrm.Heap.Heap[start:start+pointee.size()], pointee, val, depth+1)
+	default:
+		basic, ok := jsonBasicBytes(val, t.typ)
+		if ok {
+			copy(byts, basic)
+		}
+		return ok
+	}
+}
+
+// decodeJSON decodes `str` keeping numbers as json.Number, so integers don't
+// lose precision
+func decodeJSON(str string) (val interface{}, err error) {
+	dec := json.NewDecoder(strings.NewReader(str))
+	dec.UseNumber()
+	if err = dec.Decode(&val); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, &json.SyntaxError{Offset: dec.InputOffset()}
+	}
+	return val, nil
+}
+
+// op_json_Marshal. The Marshal built-in function returns the JSON encoding of
+// `v`, which can be of any type. Struct fields are encoded as object keys,
+// named as json.Field and json.Naming say
+func op_json_Marshal(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+
+	t := argValueType(inp1)
+	var buf bytes.Buffer
+	if t.isBase() && t.typ == TYPE_STR && t.specs[0] == DECL_POINTER {
+		buf.WriteString(jsonString(ReadStr(stack, fp, inp1)))
+	} else {
+		byts := ReadMemory(stack, GetFinalOffset(stack, fp, inp1, MEM_READ), inp1)
+		marshalJSON(&buf, stack.Program, byts, t, 0)
+	}
+
+	WriteString(stack, fp, out1, buf.String())
+}
+
+// op_json_Unmarshal. The Unmarshal built-in function decodes the JSON string
+// `s` into the variable pointed by its second input, e.g. &v. It returns false
+// if `s` isn't valid JSON or doesn't match the type of the variable
+func op_json_Unmarshal(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	prgrm := stack.Program
+
+	val, err := decodeJSON(ReadStr(stack, fp, inp1))
+	ok := err == nil
+
+	if ok {
+		t := argValueType(inp2)
+		var mem []byte
+		if inp2.IsReference {
+			// &v: the variable is in the stack frame
+			offset := GetFinalOffset(stack, fp, inp2, MEM_READ)
+			mem = stack.Stack[offset : offset+t.size()]
+		} else if t.specs[0] == DECL_POINTER && !t.isBase() {
+			// a pointer variable
+			var heapOffset int32
+			encoder.DeserializeAtomic(ReadMemory(stack, GetFinalOffset(stack, fp, inp2, MEM_READ), inp2), &heapOffset)
+			t = t.elem()
+			if heapOffset != NULL_HEAP_ADDRESS {
+				start := int(heapOffset) + OBJECT_HEADER_SIZE
+				mem = prgrm.Heap.Heap[start : start+t.size()]
+			}
+		}
+
+		if mem == nil {
+			ok = false
+		} else {
+			// decoding into a copy, as strings are allocated while decoding
+			byts := make([]byte, len(mem))
+			copy(byts, mem)
+			ok = unmarshalJSON(prgrm, byts, t, val, 0)
+			copy(mem, byts)
+		}
+	}
+
+	writeOptionalOutput(expr, stack, fp, 0, FromBool(ok))
+}
+
+// op_json_Field. The Field built-in function sets the JSON key of the field
+// `field` of the struct type `typ`. The key "-" skips the field
+func op_json_Field(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2]
+	prgrm := stack.Program
+
+	if prgrm.JSONFieldNames == nil {
+		prgrm.JSONFieldNames = make(map[string]string)
+	}
+	prgrm.JSONFieldNames[ReadStr(stack, fp, inp1)+"."+ReadStr(stack, fp, inp2)] = ReadStr(stack, fp, inp3)
+}
+
+// op_json_Naming. The Naming built-in function sets how the JSON keys of the
+// fields without a key set by json.Field are named: "exact", "lower" or
+// "snake". It returns false if the style is unknown
+func op_json_Naming(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	style := ReadStr(stack, fp, inp1)
+
+	ok := style == JSON_NAMING_EXACT || style == JSON_NAMING_LOWER || style == JSON_NAMING_SNAKE
+	if ok {
+		stack.Program.JSONNaming = style
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromBool(ok))
+}
+
+// addJSONNode stores a decoded JSON value and returns its node handle
+func addJSONNode(prgrm *CXProgram, val interface{}) int32 {
+	prgrm.JSONNodes = append(prgrm.JSONNodes, val)
+	return int32(len(prgrm.JSONNodes) - 1)
+}
+
+// getJSONNode returns the JSON value of the node `node`, and false if the
+// handle is invalid
+func getJSONNode(prgrm *CXProgram, node int32) (interface{}, bool) {
+	if node < 0 || int(node) >= len(prgrm.JSONNodes) {
+		return nil, false
+	}
+	return prgrm.JSONNodes[node], true
+}
+
+// writeJSONNode writes the node handle of `val` to the first output of `expr`,
+// or -1 if there's no value, and whether there's a value to the second output
+func writeJSONNode(expr *CXExpression, stack *CXStack, fp int, val interface{}, ok bool) {
+	out1 := expr.Outputs[0]
+	node := int32(-1)
+	if ok {
+		node = addJSONNode(stack.Program, val)
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromI32(node))
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(ok))
+}
+
+// op_json_Parse. The Parse built-in function parses a JSON string of unknown
+// shape into a tree. It returns the handle of the root node, which is used by
+// the rest of the json node functions, and false if `s` isn't valid JSON
+func op_json_Parse(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	val, err := decodeJSON(ReadStr(stack, fp, inp1))
+	writeJSONNode(expr, stack, fp, val, err == nil)
+}
+
+// op_json_Kind. The Kind built-in function returns the kind of the JSON node
+// `node`: "null", "bool", "number", "string", "array" or "object". It returns
+// an empty string if the handle is invalid
+func op_json_Kind(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	var kind string
+	if val, ok := getJSONNode(stack.Program, ReadI32(stack, fp, inp1)); ok {
+		switch val.(type) {
+		case nil:
+			kind = "null"
+		case bool:
+			kind = "bool"
+		case json.Number:
+			kind = "number"
+		case string:
+			kind = "string"
+		case []interface{}:
+			kind = "array"
+		case map[string]interface{}:
+			kind = "object"
+		}
+	}
+	WriteString(stack, fp, out1, kind)
+}
+
+// op_json_Get. The Get built-in function returns the node of the key `key` of
+// the object node `node`, and false if there's no such key
+func op_json_Get(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	val, _ := getJSONNode(stack.Program, ReadI32(stack, fp, inp1))
+	obj, _ := val.(map[string]interface{})
+	child, ok := obj[ReadStr(stack, fp, inp2)]
+	writeJSONNode(expr, stack, fp, child, ok)
+}
+
+// op_json_Index. The Index built-in function returns the node of the element
+// `idx` of the array node `node`, and false if there's no such element
+func op_json_Index(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	val, _ := getJSONNode(stack.Program, ReadI32(stack, fp, inp1))
+	arr, _ := val.([]interface{})
+	idx := ReadI32(stack, fp, inp2)
+	if idx < 0 || int(idx) >= len(arr) {
+		writeJSONNode(expr, stack, fp, nil, false)
+		return
+	}
+	writeJSONNode(expr, stack, fp, arr[idx], true)
+}
+
+// op_json_Len. The Len built-in function returns the number of elements of an
+// array node, or the number of keys of an object node
+func op_json_Len(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	var n int
+	val, _ := getJSONNode(stack.Program, ReadI32(stack, fp, inp1))
+	switch v := val.(type) {
+	case []interface{}:
+		n = len(v)
+	case map[string]interface{}:
+		n = len(v)
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromI32(int32(n)))
+}
+
+// op_json_Keys. The Keys built-in function returns the sorted keys of an
+// object node and their count
+func op_json_Keys(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	var keys []string
+	val, _ := getJSONNode(stack.Program, ReadI32(stack, fp, inp1))
+	if obj, ok := val.(map[string]interface{}); ok {
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	}
+	n := writeStrSlice(stack, fp, out1, keys)
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+}
+
+// op_json_Str. The Str built-in function returns the value of a string node,
+// and false if the node isn't a string
+func op_json_Str(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	val, _ := getJSONNode(stack.Program, ReadI32(stack, fp, inp1))
+	str, ok := val.(string)
+	WriteString(stack, fp, out1, str)
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(ok))
+}
+
+// op_json_Bool. The Bool built-in function returns the value of a bool node,
+// and false as its second output if the node isn't a bool
+func op_json_Bool(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	val, _ := getJSONNode(stack.Program, ReadI32(stack, fp, inp1))
+	b, ok := val.(bool)
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromBool(b))
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(ok))
+}
+
+// writeJSONNumber converts the number node read by the first input of `expr`
+// to the basic type `typ` and writes it to the first output, and whether the
+// conversion succeeded to the second one
+func writeJSONNumber(expr *CXExpression, stack *CXStack, fp int, typ int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	val, _ := getJSONNode(stack.Program, ReadI32(stack, fp, inp1))
+	byts, ok := jsonBasicBytes(val, typ)
+	if !ok {
+		byts = make([]byte, GetArgSize(typ))
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, byts)
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(ok))
+}
+
+// op_json_I32. The I32 built-in function returns the value of a number node as
+// an i32, and false if the node isn't an integer that fits in an i32
+func op_json_I32(expr *CXExpression, stack *CXStack, fp int) {
+	writeJSONNumber(expr, stack, fp, TYPE_I32)
+}
+
+// op_json_I64. The I64 built-in function returns the value of a number node as
+// an i64, and false if the node isn't an integer that fits in an i64
+func op_json_I64(expr *CXExpression, stack *CXStack, fp int) {
+	writeJSONNumber(expr, stack, fp, TYPE_I64)
+}
+
+// op_json_F64. The F64 built-in function returns the value of a number node as
+// an f64, and false if the node isn't a number
+func op_json_F64(expr *CXExpression, stack *CXStack, fp int) {
+	writeJSONNumber(expr, stack, fp, TYPE_F64)
+}
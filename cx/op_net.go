@@ -0,0 +1,240 @@
+package base
+
+import (
+	"io"
+	"net"
+)
+
+// error codes returned by the net natives
+const (
+	NET_OK = iota
+	NET_ERROR
+	NET_INVALID_HANDLE
+	NET_EOF
+	NET_TIMEOUT
+)
+
+// netErrorCode converts an error returned by the net package to the error
+// code received by a CX program
+func netErrorCode(err error) int32 {
+	if err == nil {
+		return NET_OK
+	}
+	if err == io.EOF {
+		return NET_EOF
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return NET_TIMEOUT
+	}
+	return NET_ERROR
+}
+
+// addNetHandle stores a connection or a listener in the program's handle
+// table and returns its handle, reusing the handles of closed ones
+func addNetHandle(prgrm *CXProgram, handle interface{}) int32 {
+	for c, h := range prgrm.NetHandles {
+		if h == nil {
+			prgrm.NetHandles[c] = handle
+			return int32(c)
+		}
+	}
+	prgrm.NetHandles = append(prgrm.NetHandles, handle)
+	return int32(len(prgrm.NetHandles) - 1)
+}
+
+// CloseNetHandles closes the connections and listeners the program left open,
+// so a listener doesn't keep its port once the program is released
+func (prgrm *CXProgram) CloseNetHandles() {
+	for _, handle := range prgrm.NetHandles {
+		if closer, ok := handle.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+	prgrm.NetHandles = nil
+}
+
+// getNetHandle returns the connection or listener opened with `handle`, or
+// nil if it isn't open
+func getNetHandle(prgrm *CXProgram, handle int32) interface{} {
+	if handle < 0 || int(handle) >= len(prgrm.NetHandles) {
+		return nil
+	}
+	return prgrm.NetHandles[handle]
+}
+
+// writeNetHandle writes the handle of `conn` to the first output of `expr`,
+// or -1 if it couldn't be opened, and the error code to the second one
+func writeNetHandle(expr *CXExpression, stack *CXStack, fp int, conn interface{}, err error) {
+	out1 := expr.Outputs[0]
+	handle := int32(-1)
+	if err == nil {
+		handle = addNetHandle(stack.Program, conn)
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromI32(handle))
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(netErrorCode(err)))
+}
+
+// op_net_Dial. The Dial built-in function connects to `address` on the
+// `network` "tcp" or "udp". It returns a connection handle, or -1 if the
+// connection failed, and an error code
+func op_net_Dial(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	conn, err := net.Dial(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2))
+	writeNetHandle(expr, stack, fp, conn, err)
+}
+
+// op_net_Listen. The Listen built-in function listens for connections at
+// `address` on a stream `network` such as "tcp". It returns a listener handle,
+// or -1 if it failed, and an error code
+func op_net_Listen(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	ln, err := net.Listen(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2))
+	writeNetHandle(expr, stack, fp, ln, err)
+}
+
+// op_net_ListenPacket. The ListenPacket built-in function listens for packets
+// at `address` on a packet `network` such as "udp". The returned handle is
+// used by ReadFrom and WriteTo
+func op_net_ListenPacket(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	conn, err := net.ListenPacket(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2))
+	writeNetHandle(expr, stack, fp, conn, err)
+}
+
+// op_net_Accept. The Accept built-in function waits for the next connection
+// to the listener `handle` and returns its connection handle and an error code
+func op_net_Accept(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	if ln, ok := getNetHandle(stack.Program, ReadI32(stack, fp, inp1)).(net.Listener); ok {
+		conn, err := ln.Accept()
+		writeNetHandle(expr, stack, fp, conn, err)
+		return
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromI32(-1))
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(NET_INVALID_HANDLE))
+}
+
+// op_net_Read. The Read built-in function reads up to len(output) bytes from
+// the connection `handle` into the []byte output. It also returns the number
+// of bytes read and an error code, which is NET_EOF if the connection was
+// closed by the other end
+func op_net_Read(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	buf := make([]byte, out1.TotalSize)
+	var n int
+	var code int32 = NET_INVALID_HANDLE
+	if conn, ok := getNetHandle(stack.Program, ReadI32(stack, fp, inp1)).(net.Conn); ok {
+		var err error
+		n, err = conn.Read(buf)
+		code = netErrorCode(err)
+	}
+	writeBytes(stack, fp, out1, buf[:n])
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+	writeOptionalOutput(expr, stack, fp, 2, FromI32(code))
+}
+
+// op_net_Write. The Write built-in function writes the first `n` bytes of
+// `data` to the connection `handle`. It returns the number of bytes written
+// and an error code
+func op_net_Write(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2]
+	byts := ReadMemory(stack, GetFinalOffset(stack, fp, inp2, MEM_READ), inp2)
+	byts = firstBytes(byts, ReadI32(stack, fp, inp3))
+	var n int
+	var code int32 = NET_INVALID_HANDLE
+	if conn, ok := getNetHandle(stack.Program, ReadI32(stack, fp, inp1)).(net.Conn); ok {
+		var err error
+		n, err = conn.Write(byts)
+		code = netErrorCode(err)
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(int32(n)))
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(code))
+}
+
+// op_net_WriteString. The WriteString built-in function writes the string `s`
+// to the connection `handle` and returns an error code
+func op_net_WriteString(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	var code int32 = NET_INVALID_HANDLE
+	if conn, ok := getNetHandle(stack.Program, ReadI32(stack, fp, inp1)).(net.Conn); ok {
+		_, err := io.WriteString(conn, ReadStr(stack, fp, inp2))
+		code = netErrorCode(err)
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(code))
+}
+
+// op_net_ReadFrom. The ReadFrom built-in function reads a packet from the
+// packet connection `handle` into the []byte output. It also returns the
+// number of bytes read, the address of the sender and an error code
+func op_net_ReadFrom(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	buf := make([]byte, out1.TotalSize)
+	var n int
+	var addr string
+	var code int32 = NET_INVALID_HANDLE
+	if conn, ok := getNetHandle(stack.Program, ReadI32(stack, fp, inp1)).(net.PacketConn); ok {
+		var from net.Addr
+		var err error
+		n, from, err = conn.ReadFrom(buf)
+		if from != nil {
+			addr = from.String()
+		}
+		code = netErrorCode(err)
+	}
+	writeBytes(stack, fp, out1, buf[:n])
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+	if len(expr.Outputs) > 2 {
+		WriteString(stack, fp, expr.Outputs[2], addr)
+	}
+	writeOptionalOutput(expr, stack, fp, 3, FromI32(code))
+}
+
+// op_net_WriteTo. The WriteTo built-in function sends the first `n` bytes of
+// `data` as a packet to `address` from the packet connection `handle`. It
+// returns the number of bytes written and an error code
+func op_net_WriteTo(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3, inp4 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2], expr.Inputs[3]
+	byts := ReadMemory(stack, GetFinalOffset(stack, fp, inp2, MEM_READ), inp2)
+	byts = firstBytes(byts, ReadI32(stack, fp, inp3))
+	var n int
+	var code int32 = NET_INVALID_HANDLE
+	if conn, ok := getNetHandle(stack.Program, ReadI32(stack, fp, inp1)).(net.PacketConn); ok {
+		addr, err := net.ResolveUDPAddr(conn.LocalAddr().Network(), ReadStr(stack, fp, inp4))
+		if err == nil {
+			n, err = conn.WriteTo(byts, addr)
+		}
+		code = netErrorCode(err)
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(int32(n)))
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(code))
+}
+
+// op_net_LocalAddr. The LocalAddr built-in function returns the local address
+// of the connection or listener `handle`, which tells the port chosen when
+// listening at port 0
+func op_net_LocalAddr(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	var addr string
+	switch h := getNetHandle(stack.Program, ReadI32(stack, fp, inp1)).(type) {
+	case net.Conn:
+		addr = h.LocalAddr().String()
+	case net.Listener:
+		addr = h.Addr().String()
+	case net.PacketConn:
+		addr = h.LocalAddr().String()
+	}
+	WriteString(stack, fp, out1, addr)
+}
+
+// op_net_Close. The Close built-in function closes the connection or listener
+// `handle` and returns an error code
+func op_net_Close(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	handle := ReadI32(stack, fp, inp1)
+	var code int32 = NET_INVALID_HANDLE
+	if closer, ok := getNetHandle(stack.Program, handle).(io.Closer); ok {
+		code = netErrorCode(closer.Close())
+		stack.Program.NetHandles[handle] = nil
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(code))
+}
@@ -0,0 +1,29 @@
+package base
+
+import (
+	"net"
+	"testing"
+)
+
+func TestCloseNetHandles(t *testing.T) {
+	prgrm, _ := makeTestProgram()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	handle := addNetHandle(prgrm, ln)
+
+	prgrm.CloseNetHandles()
+	if getNetHandle(prgrm, handle) != nil {
+		t.Errorf("the handle is still open")
+	}
+
+	// the port is free for the next program
+	ln, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("listening again at %s: %v", addr, err)
+	}
+	ln.Close()
+}
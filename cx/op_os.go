@@ -2,6 +2,10 @@ package base
 
 import (
 	// "fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
 	"github.com/skycoin/skycoin/src/cipher/encoder"
 )
 
@@ -27,3 +31,349 @@ func op_os_GetWorkingDirectory(expr *CXExpression, stack *CXStack, fp int) {
 
 	WriteToStack(stack, out1Offset, off)
 }
+
+// error codes returned by the file system natives
+const (
+	OS_OK = iota
+	OS_ERROR
+	OS_NOT_EXIST
+	OS_EXIST
+	OS_PERMISSION
+	OS_INVALID_HANDLE
+	OS_EOF
+)
+
+// osErrorCode converts an error returned by the os package to the error code
+// received by a CX program
+func osErrorCode(err error) int32 {
+	switch {
+	case err == nil:
+		return OS_OK
+	case err == io.EOF:
+		return OS_EOF
+	case os.IsNotExist(err):
+		return OS_NOT_EXIST
+	case os.IsExist(err):
+		return OS_EXIST
+	case os.IsPermission(err):
+		return OS_PERMISSION
+	default:
+		return OS_ERROR
+	}
+}
+
+// writeOptionalOutput writes `byts` to the output `idx` of `expr`, if the call
+// receives it. Error codes are optional outputs, so they can be ignored
+func writeOptionalOutput(expr *CXExpression, stack *CXStack, fp int, idx int, byts []byte) {
+	if len(expr.Outputs) > idx {
+		out := expr.Outputs[idx]
+		WriteMemory(stack, GetFinalOffset(stack, fp, out, MEM_WRITE), out, byts)
+	}
+}
+
+// writeBytes writes `byts` to the []byte output `out`, filling the rest of
+// the array with zeros. It returns the number of bytes written
+func writeBytes(stack *CXStack, fp int, out *CXArgument, byts []byte) int {
+	buf := make([]byte, out.TotalSize)
+	n := copy(buf, byts)
+	WriteMemory(stack, GetFinalOffset(stack, fp, out, MEM_WRITE), out, buf)
+	return n
+}
+
+// firstBytes returns the first `n` bytes of `byts`, clamping `n` to its length
+func firstBytes(byts []byte, n int32) []byte {
+	if n < 0 {
+		return byts[:0]
+	}
+	if int(n) > len(byts) {
+		return byts
+	}
+	return byts[:n]
+}
+
+// addFileHandle stores an open file in the program's handle table and returns
+// its handle, reusing the handles of closed files
+func addFileHandle(prgrm *CXProgram, file *os.File) int32 {
+	for c, f := range prgrm.FileHandles {
+		if f == nil {
+			prgrm.FileHandles[c] = file
+			return int32(c)
+		}
+	}
+	prgrm.FileHandles = append(prgrm.FileHandles, file)
+	return int32(len(prgrm.FileHandles) - 1)
+}
+
+// getFileHandle returns the file opened with `handle`, or nil if it isn't open
+func getFileHandle(prgrm *CXProgram, handle int32) *os.File {
+	if handle < 0 || int(handle) >= len(prgrm.FileHandles) {
+		return nil
+	}
+	return prgrm.FileHandles[handle]
+}
+
+// CloseFiles closes the files the program left open. It's called when the
+// program is released, as cx test does after each of its tests
+func (prgrm *CXProgram) CloseFiles() {
+	for _, file := range prgrm.FileHandles {
+		if file != nil {
+			file.Close()
+		}
+	}
+	prgrm.FileHandles = nil
+}
+
+// op_os_ReadFile. The ReadFile built-in function returns the contents of the
+// file at `path` as a string and an error code
+func op_os_ReadFile(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	byts, err := ioutil.ReadFile(ReadStr(stack, fp, inp1))
+	if len(expr.Outputs) > 0 {
+		WriteString(stack, fp, expr.Outputs[0], string(byts))
+	}
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(osErrorCode(err)))
+}
+
+// op_os_WriteFile. The WriteFile built-in function writes the string `data`
+// to the file at `path`, creating it if needed, and returns an error code
+func op_os_WriteFile(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	err := ioutil.WriteFile(ReadStr(stack, fp, inp1), []byte(ReadStr(stack, fp, inp2)), 0644)
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(osErrorCode(err)))
+}
+
+// op_os_ReadFileBytes. The ReadFileBytes built-in function reads the file at
+// `path` into the []byte output. As arrays have a fixed length, only the first
+// len(output) bytes are read. It also returns the number of bytes read and an
+// error code
+func op_os_ReadFileBytes(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	byts, err := ioutil.ReadFile(ReadStr(stack, fp, inp1))
+	var n int
+	if len(expr.Outputs) > 0 {
+		n = writeBytes(stack, fp, expr.Outputs[0], byts)
+	}
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+	writeOptionalOutput(expr, stack, fp, 2, FromI32(osErrorCode(err)))
+}
+
+// op_os_WriteFileBytes. The WriteFileBytes built-in function writes the first
+// `n` bytes of `data` to the file at `path`, creating it if needed, and
+// returns an error code
+func op_os_WriteFileBytes(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2]
+	byts := ReadMemory(stack, GetFinalOffset(stack, fp, inp2, MEM_READ), inp2)
+	err := ioutil.WriteFile(ReadStr(stack, fp, inp1), firstBytes(byts, ReadI32(stack, fp, inp3)), 0644)
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(osErrorCode(err)))
+}
+
+// op_os_Open. The Open built-in function opens the file at `path` for reading.
+// It returns a file handle, or -1 if the file couldn't be opened, and an error
+// code
+func op_os_Open(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	handle := int32(-1)
+	file, err := os.Open(ReadStr(stack, fp, inp1))
+	if err == nil {
+		handle = addFileHandle(stack.Program, file)
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(handle))
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(osErrorCode(err)))
+}
+
+// op_os_Create. The Create built-in function creates or truncates the file at
+// `path` and opens it for reading and writing. It returns a file handle, or -1
+// if the file couldn't be created, and an error code
+func op_os_Create(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	handle := int32(-1)
+	file, err := os.Create(ReadStr(stack, fp, inp1))
+	if err == nil {
+		handle = addFileHandle(stack.Program, file)
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(handle))
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(osErrorCode(err)))
+}
+
+// op_os_Read. The Read built-in function reads up to len(output) bytes from
+// the file `handle` into the []byte output. It also returns the number of
+// bytes read and an error code, which is OS_EOF at the end of the file
+func op_os_Read(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	var buf []byte
+	if len(expr.Outputs) > 0 {
+		buf = make([]byte, expr.Outputs[0].TotalSize)
+	}
+	var n int
+	var code int32 = OS_INVALID_HANDLE
+	if file := getFileHandle(stack.Program, ReadI32(stack, fp, inp1)); file != nil {
+		var err error
+		n, err = file.Read(buf)
+		code = osErrorCode(err)
+	}
+	if len(expr.Outputs) > 0 {
+		writeBytes(stack, fp, expr.Outputs[0], buf[:n])
+	}
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+	writeOptionalOutput(expr, stack, fp, 2, FromI32(code))
+}
+
+// op_os_Write. The Write built-in function writes the first `n` bytes of
+// `data` to the file `handle`. It returns the number of bytes written and an
+// error code
+func op_os_Write(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2]
+	byts := ReadMemory(stack, GetFinalOffset(stack, fp, inp2, MEM_READ), inp2)
+	byts = firstBytes(byts, ReadI32(stack, fp, inp3))
+	var n int
+	var code int32 = OS_INVALID_HANDLE
+	if file := getFileHandle(stack.Program, ReadI32(stack, fp, inp1)); file != nil {
+		var err error
+		n, err = file.Write(byts)
+		code = osErrorCode(err)
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(int32(n)))
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(code))
+}
+
+// op_os_WriteString. The WriteString built-in function writes the string `s`
+// to the file `handle` and returns an error code
+func op_os_WriteString(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	var code int32 = OS_INVALID_HANDLE
+	if file := getFileHandle(stack.Program, ReadI32(stack, fp, inp1)); file != nil {
+		_, err := file.WriteString(ReadStr(stack, fp, inp2))
+		code = osErrorCode(err)
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(code))
+}
+
+// op_os_Seek. The Seek built-in function sets the offset of the next read or
+// write of the file `handle` to `offset`, relative to the start of the file if
+// `whence` is 0, to the current offset if it's 1 and to the end if it's 2. It
+// returns the new offset and an error code
+func op_os_Seek(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2]
+	var pos int64
+	var code int32 = OS_INVALID_HANDLE
+	if file := getFileHandle(stack.Program, ReadI32(stack, fp, inp1)); file != nil {
+		var err error
+		pos, err = file.Seek(ReadI64(stack, fp, inp2), int(ReadI32(stack, fp, inp3)))
+		code = osErrorCode(err)
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI64(pos))
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(code))
+}
+
+// op_os_Close. The Close built-in function closes the file `handle` and
+// returns an error code
+func op_os_Close(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	handle := ReadI32(stack, fp, inp1)
+	var code int32 = OS_INVALID_HANDLE
+	if file := getFileHandle(stack.Program, handle); file != nil {
+		code = osErrorCode(file.Close())
+		stack.Program.FileHandles[handle] = nil
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(code))
+}
+
+// op_os_ReadDir. The ReadDir built-in function writes the names of the entries
+// of the directory at `path`, sorted by name, to the []str output. Unused
+// elements are set to null. It also returns the number of names written and an
+// error code
+func op_os_ReadDir(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	infos, err := ioutil.ReadDir(ReadStr(stack, fp, inp1))
+
+	names := make([]string, len(infos))
+	for c, info := range infos {
+		names[c] = info.Name()
+	}
+	var n int
+	if len(expr.Outputs) > 0 {
+		n = writeStrSlice(stack, fp, expr.Outputs[0], names)
+	}
+
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+	writeOptionalOutput(expr, stack, fp, 2, FromI32(osErrorCode(err)))
+}
+
+// op_os_Stat. The Stat built-in function returns the size in bytes of the
+// file at `path`, whether it's a directory, its modification time in Unix
+// seconds and an error code
+func op_os_Stat(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	var size, modTime int64
+	var isDir bool
+	info, err := os.Stat(ReadStr(stack, fp, inp1))
+	if err == nil {
+		size = info.Size()
+		isDir = info.IsDir()
+		modTime = info.ModTime().Unix()
+	}
+	writeOptionalOutput(expr, stack, fp, 0, FromI64(size))
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(isDir))
+	writeOptionalOutput(expr, stack, fp, 2, FromI64(modTime))
+	writeOptionalOutput(expr, stack, fp, 3, FromI32(osErrorCode(err)))
+}
+
+// op_os_Mkdir. The Mkdir built-in function creates the directory at `path`,
+// along with any missing parent, and returns an error code
+func op_os_Mkdir(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	err := os.MkdirAll(ReadStr(stack, fp, inp1), 0755)
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(osErrorCode(err)))
+}
+
+// op_os_Remove. The Remove built-in function removes the file or empty
+// directory at `path` and returns an error code
+func op_os_Remove(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	err := os.Remove(ReadStr(stack, fp, inp1))
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(osErrorCode(err)))
+}
+
+// op_os_Rename. The Rename built-in function moves the file or directory at
+// `oldPath` to `newPath` and returns an error code
+func op_os_Rename(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	err := os.Rename(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2))
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(osErrorCode(err)))
+}
+
+// op_os_Args. The Args built-in function writes the command-line arguments of
+// the program to the []str output, starting with the name of its first source
+// file. They are the arguments following "--" in `cx prog.cx -- a b c`. It
+// also returns the number of arguments written
+func op_os_Args(expr *CXExpression, stack *CXStack, fp int) {
+	out1 := expr.Outputs[0]
+	n := writeStrSlice(stack, fp, out1, stack.Program.Args)
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+}
+
+// op_os_Getenv. The Getenv built-in function returns the value of the
+// environment variable `key`, or an empty string if it isn't set
+func op_os_Getenv(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	WriteString(stack, fp, out1, os.Getenv(ReadStr(stack, fp, inp1)))
+}
+
+// op_os_Setenv. The Setenv built-in function sets the environment variable
+// `key` to `value` and returns an error code
+func op_os_Setenv(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	err := os.Setenv(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2))
+	writeOptionalOutput(expr, stack, fp, 0, FromI32(osErrorCode(err)))
+}
+
+// op_os_Exit. The Exit built-in function terminates the program with the exit
+// status `code`. The VM stops instead of the process exiting, so the coverage,
+// profile and trace are still written
+func op_os_Exit(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	prgrm := stack.Program
+	prgrm.ExitCode = int(ReadI32(stack, fp, inp1))
+	prgrm.Exited = true
+	prgrm.Terminated = true
+}
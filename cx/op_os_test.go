@@ -0,0 +1,50 @@
+package base
+
+import "testing"
+
+// makeExitProgram makes the equivalent of:
+//
+//	var total i32
+//	func main() (status i32) {
+//		os.Exit(3)
+//		total = 1
+//		status = 2
+//	}
+func makeExitProgram() (*CXProgram, *CXArgument) {
+	prgrm, main := makeTestProgram()
+
+	total := testData(prgrm, "total", TYPE_I32, FromI32(0))
+	main.AddGlobal(total)
+
+	mainFn := MakeFunction(MAIN_FUNC)
+	main.AddFunction(mainFn)
+	status := testLocal("status", TYPE_I32, 0)
+	mainFn.Outputs = []*CXArgument{status}
+
+	exit := MakeExpression(Natives[OP_OS_EXIT], "exit.cx", 5)
+	exit.AddInput(testData(prgrm, "", TYPE_I32, FromI32(3)))
+	assignTotal := MakeExpression(Natives[OP_IDENTITY], "exit.cx", 6)
+	assignTotal.AddInput(testData(prgrm, "", TYPE_I32, FromI32(1))).AddOutput(total)
+	assignStatus := MakeExpression(Natives[OP_IDENTITY], "exit.cx", 7)
+	assignStatus.AddInput(testData(prgrm, "", TYPE_I32, FromI32(2))).AddOutput(status)
+	for _, expr := range []*CXExpression{exit, assignTotal, assignStatus} {
+		mainFn.AddExpression(expr)
+	}
+	mainFn.Length, mainFn.Size = len(mainFn.Expressions), 4
+
+	return prgrm, total
+}
+
+func TestExit(t *testing.T) {
+	prgrm, total := makeExitProgram()
+	if err := prgrm.RunCompiled(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !prgrm.Exited || prgrm.ExitCode != 3 {
+		t.Errorf("exited = %v with %d, want true with 3", prgrm.Exited, prgrm.ExitCode)
+	}
+	if val := ReadI32(&prgrm.Stacks[0], 0, total); val != 0 {
+		t.Errorf("total = %d, want the program to stop before assigning it", val)
+	}
+}
@@ -0,0 +1,118 @@
+package base
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// getStdin returns the program's buffered standard input, which is shared by
+// all the read natives so no input is lost between calls
+func getStdin(prgrm *CXProgram) *bufio.Reader {
+	if prgrm.Stdin == nil {
+		prgrm.Stdin = bufio.NewReader(os.Stdin)
+	}
+	return prgrm.Stdin
+}
+
+// readToken skips leading white space and reads the next white space
+// separated token from `reader`. `eof` is true if the input ended before a
+// token was found
+func readToken(reader *bufio.Reader) (token string, eof bool) {
+	var byts []byte
+	for {
+		ch, err := reader.ReadByte()
+		if err != nil {
+			return string(byts), len(byts) == 0
+		}
+		if ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' {
+			if len(byts) > 0 {
+				return string(byts), false
+			}
+			continue
+		}
+		byts = append(byts, ch)
+	}
+}
+
+// writeReadResult writes the value read to the first output and, if the
+// caller is receiving them, the end of input flag and the error flag to the
+// second and third outputs
+func writeReadResult(expr *CXExpression, stack *CXStack, fp int, outB1 []byte, eof bool, err error) {
+	out1 := expr.Outputs[0]
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(eof))
+	writeOptionalOutput(expr, stack, fp, 2, FromBool(err != nil && !eof))
+}
+
+// op_read_line. The line built-in function reads the next line of the standard
+// input, without its line break. The second output is true if the input ended
+// before a line could be read
+func op_read_line(expr *CXExpression, stack *CXStack, fp int) {
+	out1 := expr.Outputs[0]
+	line, err := getStdin(stack.Program).ReadString('\n')
+	eof := err == io.EOF && line == ""
+
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+
+	WriteString(stack, fp, out1, line)
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(eof))
+}
+
+// op_read_all. The all built-in function reads the standard input until it
+// ends
+func op_read_all(expr *CXExpression, stack *CXStack, fp int) {
+	out1 := expr.Outputs[0]
+	byts, _ := ioutil.ReadAll(getStdin(stack.Program))
+	WriteString(stack, fp, out1, string(byts))
+}
+
+// op_read_str. The str built-in function reads the next white space separated
+// word of the standard input. The second output is true if the input ended
+// before a word could be read
+func op_read_str(expr *CXExpression, stack *CXStack, fp int) {
+	out1 := expr.Outputs[0]
+	token, eof := readToken(getStdin(stack.Program))
+	WriteString(stack, fp, out1, token)
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(eof))
+}
+
+// op_read_i32. The i32 built-in function reads the next word of the standard
+// input as a base 10 i32. The second output is true if the input ended and the
+// third one is true if the word couldn't be parsed
+func op_read_i32(expr *CXExpression, stack *CXStack, fp int) {
+	token, eof := readToken(getStdin(stack.Program))
+	out, err := strconv.ParseInt(token, 10, 32)
+	writeReadResult(expr, stack, fp, FromI32(int32(out)), eof, err)
+}
+
+// op_read_i64. The i64 built-in function reads the next word of the standard
+// input as a base 10 i64. The second output is true if the input ended and the
+// third one is true if the word couldn't be parsed
+func op_read_i64(expr *CXExpression, stack *CXStack, fp int) {
+	token, eof := readToken(getStdin(stack.Program))
+	out, err := strconv.ParseInt(token, 10, 64)
+	writeReadResult(expr, stack, fp, FromI64(out), eof, err)
+}
+
+// op_read_f32. The f32 built-in function reads the next word of the standard
+// input as an f32. The second output is true if the input ended and the third
+// one is true if the word couldn't be parsed
+func op_read_f32(expr *CXExpression, stack *CXStack, fp int) {
+	token, eof := readToken(getStdin(stack.Program))
+	out, err := strconv.ParseFloat(token, 32)
+	writeReadResult(expr, stack, fp, FromF32(float32(out)), eof, err)
+}
+
+// op_read_f64. The f64 built-in function reads the next word of the standard
+// input as an f64. The second output is true if the input ended and the third
+// one is true if the word couldn't be parsed
+func op_read_f64(expr *CXExpression, stack *CXStack, fp int) {
+	token, eof := readToken(getStdin(stack.Program))
+	out, err := strconv.ParseFloat(token, 64)
+	writeReadResult(expr, stack, fp, FromF64(out), eof, err)
+}
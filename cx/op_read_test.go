@@ -0,0 +1,82 @@
+package base
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	prgrm, _ := makeTestProgram()
+	prgrm.Stdin = bufio.NewReader(strings.NewReader("first line\nword 12 34\n1.5 2.25 x\n"))
+	stack := &prgrm.Stacks[0]
+
+	word := testLocal("word", TYPE_STR, 0)
+	n := testLocal("n", TYPE_I32, 4)
+	l := testLocal("l", TYPE_I64, 8)
+	f := testLocal("f", TYPE_F32, 16)
+	d := testLocal("d", TYPE_F64, 20)
+	eof := testLocal("eof", TYPE_BOOL, 28)
+	bad := testLocal("bad", TYPE_BOOL, 29)
+	stack.StackPointer = 30
+
+	// read calls the native `op` with the outputs `outs`
+	read := func(op int, outs ...*CXArgument) {
+		expr := MakeExpression(Natives[op], "read.cx", 1)
+		for _, out := range outs {
+			expr.AddOutput(out)
+		}
+		switch op {
+		case OP_READ_LINE:
+			op_read_line(expr, stack, 0)
+		case OP_READ_STR:
+			op_read_str(expr, stack, 0)
+		case OP_READ_I32:
+			op_read_i32(expr, stack, 0)
+		case OP_READ_I64:
+			op_read_i64(expr, stack, 0)
+		case OP_READ_F32:
+			op_read_f32(expr, stack, 0)
+		case OP_READ_F64:
+			op_read_f64(expr, stack, 0)
+		}
+	}
+
+	read(OP_READ_LINE, word, eof)
+	if val := ReadStr(stack, 0, word); val != "first line" || ReadBool(stack, 0, eof) {
+		t.Errorf("read.line() = %q, %v, want \"first line\", false", val, ReadBool(stack, 0, eof))
+	}
+	read(OP_READ_STR, word, eof)
+	if val := ReadStr(stack, 0, word); val != "word" {
+		t.Errorf("read.str() = %q, want \"word\"", val)
+	}
+	read(OP_READ_I32, n, eof, bad)
+	if val := ReadI32(stack, 0, n); val != 12 || ReadBool(stack, 0, bad) {
+		t.Errorf("read.i32() = %d, error %v, want 12 without an error", val, ReadBool(stack, 0, bad))
+	}
+	read(OP_READ_I64, l)
+	if val := ReadI64(stack, 0, l); val != 34 {
+		t.Errorf("read.i64() = %d, want 34", val)
+	}
+	read(OP_READ_F32, f)
+	if val := ReadF32(stack, 0, f); val != 1.5 {
+		t.Errorf("read.f32() = %v, want 1.5", val)
+	}
+	read(OP_READ_F64, d, eof)
+	if val := ReadF64(stack, 0, d); val != 2.25 || ReadBool(stack, 0, eof) {
+		t.Errorf("read.f64() = %v, %v, want 2.25, false", val, ReadBool(stack, 0, eof))
+	}
+
+	read(OP_READ_I32, n, eof, bad)
+	if !ReadBool(stack, 0, bad) || ReadBool(stack, 0, eof) {
+		t.Errorf("read.i32() of \"x\": error %v, end of input %v, want an error", ReadBool(stack, 0, bad), ReadBool(stack, 0, eof))
+	}
+	read(OP_READ_I32, n, eof, bad)
+	if !ReadBool(stack, 0, eof) || ReadBool(stack, 0, bad) {
+		t.Errorf("read.i32() at the end: end of input %v, error %v, want the end of input", ReadBool(stack, 0, eof), ReadBool(stack, 0, bad))
+	}
+	read(OP_READ_STR, word, eof)
+	if !ReadBool(stack, 0, eof) {
+		t.Errorf("read.str() at the end: want the end of input")
+	}
+}
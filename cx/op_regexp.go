@@ -0,0 +1,116 @@
+package base
+
+import (
+	"regexp"
+)
+
+// getRegexp returns the regular expression compiled with `handle`, or nil if
+// the handle is invalid
+func getRegexp(prgrm *CXProgram, handle int32) *regexp.Regexp {
+	if handle < 0 || int(handle) >= len(prgrm.Regexps) {
+		return nil
+	}
+	return prgrm.Regexps[handle]
+}
+
+// op_regexp_Compile. The Compile built-in function compiles a regular
+// expression with Go's RE2 syntax and returns its handle, or -1 if it's
+// invalid. Compiling the same pattern again returns the same handle. The
+// second output, if received, is the compilation error
+func op_regexp_Compile(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	prgrm := stack.Program
+	pattern := ReadStr(stack, fp, inp1)
+
+	var errStr string
+	handle, found := prgrm.RegexpHandles[pattern]
+	if !found {
+		re, err := regexp.Compile(pattern)
+		if err == nil {
+			if prgrm.RegexpHandles == nil {
+				prgrm.RegexpHandles = make(map[string]int32)
+			}
+			prgrm.Regexps = append(prgrm.Regexps, re)
+			handle = int32(len(prgrm.Regexps) - 1)
+			prgrm.RegexpHandles[pattern] = handle
+		} else {
+			handle = -1
+			errStr = err.Error()
+		}
+	}
+
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromI32(handle))
+	if len(expr.Outputs) > 1 {
+		WriteString(stack, fp, expr.Outputs[1], errStr)
+	}
+}
+
+// op_regexp_MatchString. The MatchString built-in function reports whether
+// `s` contains a match of the regular expression `re`
+func op_regexp_MatchString(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	var match bool
+	if re := getRegexp(stack.Program, ReadI32(stack, fp, inp1)); re != nil {
+		match = re.MatchString(ReadStr(stack, fp, inp2))
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromBool(match))
+}
+
+// op_regexp_FindString. The FindString built-in function returns the leftmost
+// match of the regular expression `re` in `s`. The second output, if
+// received, reports whether there was a match, as the match can be empty
+func op_regexp_FindString(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	var match string
+	var found bool
+	if re := getRegexp(stack.Program, ReadI32(stack, fp, inp1)); re != nil {
+		s := ReadStr(stack, fp, inp2)
+		if loc := re.FindStringIndex(s); loc != nil {
+			match, found = s[loc[0]:loc[1]], true
+		}
+	}
+	WriteString(stack, fp, out1, match)
+	writeOptionalOutput(expr, stack, fp, 1, FromBool(found))
+}
+
+// op_regexp_FindAllString. The FindAllString built-in function writes the
+// successive matches of the regular expression `re` in `s` to the []str
+// output, at most `n` of them if `n` isn't negative. As slices hold at most
+// SLICE_SIZE elements, further matches are dropped. The second output, if
+// received, is the number of matches written
+func op_regexp_FindAllString(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3, out1 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2], expr.Outputs[0]
+	var matches []string
+	if re := getRegexp(stack.Program, ReadI32(stack, fp, inp1)); re != nil {
+		matches = re.FindAllString(ReadStr(stack, fp, inp2), int(ReadI32(stack, fp, inp3)))
+	}
+	n := writeStrSlice(stack, fp, out1, matches)
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+}
+
+// op_regexp_FindStringSubmatch. The FindStringSubmatch built-in function
+// writes the leftmost match of the regular expression `re` in `s` and the
+// matches of its groups to the []str output. Groups that didn't match are
+// empty strings. The second output, if received, is the number of strings
+// written, which is 0 if there was no match
+func op_regexp_FindStringSubmatch(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	var matches []string
+	if re := getRegexp(stack.Program, ReadI32(stack, fp, inp1)); re != nil {
+		matches = re.FindStringSubmatch(ReadStr(stack, fp, inp2))
+	}
+	n := writeStrSlice(stack, fp, out1, matches)
+	writeOptionalOutput(expr, stack, fp, 1, FromI32(int32(n)))
+}
+
+// op_regexp_ReplaceAllString. The ReplaceAllString built-in function returns
+// a copy of `s` with the matches of the regular expression `re` replaced by
+// `repl`, where $1 or ${name} stand for the text matched by a group
+func op_regexp_ReplaceAllString(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3, out1 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2], expr.Outputs[0]
+	s := ReadStr(stack, fp, inp2)
+	if re := getRegexp(stack.Program, ReadI32(stack, fp, inp1)); re != nil {
+		s = re.ReplaceAllString(s, ReadStr(stack, fp, inp3))
+	}
+	WriteString(stack, fp, out1, s)
+}
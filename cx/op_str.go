@@ -2,6 +2,7 @@ package base
 
 import (
 	"fmt"
+	"strconv"
 )
 
 // func op_str_str(expr *CXExpression, stack *CXStack, fp int) {
@@ -33,3 +34,73 @@ func op_str_eq(expr *CXExpression, stack *CXStack, fp int) {
 	outB1 := FromBool(ReadStr(stack, fp, inp1) == ReadStr(stack, fp, inp2))
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
 }
+
+// writeParseResult writes the parsed value to the first output and, if the
+// caller is receiving it, the error flag to the second output
+func writeParseResult(expr *CXExpression, stack *CXStack, fp int, outB1 []byte, err error) {
+	out1 := expr.Outputs[0]
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+	if len(expr.Outputs) > 1 {
+		out2 := expr.Outputs[1]
+		WriteMemory(stack, GetFinalOffset(stack, fp, out2, MEM_WRITE), out2, FromBool(err != nil))
+	}
+}
+
+// digits after the decimal point of the smallest f64, 2^-1074, so every float
+// can be formatted exactly, but a precision can't exhaust the heap
+const FORMAT_MAX_PRECISION = 1074
+
+// writeNumberFormat writes the number formatted by `format` with the base or
+// precision read from the second input. If it isn't between `min` and `max`
+// the string is empty and the error flag, if the caller is receiving it, is
+// true
+func writeNumberFormat(expr *CXExpression, stack *CXStack, fp int, min, max int, format func(y int) string) {
+	y := int(ReadI32(stack, fp, expr.Inputs[1]))
+	invalid := y < min || y > max
+
+	var out string
+	if !invalid {
+		out = format(y)
+	}
+	WriteString(stack, fp, expr.Outputs[0], out)
+	if len(expr.Outputs) > 1 {
+		out2 := expr.Outputs[1]
+		WriteMemory(stack, GetFinalOffset(stack, fp, out2, MEM_WRITE), out2, FromBool(invalid))
+	}
+}
+
+// op_str_i32. The i32 built-in function parses a string as a base 10 i32.
+// The second output is true if the string couldn't be parsed
+func op_str_i32(expr *CXExpression, stack *CXStack, fp int) {
+	out, err := strconv.ParseInt(ReadStr(stack, fp, expr.Inputs[0]), 10, 32)
+	writeParseResult(expr, stack, fp, FromI32(int32(out)), err)
+}
+
+// op_str_i64. The i64 built-in function parses a string as a base 10 i64.
+// The second output is true if the string couldn't be parsed
+func op_str_i64(expr *CXExpression, stack *CXStack, fp int) {
+	out, err := strconv.ParseInt(ReadStr(stack, fp, expr.Inputs[0]), 10, 64)
+	writeParseResult(expr, stack, fp, FromI64(out), err)
+}
+
+// op_str_f32. The f32 built-in function parses a string as an f32.
+// The second output is true if the string couldn't be parsed
+func op_str_f32(expr *CXExpression, stack *CXStack, fp int) {
+	out, err := strconv.ParseFloat(ReadStr(stack, fp, expr.Inputs[0]), 32)
+	writeParseResult(expr, stack, fp, FromF32(float32(out)), err)
+}
+
+// op_str_f64. The f64 built-in function parses a string as an f64.
+// The second output is true if the string couldn't be parsed
+func op_str_f64(expr *CXExpression, stack *CXStack, fp int) {
+	out, err := strconv.ParseFloat(ReadStr(stack, fp, expr.Inputs[0]), 64)
+	writeParseResult(expr, stack, fp, FromF64(out), err)
+}
+
+// op_str_bool. The bool built-in function parses a string as a bool. It
+// accepts the same values as Go's strconv.ParseBool. The second output is
+// true if the string couldn't be parsed
+func op_str_bool(expr *CXExpression, stack *CXStack, fp int) {
+	out, err := strconv.ParseBool(ReadStr(stack, fp, expr.Inputs[0]))
+	writeParseResult(expr, stack, fp, FromBool(out), err)
+}
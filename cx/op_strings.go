@@ -0,0 +1,229 @@
+package base
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+// readStrFromHeap reads the string object stored at `heapOffset`
+func readStrFromHeap(prgrm *CXProgram, heapOffset int32) (out string) {
+	var size int32
+	off := heapOffset + OBJECT_HEADER_SIZE
+	encoder.DeserializeAtomic(prgrm.Heap.Heap[off:off+STR_HEADER_SIZE], &size)
+	encoder.DeserializeRaw(prgrm.Heap.Heap[off:off+STR_HEADER_SIZE+size], &out)
+	return
+}
+
+// readStrSlice reads the strings pointed by the elements of a []str. A null
+// pointer marks the end of the slice's used elements
+func readStrSlice(stack *CXStack, fp int, inp *CXArgument) (out []string) {
+	offset := GetFinalOffset(stack, fp, inp, MEM_READ)
+	byts := ReadMemory(stack, offset, inp)
+
+	for c := 0; c+TYPE_POINTER_SIZE <= len(byts); c += TYPE_POINTER_SIZE {
+		var heapOffset int32
+		encoder.DeserializeAtomic(byts[c:c+TYPE_POINTER_SIZE], &heapOffset)
+		if heapOffset == NULL_HEAP_ADDRESS {
+			break
+		}
+		out = append(out, readStrFromHeap(stack.Program, heapOffset))
+	}
+
+	return
+}
+
+// writeStrSlice writes `strs` to the []str output `out`, setting its unused
+// elements to null. It returns the number of strings written, which is
+// limited by the length of `out`
+func writeStrSlice(stack *CXStack, fp int, out *CXArgument, strs []string) int {
+	outOffset := GetFinalOffset(stack, fp, out, MEM_WRITE)
+
+	n := out.TotalSize / TYPE_POINTER_SIZE
+	if len(strs) < n {
+		n = len(strs)
+	}
+
+	for c := 0; c < out.TotalSize/TYPE_POINTER_SIZE; c++ {
+		var heapOffset int
+		if c < n {
+			heapOffset = WriteObject(stack.Program, encoder.Serialize(strs[c]))
+		} else {
+			heapOffset = NULL_HEAP_ADDRESS
+		}
+		WriteMemory(stack, outOffset+c*TYPE_POINTER_SIZE, out, encoder.SerializeAtomic(int32(heapOffset)))
+	}
+
+	return n
+}
+
+// clampIndex keeps a byte index of a string within [0, len(str)]
+func clampIndex(idx int32, str string) int {
+	if idx < 0 {
+		return 0
+	}
+	if int(idx) > len(str) {
+		return len(str)
+	}
+	return int(idx)
+}
+
+// op_strings_Index. The Index built-in function returns the byte index of the
+// first instance of `sub` in `s`, or -1 if it isn't present
+func op_strings_Index(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromI32(int32(strings.Index(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2))))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_strings_LastIndex. The LastIndex built-in function returns the byte
+// index of the last instance of `sub` in `s`, or -1 if it isn't present
+func op_strings_LastIndex(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromI32(int32(strings.LastIndex(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2))))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_strings_Contains. The Contains built-in function reports whether `sub`
+// is within `s`
+func op_strings_Contains(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(strings.Contains(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2)))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_strings_HasPrefix. The HasPrefix built-in function reports whether `s`
+// begins with `prefix`
+func op_strings_HasPrefix(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(strings.HasPrefix(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2)))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_strings_HasSuffix. The HasSuffix built-in function reports whether `s`
+// ends with `suffix`
+func op_strings_HasSuffix(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(strings.HasSuffix(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2)))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_strings_Split. The Split built-in function slices `s` into the substrings
+// separated by `sep` and writes them to the []str output. As slices hold at
+// most SLICE_SIZE elements, the last element holds the unsplit remainder. The
+// second output, if received, is the number of substrings written
+func op_strings_Split(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	parts := strings.SplitN(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2), out1.TotalSize/TYPE_POINTER_SIZE)
+	n := writeStrSlice(stack, fp, out1, parts)
+
+	if len(expr.Outputs) > 1 {
+		out2 := expr.Outputs[1]
+		WriteMemory(stack, GetFinalOffset(stack, fp, out2, MEM_WRITE), out2, FromI32(int32(n)))
+	}
+}
+
+// op_strings_Join. The Join built-in function concatenates the elements of a
+// []str, placing `sep` between them. Unassigned elements are ignored
+func op_strings_Join(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	WriteString(stack, fp, out1, strings.Join(readStrSlice(stack, fp, inp1), ReadStr(stack, fp, inp2)))
+}
+
+// op_strings_Substring. The Substring built-in function returns the bytes of
+// `s` in the range [start, end). Both indexes are clamped to the string's bounds
+func op_strings_Substring(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3, out1 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2], expr.Outputs[0]
+	str := ReadStr(stack, fp, inp1)
+	start := clampIndex(ReadI32(stack, fp, inp2), str)
+	end := clampIndex(ReadI32(stack, fp, inp3), str)
+	if end < start {
+		end = start
+	}
+	WriteString(stack, fp, out1, str[start:end])
+}
+
+// op_strings_Replace. The Replace built-in function returns a copy of `s` with
+// every instance of `old` replaced by `new`
+func op_strings_Replace(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, inp3, out1 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2], expr.Outputs[0]
+	WriteString(stack, fp, out1, strings.Replace(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2), ReadStr(stack, fp, inp3), -1))
+}
+
+// op_strings_Trim. The Trim built-in function returns `s` without the leading
+// and trailing characters contained in `cutset`
+func op_strings_Trim(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	WriteString(stack, fp, out1, strings.Trim(ReadStr(stack, fp, inp1), ReadStr(stack, fp, inp2)))
+}
+
+// op_strings_TrimSpace. The TrimSpace built-in function returns `s` without
+// leading and trailing white space
+func op_strings_TrimSpace(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	WriteString(stack, fp, out1, strings.TrimSpace(ReadStr(stack, fp, inp1)))
+}
+
+// op_strings_ToUpper. The ToUpper built-in function returns `s` with all its
+// letters mapped to upper case
+func op_strings_ToUpper(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	WriteString(stack, fp, out1, strings.ToUpper(ReadStr(stack, fp, inp1)))
+}
+
+// op_strings_ToLower. The ToLower built-in function returns `s` with all its
+// letters mapped to lower case
+func op_strings_ToLower(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	WriteString(stack, fp, out1, strings.ToLower(ReadStr(stack, fp, inp1)))
+}
+
+// op_strings_Repeat. The Repeat built-in function returns `s` repeated `count`
+// times. A negative count returns an empty string
+func op_strings_Repeat(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	count := ReadI32(stack, fp, inp2)
+	if count < 0 {
+		count = 0
+	}
+	WriteString(stack, fp, out1, strings.Repeat(ReadStr(stack, fp, inp1), int(count)))
+}
+
+// op_strings_RuneCount. The RuneCount built-in function returns the number of
+// UTF-8 encoded runes in `s`
+func op_strings_RuneCount(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	outB1 := FromI32(int32(utf8.RuneCountInString(ReadStr(stack, fp, inp1))))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_strings_DecodeRune. The DecodeRune built-in function decodes the UTF-8
+// rune starting at byte index `i` of `s`. It returns the rune and, if
+// received, its width in bytes, so a string can be iterated by adding the width
+// to `i`. An invalid encoding returns the replacement rune and a width of 1;
+// an index out of bounds returns 0 and a width of 0
+func op_strings_DecodeRune(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	str := ReadStr(stack, fp, inp1)
+	idx := ReadI32(stack, fp, inp2)
+
+	var r rune
+	var size int
+	if idx >= 0 && int(idx) < len(str) {
+		r, size = utf8.DecodeRuneInString(str[idx:])
+	}
+
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromI32(int32(r)))
+	if len(expr.Outputs) > 1 {
+		out2 := expr.Outputs[1]
+		WriteMemory(stack, GetFinalOffset(stack, fp, out2, MEM_WRITE), out2, FromI32(int32(size)))
+	}
+}
+
+// op_strings_EncodeRune. The EncodeRune built-in function returns the UTF-8
+// encoding of rune `r` as a string
+func op_strings_EncodeRune(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	WriteString(stack, fp, out1, string(rune(ReadI32(stack, fp, inp1))))
+}
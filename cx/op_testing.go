@@ -1,15 +1,20 @@
 package base
 
 import (
+	"bytes"
 	"fmt"
 	// "github.com/skycoin/skycoin/src/cipher/encoder"
 )
 
+// op_assert_value. The assert built-in function checks that its first two
+// inputs are equal. A failure is recorded in prgrm.TestFailures along with
+// both values
 func op_assert_value(expr *CXExpression, stack *CXStack, fp int) {
 	inp1, inp2, inp3 := expr.Inputs[0], expr.Inputs[1], expr.Inputs[2]
+	prgrm := stack.Program
 
 	var byts1, byts2 []byte
-	
+
 	if inp1.Type == TYPE_STR {
 		byts1 = []byte(ReadStr(stack, fp, inp1))
 		byts2 = []byte(ReadStr(stack, fp, inp2))
@@ -18,40 +23,41 @@ func op_assert_value(expr *CXExpression, stack *CXStack, fp int) {
 		byts2 = ReadMemory(stack, GetFinalOffset(stack, fp, inp2, MEM_READ), inp2)
 	}
 
-	var same bool
-	same = true
+	if bytes.Equal(byts1, byts2) {
+		return
+	}
 
-	// fmt.Println("byts1", inp1.Type, byts1, inp1.Name)
-	// fmt.Println("byts2", inp2.Type, byts2, inp2.Name)
+	message := fmt.Sprintf("result was not equal to the expected value (got %s, want %s)",
+		FormatValue(stack, fp, inp1, false, true), FormatValue(stack, fp, inp2, false, true))
+	if msg := ReadStr(stack, fp, inp3); msg != "" {
+		message += "; " + msg
+	}
+	prgrm.addTestFailure(expr.FileName, expr.FileLine, message)
+}
 
-	// fmt.Println("byts1", inp1.Type, byts1, inp1.Name, inp1.MemoryRead, inp1.MemoryWrite)
-	// 	fmt.Println("byts2", inp2.Type, byts2, inp2.Name, inp2.MemoryRead, inp2.MemoryWrite)
-	
-	if len(byts1) != len(byts2) {
-		same = false
-		fmt.Println("byts1", inp1.Type, byts1, inp1.Name, inp1.MemoryRead, inp1.MemoryWrite)
-		fmt.Println("byts2", inp2.Type, byts2, inp2.Name, inp2.MemoryRead, inp2.MemoryWrite)
-	} else {
-		for i, byt := range byts1 {
-			if byt != byts2[i] {
-				same = false
-				fmt.Println("byts1", inp1.Type, byts1, inp1.Name, inp1.MemoryRead, inp1.MemoryWrite)
-				fmt.Println("byts2", inp2.Type, byts2, inp2.Name, inp2.MemoryRead, inp2.MemoryWrite)
-			}
-		}
+// op_test_value. test.value checks its first two inputs as assert does, and
+// also that they are of the same type
+func op_test_value(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2 := expr.Inputs[0], expr.Inputs[1]
+	if inp1.Type != inp2.Type {
+		stack.Program.addTestFailure(expr.FileName, expr.FileLine, "result and expected value are not of the same type")
+		return
 	}
-	
 
-	var message string
-	message = ReadStr(stack, fp, inp3)
+	op_assert_value(expr, stack, fp)
+}
 
-	if !same {
-		if message != "" {
-			fmt.Printf("%s: %d: result was not equal to the expected value; %s\n", expr.FileName, expr.FileLine, message)
-		} else {
-			fmt.Printf("%s: %d: result was not equal to the expected value\n", expr.FileName, expr.FileLine)
-		}
-	} else {
+// op_test_error. test.error records a failure if no runtime error was raised
+// since test.start or the previous test.error
+func op_test_error(expr *CXExpression, stack *CXStack, fp int) {
+	if isErrorPresent {
+		isErrorPresent = false
+		return
+	}
 
+	message := ReadStr(stack, fp, expr.Inputs[0])
+	if message == "" {
+		message = "an error was expected and did not occur"
 	}
+	stack.Program.addTestFailure(expr.FileName, expr.FileLine, message)
 }
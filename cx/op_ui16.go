@@ -0,0 +1,190 @@
+package base
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func op_ui16_ui16(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	out1Offset := GetFinalOffset(stack, fp, out1, MEM_WRITE)
+
+	switch out1.Type {
+	case TYPE_BYTE:
+		WriteMemory(stack, out1Offset, out1, FromByte(byte(ReadUI16(stack, fp, inp1))))
+	case TYPE_STR:
+		WriteString(stack, fp, out1, strconv.FormatUint(uint64(ReadUI16(stack, fp, inp1)), 10))
+	case TYPE_I8:
+		WriteMemory(stack, out1Offset, out1, FromI8(int8(ReadUI16(stack, fp, inp1))))
+	case TYPE_I16:
+		WriteMemory(stack, out1Offset, out1, FromI16(int16(ReadUI16(stack, fp, inp1))))
+	case TYPE_I32:
+		WriteMemory(stack, out1Offset, out1, FromI32(int32(ReadUI16(stack, fp, inp1))))
+	case TYPE_I64:
+		WriteMemory(stack, out1Offset, out1, FromI64(int64(ReadUI16(stack, fp, inp1))))
+	case TYPE_UI8:
+		WriteMemory(stack, out1Offset, out1, FromUI8(uint8(ReadUI16(stack, fp, inp1))))
+	case TYPE_UI16:
+		WriteMemory(stack, out1Offset, out1, FromUI16(uint16(ReadUI16(stack, fp, inp1))))
+	case TYPE_UI32:
+		WriteMemory(stack, out1Offset, out1, FromUI32(uint32(ReadUI16(stack, fp, inp1))))
+	case TYPE_UI64:
+		WriteMemory(stack, out1Offset, out1, FromUI64(uint64(ReadUI16(stack, fp, inp1))))
+	case TYPE_F32:
+		WriteMemory(stack, out1Offset, out1, FromF32(float32(ReadUI16(stack, fp, inp1))))
+	case TYPE_F64:
+		WriteMemory(stack, out1Offset, out1, FromF64(float64(ReadUI16(stack, fp, inp1))))
+	}
+}
+
+// op_ui16_print. The print built-in function formats its arguments in an
+// implementation-specific
+func op_ui16_print(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	fmt.Println(ReadUI16(stack, fp, inp1))
+}
+
+// op_ui16_add. The add built-in function returns the add of two numbers
+func op_ui16_add(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) + ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_sub. The sub built-in function returns the substract of two numbers
+func op_ui16_sub(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) - ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_mul. The mul built-in function returns the multiplication of two numbers
+func op_ui16_mul(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) * ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_div. The div built-in function returns the divides two numbers
+func op_ui16_div(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) / ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_mod. The mod built-in function returns the remainder of the division of two numbers
+func op_ui16_mod(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) % ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_gt. The gt built-in function returns true if x number is greater than a y number
+func op_ui16_gt(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI16(stack, fp, inp1) > ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_gteq. The gteq built-in function returns true if x number is greater or
+// equal than a y number
+func op_ui16_gteq(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI16(stack, fp, inp1) >= ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_lt. The lt built-in function returns true if x number is less then
+func op_ui16_lt(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI16(stack, fp, inp1) < ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_lteq. The lteq built-in function returns true if x number is less or
+// equal than a y number
+func op_ui16_lteq(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI16(stack, fp, inp1) <= ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_eq. The eq built-in function returns true if x number is equal to the y number
+func op_ui16_eq(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI16(stack, fp, inp1) == ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_uneq. The uneq built-in function returns true if x number is diferent to the y number
+func op_ui16_uneq(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI16(stack, fp, inp1) != ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui16_bitand(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) & ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui16_bitor(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) | ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui16_bitxor(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) ^ ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui16_bitclear(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) &^ ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui16_bitshl(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) << ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui16_bitshr(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI16(ReadUI16(stack, fp, inp1) >> ReadUI16(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui16_max. The max built-in function returns the max value between x and y numbers
+func op_ui16_max(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	in1, in2 := ReadUI16(stack, fp, inp1), ReadUI16(stack, fp, inp2)
+	if in2 > in1 {
+		in1 = in2
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromUI16(in1))
+}
+
+// op_ui16_min. The min built-in function returns the min value between x and y numbers
+func op_ui16_min(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	in1, in2 := ReadUI16(stack, fp, inp1), ReadUI16(stack, fp, inp2)
+	if in2 < in1 {
+		in1 = in2
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromUI16(in1))
+}
+
+// op_ui16_format. The format built-in function returns the string
+// representation of x in base y. The second output is true if y isn't
+// between 2 and 36
+func op_ui16_format(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	writeNumberFormat(expr, stack, fp, 2, 36, func(base int) string {
+		return strconv.FormatUint(uint64(ReadUI16(stack, fp, inp1)), base)
+	})
+}
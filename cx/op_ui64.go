@@ -0,0 +1,190 @@
+package base
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func op_ui64_ui64(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
+	out1Offset := GetFinalOffset(stack, fp, out1, MEM_WRITE)
+
+	switch out1.Type {
+	case TYPE_BYTE:
+		WriteMemory(stack, out1Offset, out1, FromByte(byte(ReadUI64(stack, fp, inp1))))
+	case TYPE_STR:
+		WriteString(stack, fp, out1, strconv.FormatUint(ReadUI64(stack, fp, inp1), 10))
+	case TYPE_I8:
+		WriteMemory(stack, out1Offset, out1, FromI8(int8(ReadUI64(stack, fp, inp1))))
+	case TYPE_I16:
+		WriteMemory(stack, out1Offset, out1, FromI16(int16(ReadUI64(stack, fp, inp1))))
+	case TYPE_I32:
+		WriteMemory(stack, out1Offset, out1, FromI32(int32(ReadUI64(stack, fp, inp1))))
+	case TYPE_I64:
+		WriteMemory(stack, out1Offset, out1, FromI64(int64(ReadUI64(stack, fp, inp1))))
+	case TYPE_UI8:
+		WriteMemory(stack, out1Offset, out1, FromUI8(uint8(ReadUI64(stack, fp, inp1))))
+	case TYPE_UI16:
+		WriteMemory(stack, out1Offset, out1, FromUI16(uint16(ReadUI64(stack, fp, inp1))))
+	case TYPE_UI32:
+		WriteMemory(stack, out1Offset, out1, FromUI32(uint32(ReadUI64(stack, fp, inp1))))
+	case TYPE_UI64:
+		WriteMemory(stack, out1Offset, out1, FromUI64(uint64(ReadUI64(stack, fp, inp1))))
+	case TYPE_F32:
+		WriteMemory(stack, out1Offset, out1, FromF32(float32(ReadUI64(stack, fp, inp1))))
+	case TYPE_F64:
+		WriteMemory(stack, out1Offset, out1, FromF64(float64(ReadUI64(stack, fp, inp1))))
+	}
+}
+
+// op_ui64_print. The print built-in function formats its arguments in an
+// implementation-specific
+func op_ui64_print(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	fmt.Println(ReadUI64(stack, fp, inp1))
+}
+
+// op_ui64_add. The add built-in function returns the add of two numbers
+func op_ui64_add(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) + ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_sub. The sub built-in function returns the substract of two numbers
+func op_ui64_sub(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) - ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_mul. The mul built-in function returns the multiplication of two numbers
+func op_ui64_mul(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) * ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_div. The div built-in function returns the divides two numbers
+func op_ui64_div(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) / ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_mod. The mod built-in function returns the remainder of the division of two numbers
+func op_ui64_mod(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) % ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_gt. The gt built-in function returns true if x number is greater than a y number
+func op_ui64_gt(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI64(stack, fp, inp1) > ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_gteq. The gteq built-in function returns true if x number is greater or
+// equal than a y number
+func op_ui64_gteq(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI64(stack, fp, inp1) >= ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_lt. The lt built-in function returns true if x number is less then
+func op_ui64_lt(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI64(stack, fp, inp1) < ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_lteq. The lteq built-in function returns true if x number is less or
+// equal than a y number
+func op_ui64_lteq(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI64(stack, fp, inp1) <= ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_eq. The eq built-in function returns true if x number is equal to the y number
+func op_ui64_eq(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI64(stack, fp, inp1) == ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_uneq. The uneq built-in function returns true if x number is diferent to the y number
+func op_ui64_uneq(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromBool(ReadUI64(stack, fp, inp1) != ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui64_bitand(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) & ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui64_bitor(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) | ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui64_bitxor(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) ^ ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui64_bitclear(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) &^ ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui64_bitshl(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) << ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+func op_ui64_bitshr(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	outB1 := FromUI64(ReadUI64(stack, fp, inp1) >> ReadUI64(stack, fp, inp2))
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+}
+
+// op_ui64_max. The max built-in function returns the max value between x and y numbers
+func op_ui64_max(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	in1, in2 := ReadUI64(stack, fp, inp1), ReadUI64(stack, fp, inp2)
+	if in2 > in1 {
+		in1 = in2
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromUI64(in1))
+}
+
+// op_ui64_min. The min built-in function returns the min value between x and y numbers
+func op_ui64_min(expr *CXExpression, stack *CXStack, fp int) {
+	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
+	in1, in2 := ReadUI64(stack, fp, inp1), ReadUI64(stack, fp, inp2)
+	if in2 < in1 {
+		in1 = in2
+	}
+	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, FromUI64(in1))
+}
+
+// op_ui64_format. The format built-in function returns the string
+// representation of x in base y. The second output is true if y isn't
+// between 2 and 36
+func op_ui64_format(expr *CXExpression, stack *CXStack, fp int) {
+	inp1 := expr.Inputs[0]
+	writeNumberFormat(expr, stack, fp, 2, 36, func(base int) string {
+		return strconv.FormatUint(uint64(ReadUI64(stack, fp, inp1)), base)
+	})
+}
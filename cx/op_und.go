@@ -1,7 +1,6 @@
 package base
 
 import (
-	"strconv"
 	"fmt"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
 )
@@ -14,6 +13,18 @@ func op_lt(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromBool(ReadI32(stack, fp, inp1) < ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromBool(ReadI64(stack, fp, inp1) < ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromBool(ReadI8(stack, fp, inp1) < ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromBool(ReadI16(stack, fp, inp1) < ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromBool(ReadUI8(stack, fp, inp1) < ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromBool(ReadUI16(stack, fp, inp1) < ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromBool(ReadUI32(stack, fp, inp1) < ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromBool(ReadUI64(stack, fp, inp1) < ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromBool(ReadF32(stack, fp, inp1) < ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -31,6 +42,18 @@ func op_gt(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromBool(ReadI32(stack, fp, inp1) > ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromBool(ReadI64(stack, fp, inp1) > ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromBool(ReadI8(stack, fp, inp1) > ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromBool(ReadI16(stack, fp, inp1) > ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromBool(ReadUI8(stack, fp, inp1) > ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromBool(ReadUI16(stack, fp, inp1) > ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromBool(ReadUI32(stack, fp, inp1) > ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromBool(ReadUI64(stack, fp, inp1) > ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromBool(ReadF32(stack, fp, inp1) > ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -47,6 +70,18 @@ func op_lteq(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromBool(ReadI32(stack, fp, inp1) <= ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromBool(ReadI64(stack, fp, inp1) <= ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromBool(ReadI8(stack, fp, inp1) <= ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromBool(ReadI16(stack, fp, inp1) <= ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromBool(ReadUI8(stack, fp, inp1) <= ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromBool(ReadUI16(stack, fp, inp1) <= ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromBool(ReadUI32(stack, fp, inp1) <= ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromBool(ReadUI64(stack, fp, inp1) <= ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromBool(ReadF32(stack, fp, inp1) <= ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -64,6 +99,18 @@ func op_gteq(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromBool(ReadI32(stack, fp, inp1) >= ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromBool(ReadI64(stack, fp, inp1) >= ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromBool(ReadI8(stack, fp, inp1) >= ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromBool(ReadI16(stack, fp, inp1) >= ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromBool(ReadUI8(stack, fp, inp1) >= ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromBool(ReadUI16(stack, fp, inp1) >= ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromBool(ReadUI32(stack, fp, inp1) >= ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromBool(ReadUI64(stack, fp, inp1) >= ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromBool(ReadF32(stack, fp, inp1) >= ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -76,6 +123,12 @@ func op_gteq(expr *CXExpression, stack *CXStack, fp int) {
 func op_equal(expr *CXExpression, stack *CXStack, fp int) {
 	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
 	var outB1 []byte
+	if isCompositeArg(inp1) {
+		// structs and arrays are compared byte by byte
+		outB1 = FromBool(compositeEqual(stack, fp, inp1, inp2))
+		WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+		return
+	}
 	switch inp1.Type {
 	case TYPE_BOOL:
 		outB1 = FromBool(ReadBool(stack, fp, inp1) == ReadBool(stack, fp, inp2))
@@ -85,6 +138,18 @@ func op_equal(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromBool(ReadI32(stack, fp, inp1) == ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromBool(ReadI64(stack, fp, inp1) == ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromBool(ReadI8(stack, fp, inp1) == ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromBool(ReadI16(stack, fp, inp1) == ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromBool(ReadUI8(stack, fp, inp1) == ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromBool(ReadUI16(stack, fp, inp1) == ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromBool(ReadUI32(stack, fp, inp1) == ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromBool(ReadUI64(stack, fp, inp1) == ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromBool(ReadF32(stack, fp, inp1) == ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -97,6 +162,12 @@ func op_equal(expr *CXExpression, stack *CXStack, fp int) {
 func op_unequal(expr *CXExpression, stack *CXStack, fp int) {
 	inp1, inp2, out1 := expr.Inputs[0], expr.Inputs[1], expr.Outputs[0]
 	var outB1 []byte
+	if isCompositeArg(inp1) {
+		// structs and arrays are compared byte by byte
+		outB1 = FromBool(!compositeEqual(stack, fp, inp1, inp2))
+		WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
+		return
+	}
 	switch inp1.Type {
 	case TYPE_BOOL:
 		outB1 = FromBool(ReadBool(stack, fp, inp1) != ReadBool(stack, fp, inp2))
@@ -104,6 +175,18 @@ func op_unequal(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromBool(ReadI32(stack, fp, inp1) != ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromBool(ReadI64(stack, fp, inp1) != ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromBool(ReadI8(stack, fp, inp1) != ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromBool(ReadI16(stack, fp, inp1) != ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromBool(ReadUI8(stack, fp, inp1) != ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromBool(ReadUI16(stack, fp, inp1) != ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromBool(ReadUI32(stack, fp, inp1) != ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromBool(ReadUI64(stack, fp, inp1) != ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromBool(ReadF32(stack, fp, inp1) != ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -121,6 +204,18 @@ func op_bitand(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(ReadI32(stack, fp, inp1) & ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromI64(ReadI64(stack, fp, inp1) & ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromI8(ReadI8(stack, fp, inp1) & ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromI16(ReadI16(stack, fp, inp1) & ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) & ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) & ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) & ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) & ReadUI64(stack, fp, inp2))
 	}
 
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
@@ -134,6 +229,18 @@ func op_bitor(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(ReadI32(stack, fp, inp1) | ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromI64(ReadI64(stack, fp, inp1) | ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromI8(ReadI8(stack, fp, inp1) | ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromI16(ReadI16(stack, fp, inp1) | ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) | ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) | ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) | ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) | ReadUI64(stack, fp, inp2))
 	}
 
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
@@ -147,6 +254,18 @@ func op_bitxor(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(ReadI32(stack, fp, inp1) ^ ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromI64(ReadI64(stack, fp, inp1) ^ ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromI8(ReadI8(stack, fp, inp1) ^ ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromI16(ReadI16(stack, fp, inp1) ^ ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) ^ ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) ^ ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) ^ ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) ^ ReadUI64(stack, fp, inp2))
 	}
 
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
@@ -160,6 +279,18 @@ func op_mul(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(ReadI32(stack, fp, inp1) * ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromI64(ReadI64(stack, fp, inp1) * ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromI8(ReadI8(stack, fp, inp1) * ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromI16(ReadI16(stack, fp, inp1) * ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) * ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) * ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) * ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) * ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromF32(ReadF32(stack, fp, inp1) * ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -177,6 +308,18 @@ func op_div(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(ReadI32(stack, fp, inp1) / ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromI64(ReadI64(stack, fp, inp1) / ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromI8(ReadI8(stack, fp, inp1) / ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromI16(ReadI16(stack, fp, inp1) / ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) / ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) / ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) / ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) / ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromF32(ReadF32(stack, fp, inp1) / ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -194,6 +337,18 @@ func op_mod(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(ReadI32(stack, fp, inp1) % ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromI64(ReadI64(stack, fp, inp1) % ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromI8(ReadI8(stack, fp, inp1) % ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromI16(ReadI16(stack, fp, inp1) % ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) % ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) % ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) % ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) % ReadUI64(stack, fp, inp2))
 	}
 
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
@@ -207,6 +362,18 @@ func op_add(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(ReadI32(stack, fp, inp1) + ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromI64(ReadI64(stack, fp, inp1) + ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromI8(ReadI8(stack, fp, inp1) + ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromI16(ReadI16(stack, fp, inp1) + ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) + ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) + ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) + ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) + ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromF32(ReadF32(stack, fp, inp1) + ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -224,6 +391,18 @@ func op_sub(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(ReadI32(stack, fp, inp1) - ReadI32(stack, fp, inp2))
 	case TYPE_I64:
 		outB1 = FromI64(ReadI64(stack, fp, inp1) - ReadI64(stack, fp, inp2))
+	case TYPE_I8:
+		outB1 = FromI8(ReadI8(stack, fp, inp1) - ReadI8(stack, fp, inp2))
+	case TYPE_I16:
+		outB1 = FromI16(ReadI16(stack, fp, inp1) - ReadI16(stack, fp, inp2))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) - ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) - ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) - ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) - ReadUI64(stack, fp, inp2))
 	case TYPE_F32:
 		outB1 = FromF32(ReadF32(stack, fp, inp1) - ReadF32(stack, fp, inp2))
 	case TYPE_F64:
@@ -241,6 +420,18 @@ func op_bitshl(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(int32(uint32(ReadI32(stack, fp, inp1)) << uint32(ReadI32(stack, fp, inp2))))
 	case TYPE_I64:
 		outB1 = FromI64(int64(uint64(ReadI64(stack, fp, inp1)) << uint64(ReadI64(stack, fp, inp2))))
+	case TYPE_I8:
+		outB1 = FromI8(int8(uint8(ReadI8(stack, fp, inp1)) << uint8(ReadI8(stack, fp, inp2))))
+	case TYPE_I16:
+		outB1 = FromI16(int16(uint16(ReadI16(stack, fp, inp1)) << uint16(ReadI16(stack, fp, inp2))))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) << ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) << ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) << ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) << ReadUI64(stack, fp, inp2))
 	}
 
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
@@ -254,6 +445,18 @@ func op_bitshr(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(int32(uint32(ReadI32(stack, fp, inp1)) >> uint32(ReadI32(stack, fp, inp2))))
 	case TYPE_I64:
 		outB1 = FromI64(int64(uint32(ReadI64(stack, fp, inp1)) >> uint32(ReadI64(stack, fp, inp2))))
+	case TYPE_I8:
+		outB1 = FromI8(int8(uint8(ReadI8(stack, fp, inp1)) >> uint8(ReadI8(stack, fp, inp2))))
+	case TYPE_I16:
+		outB1 = FromI16(int16(uint16(ReadI16(stack, fp, inp1)) >> uint16(ReadI16(stack, fp, inp2))))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) >> ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) >> ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) >> ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) >> ReadUI64(stack, fp, inp2))
 	}
 
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
@@ -267,6 +470,18 @@ func op_bitclear(expr *CXExpression, stack *CXStack, fp int) {
 		outB1 = FromI32(int32(uint32(ReadI32(stack, fp, inp1)) &^ uint32(ReadI32(stack, fp, inp2))))
 	case TYPE_I64:
 		outB1 = FromI64(int64(uint32(ReadI64(stack, fp, inp1)) &^ uint32(ReadI64(stack, fp, inp2))))
+	case TYPE_I8:
+		outB1 = FromI8(int8(uint8(ReadI8(stack, fp, inp1)) &^ uint8(ReadI8(stack, fp, inp2))))
+	case TYPE_I16:
+		outB1 = FromI16(int16(uint16(ReadI16(stack, fp, inp1)) &^ uint16(ReadI16(stack, fp, inp2))))
+	case TYPE_UI8:
+		outB1 = FromUI8(ReadUI8(stack, fp, inp1) &^ ReadUI8(stack, fp, inp2))
+	case TYPE_UI16:
+		outB1 = FromUI16(ReadUI16(stack, fp, inp1) &^ ReadUI16(stack, fp, inp2))
+	case TYPE_UI32:
+		outB1 = FromUI32(ReadUI32(stack, fp, inp1) &^ ReadUI32(stack, fp, inp2))
+	case TYPE_UI64:
+		outB1 = FromUI64(ReadUI64(stack, fp, inp1) &^ ReadUI64(stack, fp, inp2))
 	}
 
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
@@ -274,7 +489,19 @@ func op_bitclear(expr *CXExpression, stack *CXStack, fp int) {
 
 func op_len(expr *CXExpression, stack *CXStack, fp int) {
 	inp1, out1 := expr.Inputs[0], expr.Outputs[0]
-	outB1 := FromI32(int32(inp1.Lengths[len(inp1.Lengths)-1]))
+
+	var outB1 []byte
+	if inp1.IsVariadic && len(inp1.Indexes) == 0 {
+		// the number of packed arguments is received right after the slice
+		offset := GetFinalOffset(stack, fp, inp1, MEM_READ) + inp1.TotalSize
+		outB1 = stack.Stack[offset : offset+GetArgSize(TYPE_I32)]
+	} else if inp1.Type == TYPE_STR && len(inp1.Lengths) == 0 {
+		// strings' lengths are only known at runtime
+		outB1 = FromI32(int32(len(ReadStr(stack, fp, inp1))))
+	} else {
+		outB1 = FromI32(int32(inp1.Lengths[len(inp1.Lengths)-1]))
+	}
+
 	WriteMemory(stack, GetFinalOffset(stack, fp, out1, MEM_WRITE), out1, outB1)
 }
 
@@ -285,55 +512,19 @@ func buildString(expr *CXExpression, stack *CXStack, fp int) []byte {
 
 	var res []byte
 	var specifiersCounter int
-	var lenStr int = len(fmtStr)
-	
-	for c := 0; c < len(fmtStr); c++ {
-		var nextCh byte
-		ch := fmtStr[c]
-		if c < lenStr - 1{
-			nextCh = fmtStr[c+1]
-		}
-		if ch == '\\' {
-			switch nextCh {
-			case '%':
-				c++
-				res = append(res, nextCh)
-				continue
-			case 'n':
-				c++
-				res = append(res, '\n')
-				continue
-			default:
-				res = append(res, ch)
-				continue
-			}
 
+	for _, dir := range parseFormat(fmtStr) {
+		if !dir.isVerb {
+			res = append(res, dir.text...)
+			continue
 		}
-		if ch == '%' {
-			inp := expr.Inputs[specifiersCounter+1]
-			switch nextCh {
-			case 's':
-				res = append(res, []byte(checkForEscapedChars(ReadStr(stack, fp, inp)))...)
-			case 'd':
-				switch inp.Type {
-				case TYPE_I32:
-					res = append(res, []byte(strconv.FormatInt(int64(ReadI32(stack, fp, inp)), 10))...)
-				case TYPE_I64:
-					res = append(res, []byte(strconv.FormatInt(ReadI64(stack, fp, inp), 10))...)
-				}
-			case 'f':
-				switch inp.Type {
-				case TYPE_F32:
-					res = append(res, []byte(strconv.FormatFloat(float64(ReadF32(stack, fp, inp)), 'f', 7, 32))...)
-				case TYPE_F64:
-					res = append(res, []byte(strconv.FormatFloat(ReadF64(stack, fp, inp), 'f', 16, 64))...)
-				}
-			}
-			c++
-			specifiersCounter++
-		} else {
-			res = append(res, ch)
+
+		var inp *CXArgument
+		if specifiersCounter+1 < len(expr.Inputs) {
+			inp = expr.Inputs[specifiersCounter+1]
 		}
+		res = append(res, []byte(formatArgument(stack, fp, dir, inp))...)
+		specifiersCounter++
 	}
 
 	return res
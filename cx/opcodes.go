@@ -272,6 +272,389 @@ const (
 	
 	// http
 	OP_HTTP_GET
+
+	OP_BYTE_BYTE
+	OP_BYTE_STR
+	OP_BYTE_I8
+	OP_BYTE_I16
+	OP_BYTE_I32
+	OP_BYTE_I64
+	OP_BYTE_UI8
+	OP_BYTE_UI16
+	OP_BYTE_UI32
+	OP_BYTE_UI64
+	OP_BYTE_F32
+	OP_BYTE_F64
+
+	OP_I32_I8
+	OP_I32_I16
+	OP_I32_UI8
+	OP_I32_UI16
+	OP_I32_UI32
+	OP_I32_UI64
+	OP_I32_FORMAT
+
+	OP_I64_BYTE
+	OP_I64_STR
+	OP_I64_I8
+	OP_I64_I16
+	OP_I64_I32
+	OP_I64_I64
+	OP_I64_UI8
+	OP_I64_UI16
+	OP_I64_UI32
+	OP_I64_UI64
+	OP_I64_F32
+	OP_I64_F64
+	OP_I64_FORMAT
+
+	OP_F32_I8
+	OP_F32_I16
+	OP_F32_UI8
+	OP_F32_UI16
+	OP_F32_UI32
+	OP_F32_UI64
+	OP_F32_FORMAT
+
+	OP_F64_BYTE
+	OP_F64_STR
+	OP_F64_I8
+	OP_F64_I16
+	OP_F64_I32
+	OP_F64_I64
+	OP_F64_UI8
+	OP_F64_UI16
+	OP_F64_UI32
+	OP_F64_UI64
+	OP_F64_F32
+	OP_F64_F64
+	OP_F64_FORMAT
+
+	OP_I8_BYTE
+	OP_I8_I8
+	OP_I8_I16
+	OP_I8_I32
+	OP_I8_I64
+	OP_I8_UI8
+	OP_I8_UI16
+	OP_I8_UI32
+	OP_I8_UI64
+	OP_I8_F32
+	OP_I8_F64
+	OP_I8_STR
+	OP_I8_FORMAT
+	OP_I8_PRINT
+	OP_I8_ADD
+	OP_I8_SUB
+	OP_I8_MUL
+	OP_I8_DIV
+	OP_I8_MOD
+	OP_I8_ABS
+	OP_I8_GT
+	OP_I8_GTEQ
+	OP_I8_LT
+	OP_I8_LTEQ
+	OP_I8_EQ
+	OP_I8_UNEQ
+	OP_I8_BITAND
+	OP_I8_BITOR
+	OP_I8_BITXOR
+	OP_I8_BITCLEAR
+	OP_I8_BITSHL
+	OP_I8_BITSHR
+	OP_I8_MAX
+	OP_I8_MIN
+
+	OP_I16_BYTE
+	OP_I16_I8
+	OP_I16_I16
+	OP_I16_I32
+	OP_I16_I64
+	OP_I16_UI8
+	OP_I16_UI16
+	OP_I16_UI32
+	OP_I16_UI64
+	OP_I16_F32
+	OP_I16_F64
+	OP_I16_STR
+	OP_I16_FORMAT
+	OP_I16_PRINT
+	OP_I16_ADD
+	OP_I16_SUB
+	OP_I16_MUL
+	OP_I16_DIV
+	OP_I16_MOD
+	OP_I16_ABS
+	OP_I16_GT
+	OP_I16_GTEQ
+	OP_I16_LT
+	OP_I16_LTEQ
+	OP_I16_EQ
+	OP_I16_UNEQ
+	OP_I16_BITAND
+	OP_I16_BITOR
+	OP_I16_BITXOR
+	OP_I16_BITCLEAR
+	OP_I16_BITSHL
+	OP_I16_BITSHR
+	OP_I16_MAX
+	OP_I16_MIN
+
+	OP_UI8_BYTE
+	OP_UI8_I8
+	OP_UI8_I16
+	OP_UI8_I32
+	OP_UI8_I64
+	OP_UI8_UI8
+	OP_UI8_UI16
+	OP_UI8_UI32
+	OP_UI8_UI64
+	OP_UI8_F32
+	OP_UI8_F64
+	OP_UI8_STR
+	OP_UI8_FORMAT
+	OP_UI8_PRINT
+	OP_UI8_ADD
+	OP_UI8_SUB
+	OP_UI8_MUL
+	OP_UI8_DIV
+	OP_UI8_MOD
+	OP_UI8_GT
+	OP_UI8_GTEQ
+	OP_UI8_LT
+	OP_UI8_LTEQ
+	OP_UI8_EQ
+	OP_UI8_UNEQ
+	OP_UI8_BITAND
+	OP_UI8_BITOR
+	OP_UI8_BITXOR
+	OP_UI8_BITCLEAR
+	OP_UI8_BITSHL
+	OP_UI8_BITSHR
+	OP_UI8_MAX
+	OP_UI8_MIN
+
+	OP_UI16_BYTE
+	OP_UI16_I8
+	OP_UI16_I16
+	OP_UI16_I32
+	OP_UI16_I64
+	OP_UI16_UI8
+	OP_UI16_UI16
+	OP_UI16_UI32
+	OP_UI16_UI64
+	OP_UI16_F32
+	OP_UI16_F64
+	OP_UI16_STR
+	OP_UI16_FORMAT
+	OP_UI16_PRINT
+	OP_UI16_ADD
+	OP_UI16_SUB
+	OP_UI16_MUL
+	OP_UI16_DIV
+	OP_UI16_MOD
+	OP_UI16_GT
+	OP_UI16_GTEQ
+	OP_UI16_LT
+	OP_UI16_LTEQ
+	OP_UI16_EQ
+	OP_UI16_UNEQ
+	OP_UI16_BITAND
+	OP_UI16_BITOR
+	OP_UI16_BITXOR
+	OP_UI16_BITCLEAR
+	OP_UI16_BITSHL
+	OP_UI16_BITSHR
+	OP_UI16_MAX
+	OP_UI16_MIN
+
+	OP_UI32_BYTE
+	OP_UI32_I8
+	OP_UI32_I16
+	OP_UI32_I32
+	OP_UI32_I64
+	OP_UI32_UI8
+	OP_UI32_UI16
+	OP_UI32_UI32
+	OP_UI32_UI64
+	OP_UI32_F32
+	OP_UI32_F64
+	OP_UI32_STR
+	OP_UI32_FORMAT
+	OP_UI32_PRINT
+	OP_UI32_ADD
+	OP_UI32_SUB
+	OP_UI32_MUL
+	OP_UI32_DIV
+	OP_UI32_MOD
+	OP_UI32_GT
+	OP_UI32_GTEQ
+	OP_UI32_LT
+	OP_UI32_LTEQ
+	OP_UI32_EQ
+	OP_UI32_UNEQ
+	OP_UI32_BITAND
+	OP_UI32_BITOR
+	OP_UI32_BITXOR
+	OP_UI32_BITCLEAR
+	OP_UI32_BITSHL
+	OP_UI32_BITSHR
+	OP_UI32_MAX
+	OP_UI32_MIN
+
+	OP_UI64_BYTE
+	OP_UI64_I8
+	OP_UI64_I16
+	OP_UI64_I32
+	OP_UI64_I64
+	OP_UI64_UI8
+	OP_UI64_UI16
+	OP_UI64_UI32
+	OP_UI64_UI64
+	OP_UI64_F32
+	OP_UI64_F64
+	OP_UI64_STR
+	OP_UI64_FORMAT
+	OP_UI64_PRINT
+	OP_UI64_ADD
+	OP_UI64_SUB
+	OP_UI64_MUL
+	OP_UI64_DIV
+	OP_UI64_MOD
+	OP_UI64_GT
+	OP_UI64_GTEQ
+	OP_UI64_LT
+	OP_UI64_LTEQ
+	OP_UI64_EQ
+	OP_UI64_UNEQ
+	OP_UI64_BITAND
+	OP_UI64_BITOR
+	OP_UI64_BITXOR
+	OP_UI64_BITCLEAR
+	OP_UI64_BITSHL
+	OP_UI64_BITSHR
+	OP_UI64_MAX
+	OP_UI64_MIN
+
+	OP_STR_I32
+	OP_STR_I64
+	OP_STR_F32
+	OP_STR_F64
+	OP_STR_BOOL
+
+	// os
+	OP_OS_READ_FILE
+	OP_OS_WRITE_FILE
+	OP_OS_READ_FILE_BYTES
+	OP_OS_WRITE_FILE_BYTES
+	OP_OS_OPEN
+	OP_OS_CREATE
+	OP_OS_READ
+	OP_OS_WRITE
+	OP_OS_WRITE_STRING
+	OP_OS_SEEK
+	OP_OS_CLOSE
+	OP_OS_READ_DIR
+	OP_OS_STAT
+	OP_OS_MKDIR
+	OP_OS_REMOVE
+	OP_OS_RENAME
+	OP_OS_ARGS
+	OP_OS_GETENV
+	OP_OS_SETENV
+	OP_OS_EXIT
+
+	// read
+	OP_READ_LINE
+	OP_READ_ALL
+	OP_READ_STR
+	OP_READ_I32
+	OP_READ_I64
+	OP_READ_F32
+	OP_READ_F64
+
+	// strings
+	OP_STRINGS_INDEX
+	OP_STRINGS_LAST_INDEX
+	OP_STRINGS_CONTAINS
+	OP_STRINGS_HAS_PREFIX
+	OP_STRINGS_HAS_SUFFIX
+	OP_STRINGS_SPLIT
+	OP_STRINGS_JOIN
+	OP_STRINGS_SUBSTRING
+	OP_STRINGS_REPLACE
+	OP_STRINGS_TRIM
+	OP_STRINGS_TRIM_SPACE
+	OP_STRINGS_TO_UPPER
+	OP_STRINGS_TO_LOWER
+	OP_STRINGS_REPEAT
+	OP_STRINGS_RUNE_COUNT
+	OP_STRINGS_DECODE_RUNE
+	OP_STRINGS_ENCODE_RUNE
+
+	// http
+	OP_HTTP_POST
+	OP_HTTP_DO
+	OP_HTTP_HANDLE
+	OP_HTTP_LISTEN_AND_SERVE
+
+	// net
+	OP_NET_DIAL
+	OP_NET_LISTEN
+	OP_NET_LISTEN_PACKET
+	OP_NET_ACCEPT
+	OP_NET_READ
+	OP_NET_WRITE
+	OP_NET_WRITE_STRING
+	OP_NET_READ_FROM
+	OP_NET_WRITE_TO
+	OP_NET_LOCAL_ADDR
+	OP_NET_CLOSE
+
+	// json
+	OP_JSON_MARSHAL
+	OP_JSON_UNMARSHAL
+	OP_JSON_FIELD
+	OP_JSON_NAMING
+	OP_JSON_PARSE
+	OP_JSON_KIND
+	OP_JSON_GET
+	OP_JSON_INDEX
+	OP_JSON_LEN
+	OP_JSON_KEYS
+	OP_JSON_STR
+	OP_JSON_BOOL
+	OP_JSON_I32
+	OP_JSON_I64
+	OP_JSON_F64
+
+	// regexp
+	OP_REGEXP_COMPILE
+	OP_REGEXP_MATCH_STRING
+	OP_REGEXP_FIND_STRING
+	OP_REGEXP_FIND_ALL_STRING
+	OP_REGEXP_FIND_STRING_SUBMATCH
+	OP_REGEXP_REPLACE_ALL_STRING
+
+	// crypto
+	OP_CRYPTO_SHA256
+	OP_CRYPTO_RIPEMD160
+	OP_HEX_ENCODE
+	OP_HEX_DECODE
+	OP_BASE58_ENCODE
+	OP_BASE58_DECODE
+	OP_BASE64_ENCODE
+	OP_BASE64_DECODE
+	OP_CIPHER_GENERATE_KEY_PAIR
+	OP_CIPHER_GENERATE_DETERMINISTIC_KEY_PAIR
+	OP_CIPHER_PUB_KEY_FROM_SEC_KEY
+	OP_CIPHER_ADDRESS_FROM_PUB_KEY
+	OP_CIPHER_SIGN_HASH
+	OP_CIPHER_VERIFY_SIGNATURE
+	OP_CIPHER_CHK_SIG
+
+	// testing
+	OP_TEST_VALUE
 )
 
 func execNative(prgrm *CXProgram) {
@@ -333,6 +716,30 @@ func execNative(prgrm *CXProgram) {
 
 	case OP_BYTE_PRINT:
 		op_byte_print(expr, stack, fp)
+	case OP_BYTE_BYTE:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_STR:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_I8:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_I16:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_I32:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_I64:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_UI8:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_UI16:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_UI32:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_UI64:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_F32:
+		op_byte_byte(expr, stack, fp)
+	case OP_BYTE_F64:
+		op_byte_byte(expr, stack, fp)
 
 	case OP_BOOL_PRINT:
 		op_bool_print(expr, stack, fp)
@@ -359,7 +766,21 @@ func execNative(prgrm *CXProgram) {
 		op_i32_i32(expr, stack, fp)
 	case OP_I32_F64:
 		op_i32_i32(expr, stack, fp)
+	case OP_I32_I8:
+		op_i32_i32(expr, stack, fp)
+	case OP_I32_I16:
+		op_i32_i32(expr, stack, fp)
+	case OP_I32_UI8:
+		op_i32_i32(expr, stack, fp)
+	case OP_I32_UI16:
+		op_i32_i32(expr, stack, fp)
+	case OP_I32_UI32:
+		op_i32_i32(expr, stack, fp)
+	case OP_I32_UI64:
+		op_i32_i32(expr, stack, fp)
 		
+	case OP_I32_FORMAT:
+		op_i32_format(expr, stack, fp)
 	case OP_I32_PRINT:
 		op_i32_print(expr, stack, fp)
 	case OP_I32_ADD:
@@ -478,6 +899,32 @@ func execNative(prgrm *CXProgram) {
 		op_i64_sin(expr, stack, fp)
 	case OP_I64_COS:
 		op_i64_cos(expr, stack, fp)
+	case OP_I64_BYTE:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_STR:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_I8:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_I16:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_I32:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_I64:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_UI8:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_UI16:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_UI32:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_UI64:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_F32:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_F64:
+		op_i64_i64(expr, stack, fp)
+	case OP_I64_FORMAT:
+		op_i64_format(expr, stack, fp)
 
 	case OP_F32_BYTE:
 		op_f32_f32(expr, stack, fp)
@@ -491,7 +938,21 @@ func execNative(prgrm *CXProgram) {
 		op_f32_f32(expr, stack, fp)
 	case OP_F32_F64:
 		op_f32_f32(expr, stack, fp)
+	case OP_F32_I8:
+		op_f32_f32(expr, stack, fp)
+	case OP_F32_I16:
+		op_f32_f32(expr, stack, fp)
+	case OP_F32_UI8:
+		op_f32_f32(expr, stack, fp)
+	case OP_F32_UI16:
+		op_f32_f32(expr, stack, fp)
+	case OP_F32_UI32:
+		op_f32_f32(expr, stack, fp)
+	case OP_F32_UI64:
+		op_f32_f32(expr, stack, fp)
 		
+	case OP_F32_FORMAT:
+		op_f32_format(expr, stack, fp)
 	case OP_F32_PRINT:
 		op_f32_print(expr, stack, fp)
 	case OP_F32_ADD:
@@ -577,10 +1038,452 @@ func execNative(prgrm *CXProgram) {
 		op_f64_max(expr, stack, fp)
 	case OP_F64_MIN:
 		op_f64_min(expr, stack, fp)
+	case OP_F64_BYTE:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_STR:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_I8:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_I16:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_I32:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_I64:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_UI8:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_UI16:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_UI32:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_UI64:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_F32:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_F64:
+		op_f64_f64(expr, stack, fp)
+	case OP_F64_FORMAT:
+		op_f64_format(expr, stack, fp)
+	case OP_I8_BYTE:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_I8:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_I16:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_I32:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_I64:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_UI8:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_UI16:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_UI32:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_UI64:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_F32:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_F64:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_STR:
+		op_i8_i8(expr, stack, fp)
+	case OP_I8_FORMAT:
+		op_i8_format(expr, stack, fp)
+	case OP_I8_PRINT:
+		op_i8_print(expr, stack, fp)
+	case OP_I8_ADD:
+		op_i8_add(expr, stack, fp)
+	case OP_I8_SUB:
+		op_i8_sub(expr, stack, fp)
+	case OP_I8_MUL:
+		op_i8_mul(expr, stack, fp)
+	case OP_I8_DIV:
+		op_i8_div(expr, stack, fp)
+	case OP_I8_MOD:
+		op_i8_mod(expr, stack, fp)
+	case OP_I8_ABS:
+		op_i8_abs(expr, stack, fp)
+	case OP_I8_GT:
+		op_i8_gt(expr, stack, fp)
+	case OP_I8_GTEQ:
+		op_i8_gteq(expr, stack, fp)
+	case OP_I8_LT:
+		op_i8_lt(expr, stack, fp)
+	case OP_I8_LTEQ:
+		op_i8_lteq(expr, stack, fp)
+	case OP_I8_EQ:
+		op_i8_eq(expr, stack, fp)
+	case OP_I8_UNEQ:
+		op_i8_uneq(expr, stack, fp)
+	case OP_I8_BITAND:
+		op_i8_bitand(expr, stack, fp)
+	case OP_I8_BITOR:
+		op_i8_bitor(expr, stack, fp)
+	case OP_I8_BITXOR:
+		op_i8_bitxor(expr, stack, fp)
+	case OP_I8_BITCLEAR:
+		op_i8_bitclear(expr, stack, fp)
+	case OP_I8_BITSHL:
+		op_i8_bitshl(expr, stack, fp)
+	case OP_I8_BITSHR:
+		op_i8_bitshr(expr, stack, fp)
+	case OP_I8_MAX:
+		op_i8_max(expr, stack, fp)
+	case OP_I8_MIN:
+		op_i8_min(expr, stack, fp)
+
+	case OP_I16_BYTE:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_I8:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_I16:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_I32:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_I64:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_UI8:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_UI16:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_UI32:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_UI64:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_F32:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_F64:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_STR:
+		op_i16_i16(expr, stack, fp)
+	case OP_I16_FORMAT:
+		op_i16_format(expr, stack, fp)
+	case OP_I16_PRINT:
+		op_i16_print(expr, stack, fp)
+	case OP_I16_ADD:
+		op_i16_add(expr, stack, fp)
+	case OP_I16_SUB:
+		op_i16_sub(expr, stack, fp)
+	case OP_I16_MUL:
+		op_i16_mul(expr, stack, fp)
+	case OP_I16_DIV:
+		op_i16_div(expr, stack, fp)
+	case OP_I16_MOD:
+		op_i16_mod(expr, stack, fp)
+	case OP_I16_ABS:
+		op_i16_abs(expr, stack, fp)
+	case OP_I16_GT:
+		op_i16_gt(expr, stack, fp)
+	case OP_I16_GTEQ:
+		op_i16_gteq(expr, stack, fp)
+	case OP_I16_LT:
+		op_i16_lt(expr, stack, fp)
+	case OP_I16_LTEQ:
+		op_i16_lteq(expr, stack, fp)
+	case OP_I16_EQ:
+		op_i16_eq(expr, stack, fp)
+	case OP_I16_UNEQ:
+		op_i16_uneq(expr, stack, fp)
+	case OP_I16_BITAND:
+		op_i16_bitand(expr, stack, fp)
+	case OP_I16_BITOR:
+		op_i16_bitor(expr, stack, fp)
+	case OP_I16_BITXOR:
+		op_i16_bitxor(expr, stack, fp)
+	case OP_I16_BITCLEAR:
+		op_i16_bitclear(expr, stack, fp)
+	case OP_I16_BITSHL:
+		op_i16_bitshl(expr, stack, fp)
+	case OP_I16_BITSHR:
+		op_i16_bitshr(expr, stack, fp)
+	case OP_I16_MAX:
+		op_i16_max(expr, stack, fp)
+	case OP_I16_MIN:
+		op_i16_min(expr, stack, fp)
+
+	case OP_UI8_BYTE:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_I8:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_I16:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_I32:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_I64:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_UI8:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_UI16:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_UI32:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_UI64:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_F32:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_F64:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_STR:
+		op_ui8_ui8(expr, stack, fp)
+	case OP_UI8_FORMAT:
+		op_ui8_format(expr, stack, fp)
+	case OP_UI8_PRINT:
+		op_ui8_print(expr, stack, fp)
+	case OP_UI8_ADD:
+		op_ui8_add(expr, stack, fp)
+	case OP_UI8_SUB:
+		op_ui8_sub(expr, stack, fp)
+	case OP_UI8_MUL:
+		op_ui8_mul(expr, stack, fp)
+	case OP_UI8_DIV:
+		op_ui8_div(expr, stack, fp)
+	case OP_UI8_MOD:
+		op_ui8_mod(expr, stack, fp)
+	case OP_UI8_GT:
+		op_ui8_gt(expr, stack, fp)
+	case OP_UI8_GTEQ:
+		op_ui8_gteq(expr, stack, fp)
+	case OP_UI8_LT:
+		op_ui8_lt(expr, stack, fp)
+	case OP_UI8_LTEQ:
+		op_ui8_lteq(expr, stack, fp)
+	case OP_UI8_EQ:
+		op_ui8_eq(expr, stack, fp)
+	case OP_UI8_UNEQ:
+		op_ui8_uneq(expr, stack, fp)
+	case OP_UI8_BITAND:
+		op_ui8_bitand(expr, stack, fp)
+	case OP_UI8_BITOR:
+		op_ui8_bitor(expr, stack, fp)
+	case OP_UI8_BITXOR:
+		op_ui8_bitxor(expr, stack, fp)
+	case OP_UI8_BITCLEAR:
+		op_ui8_bitclear(expr, stack, fp)
+	case OP_UI8_BITSHL:
+		op_ui8_bitshl(expr, stack, fp)
+	case OP_UI8_BITSHR:
+		op_ui8_bitshr(expr, stack, fp)
+	case OP_UI8_MAX:
+		op_ui8_max(expr, stack, fp)
+	case OP_UI8_MIN:
+		op_ui8_min(expr, stack, fp)
+
+	case OP_UI16_BYTE:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_I8:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_I16:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_I32:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_I64:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_UI8:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_UI16:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_UI32:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_UI64:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_F32:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_F64:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_STR:
+		op_ui16_ui16(expr, stack, fp)
+	case OP_UI16_FORMAT:
+		op_ui16_format(expr, stack, fp)
+	case OP_UI16_PRINT:
+		op_ui16_print(expr, stack, fp)
+	case OP_UI16_ADD:
+		op_ui16_add(expr, stack, fp)
+	case OP_UI16_SUB:
+		op_ui16_sub(expr, stack, fp)
+	case OP_UI16_MUL:
+		op_ui16_mul(expr, stack, fp)
+	case OP_UI16_DIV:
+		op_ui16_div(expr, stack, fp)
+	case OP_UI16_MOD:
+		op_ui16_mod(expr, stack, fp)
+	case OP_UI16_GT:
+		op_ui16_gt(expr, stack, fp)
+	case OP_UI16_GTEQ:
+		op_ui16_gteq(expr, stack, fp)
+	case OP_UI16_LT:
+		op_ui16_lt(expr, stack, fp)
+	case OP_UI16_LTEQ:
+		op_ui16_lteq(expr, stack, fp)
+	case OP_UI16_EQ:
+		op_ui16_eq(expr, stack, fp)
+	case OP_UI16_UNEQ:
+		op_ui16_uneq(expr, stack, fp)
+	case OP_UI16_BITAND:
+		op_ui16_bitand(expr, stack, fp)
+	case OP_UI16_BITOR:
+		op_ui16_bitor(expr, stack, fp)
+	case OP_UI16_BITXOR:
+		op_ui16_bitxor(expr, stack, fp)
+	case OP_UI16_BITCLEAR:
+		op_ui16_bitclear(expr, stack, fp)
+	case OP_UI16_BITSHL:
+		op_ui16_bitshl(expr, stack, fp)
+	case OP_UI16_BITSHR:
+		op_ui16_bitshr(expr, stack, fp)
+	case OP_UI16_MAX:
+		op_ui16_max(expr, stack, fp)
+	case OP_UI16_MIN:
+		op_ui16_min(expr, stack, fp)
+
+	case OP_UI32_BYTE:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_I8:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_I16:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_I32:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_I64:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_UI8:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_UI16:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_UI32:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_UI64:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_F32:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_F64:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_STR:
+		op_ui32_ui32(expr, stack, fp)
+	case OP_UI32_FORMAT:
+		op_ui32_format(expr, stack, fp)
+	case OP_UI32_PRINT:
+		op_ui32_print(expr, stack, fp)
+	case OP_UI32_ADD:
+		op_ui32_add(expr, stack, fp)
+	case OP_UI32_SUB:
+		op_ui32_sub(expr, stack, fp)
+	case OP_UI32_MUL:
+		op_ui32_mul(expr, stack, fp)
+	case OP_UI32_DIV:
+		op_ui32_div(expr, stack, fp)
+	case OP_UI32_MOD:
+		op_ui32_mod(expr, stack, fp)
+	case OP_UI32_GT:
+		op_ui32_gt(expr, stack, fp)
+	case OP_UI32_GTEQ:
+		op_ui32_gteq(expr, stack, fp)
+	case OP_UI32_LT:
+		op_ui32_lt(expr, stack, fp)
+	case OP_UI32_LTEQ:
+		op_ui32_lteq(expr, stack, fp)
+	case OP_UI32_EQ:
+		op_ui32_eq(expr, stack, fp)
+	case OP_UI32_UNEQ:
+		op_ui32_uneq(expr, stack, fp)
+	case OP_UI32_BITAND:
+		op_ui32_bitand(expr, stack, fp)
+	case OP_UI32_BITOR:
+		op_ui32_bitor(expr, stack, fp)
+	case OP_UI32_BITXOR:
+		op_ui32_bitxor(expr, stack, fp)
+	case OP_UI32_BITCLEAR:
+		op_ui32_bitclear(expr, stack, fp)
+	case OP_UI32_BITSHL:
+		op_ui32_bitshl(expr, stack, fp)
+	case OP_UI32_BITSHR:
+		op_ui32_bitshr(expr, stack, fp)
+	case OP_UI32_MAX:
+		op_ui32_max(expr, stack, fp)
+	case OP_UI32_MIN:
+		op_ui32_min(expr, stack, fp)
+
+	case OP_UI64_BYTE:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_I8:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_I16:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_I32:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_I64:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_UI8:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_UI16:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_UI32:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_UI64:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_F32:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_F64:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_STR:
+		op_ui64_ui64(expr, stack, fp)
+	case OP_UI64_FORMAT:
+		op_ui64_format(expr, stack, fp)
+	case OP_UI64_PRINT:
+		op_ui64_print(expr, stack, fp)
+	case OP_UI64_ADD:
+		op_ui64_add(expr, stack, fp)
+	case OP_UI64_SUB:
+		op_ui64_sub(expr, stack, fp)
+	case OP_UI64_MUL:
+		op_ui64_mul(expr, stack, fp)
+	case OP_UI64_DIV:
+		op_ui64_div(expr, stack, fp)
+	case OP_UI64_MOD:
+		op_ui64_mod(expr, stack, fp)
+	case OP_UI64_GT:
+		op_ui64_gt(expr, stack, fp)
+	case OP_UI64_GTEQ:
+		op_ui64_gteq(expr, stack, fp)
+	case OP_UI64_LT:
+		op_ui64_lt(expr, stack, fp)
+	case OP_UI64_LTEQ:
+		op_ui64_lteq(expr, stack, fp)
+	case OP_UI64_EQ:
+		op_ui64_eq(expr, stack, fp)
+	case OP_UI64_UNEQ:
+		op_ui64_uneq(expr, stack, fp)
+	case OP_UI64_BITAND:
+		op_ui64_bitand(expr, stack, fp)
+	case OP_UI64_BITOR:
+		op_ui64_bitor(expr, stack, fp)
+	case OP_UI64_BITXOR:
+		op_ui64_bitxor(expr, stack, fp)
+	case OP_UI64_BITCLEAR:
+		op_ui64_bitclear(expr, stack, fp)
+	case OP_UI64_BITSHL:
+		op_ui64_bitshl(expr, stack, fp)
+	case OP_UI64_BITSHR:
+		op_ui64_bitshr(expr, stack, fp)
+	case OP_UI64_MAX:
+		op_ui64_max(expr, stack, fp)
+	case OP_UI64_MIN:
+		op_ui64_min(expr, stack, fp)
+
 	case OP_STR_PRINT:
 		op_str_print(expr, stack, fp)
 	case OP_STR_EQ:
 		op_str_eq(expr, stack, fp)
+	case OP_STR_I32:
+		op_str_i32(expr, stack, fp)
+	case OP_STR_I64:
+		op_str_i64(expr, stack, fp)
+	case OP_STR_F32:
+		op_str_f32(expr, stack, fp)
+	case OP_STR_F64:
+		op_str_f64(expr, stack, fp)
+	case OP_STR_BOOL:
+		op_str_bool(expr, stack, fp)
 	case OP_MAKE:
 	case OP_READ:
 	case OP_WRITE:
@@ -609,7 +1512,7 @@ func execNative(prgrm *CXProgram) {
 	case OP_TEST_STOP:
 		isTesting = false
 	case OP_TEST_ERROR:
-		isErrorPresent = false
+		op_test_error(expr, stack, fp)
 	case OP_ASSERT:
 		op_assert_value(expr, stack, fp)
 	case OP_TIME_SLEEP:
@@ -759,10 +1662,216 @@ func execNative(prgrm *CXProgram) {
 		op_glfw_SetInputMode(expr, stack, fp)
 	case OP_HTTP_GET:
 		op_http_get(expr, stack, fp)
+	case OP_HTTP_POST:
+		op_http_post(expr, stack, fp)
+	case OP_HTTP_DO:
+		op_http_do(expr, stack, fp)
+	case OP_HTTP_HANDLE:
+		op_http_handle(expr, stack, fp)
+	case OP_HTTP_LISTEN_AND_SERVE:
+		op_http_listen_and_serve(expr, stack, fp)
+
+		// net
+	case OP_NET_DIAL:
+		op_net_Dial(expr, stack, fp)
+	case OP_NET_LISTEN:
+		op_net_Listen(expr, stack, fp)
+	case OP_NET_LISTEN_PACKET:
+		op_net_ListenPacket(expr, stack, fp)
+	case OP_NET_ACCEPT:
+		op_net_Accept(expr, stack, fp)
+	case OP_NET_READ:
+		op_net_Read(expr, stack, fp)
+	case OP_NET_WRITE:
+		op_net_Write(expr, stack, fp)
+	case OP_NET_WRITE_STRING:
+		op_net_WriteString(expr, stack, fp)
+	case OP_NET_READ_FROM:
+		op_net_ReadFrom(expr, stack, fp)
+	case OP_NET_WRITE_TO:
+		op_net_WriteTo(expr, stack, fp)
+	case OP_NET_LOCAL_ADDR:
+		op_net_LocalAddr(expr, stack, fp)
+	case OP_NET_CLOSE:
+		op_net_Close(expr, stack, fp)
+
+		// json
+	case OP_JSON_MARSHAL:
+		op_json_Marshal(expr, stack, fp)
+	case OP_JSON_UNMARSHAL:
+		op_json_Unmarshal(expr, stack, fp)
+	case OP_JSON_FIELD:
+		op_json_Field(expr, stack, fp)
+	case OP_JSON_NAMING:
+		op_json_Naming(expr, stack, fp)
+	case OP_JSON_PARSE:
+		op_json_Parse(expr, stack, fp)
+	case OP_JSON_KIND:
+		op_json_Kind(expr, stack, fp)
+	case OP_JSON_GET:
+		op_json_Get(expr, stack, fp)
+	case OP_JSON_INDEX:
+		op_json_Index(expr, stack, fp)
+	case OP_JSON_LEN:
+		op_json_Len(expr, stack, fp)
+	case OP_JSON_KEYS:
+		op_json_Keys(expr, stack, fp)
+	case OP_JSON_STR:
+		op_json_Str(expr, stack, fp)
+	case OP_JSON_BOOL:
+		op_json_Bool(expr, stack, fp)
+	case OP_JSON_I32:
+		op_json_I32(expr, stack, fp)
+	case OP_JSON_I64:
+		op_json_I64(expr, stack, fp)
+	case OP_JSON_F64:
+		op_json_F64(expr, stack, fp)
+
+		// regexp
+	case OP_REGEXP_COMPILE:
+		op_regexp_Compile(expr, stack, fp)
+	case OP_REGEXP_MATCH_STRING:
+		op_regexp_MatchString(expr, stack, fp)
+	case OP_REGEXP_FIND_STRING:
+		op_regexp_FindString(expr, stack, fp)
+	case OP_REGEXP_FIND_ALL_STRING:
+		op_regexp_FindAllString(expr, stack, fp)
+	case OP_REGEXP_FIND_STRING_SUBMATCH:
+		op_regexp_FindStringSubmatch(expr, stack, fp)
+	case OP_REGEXP_REPLACE_ALL_STRING:
+		op_regexp_ReplaceAllString(expr, stack, fp)
+
+		// crypto
+	case OP_CRYPTO_SHA256:
+		op_crypto_SHA256(expr, stack, fp)
+	case OP_CRYPTO_RIPEMD160:
+		op_crypto_RIPEMD160(expr, stack, fp)
+	case OP_HEX_ENCODE:
+		op_hex_Encode(expr, stack, fp)
+	case OP_HEX_DECODE:
+		op_hex_Decode(expr, stack, fp)
+	case OP_BASE58_ENCODE:
+		op_base58_Encode(expr, stack, fp)
+	case OP_BASE58_DECODE:
+		op_base58_Decode(expr, stack, fp)
+	case OP_BASE64_ENCODE:
+		op_base64_Encode(expr, stack, fp)
+	case OP_BASE64_DECODE:
+		op_base64_Decode(expr, stack, fp)
+	case OP_CIPHER_GENERATE_KEY_PAIR:
+		op_cipher_GenerateKeyPair(expr, stack, fp)
+	case OP_CIPHER_GENERATE_DETERMINISTIC_KEY_PAIR:
+		op_cipher_GenerateDeterministicKeyPair(expr, stack, fp)
+	case OP_CIPHER_PUB_KEY_FROM_SEC_KEY:
+		op_cipher_PubKeyFromSecKey(expr, stack, fp)
+	case OP_CIPHER_ADDRESS_FROM_PUB_KEY:
+		op_cipher_AddressFromPubKey(expr, stack, fp)
+	case OP_CIPHER_SIGN_HASH:
+		op_cipher_SignHash(expr, stack, fp)
+	case OP_CIPHER_VERIFY_SIGNATURE:
+		op_cipher_VerifySignature(expr, stack, fp)
+	case OP_CIPHER_CHK_SIG:
+		op_cipher_ChkSig(expr, stack, fp)
 
 		// os
 	case OP_OS_GET_WORKING_DIRECTORY:
 		op_os_GetWorkingDirectory(expr, stack, fp)
+	case OP_OS_READ_FILE:
+		op_os_ReadFile(expr, stack, fp)
+	case OP_OS_WRITE_FILE:
+		op_os_WriteFile(expr, stack, fp)
+	case OP_OS_READ_FILE_BYTES:
+		op_os_ReadFileBytes(expr, stack, fp)
+	case OP_OS_WRITE_FILE_BYTES:
+		op_os_WriteFileBytes(expr, stack, fp)
+	case OP_OS_OPEN:
+		op_os_Open(expr, stack, fp)
+	case OP_OS_CREATE:
+		op_os_Create(expr, stack, fp)
+	case OP_OS_READ:
+		op_os_Read(expr, stack, fp)
+	case OP_OS_WRITE:
+		op_os_Write(expr, stack, fp)
+	case OP_OS_WRITE_STRING:
+		op_os_WriteString(expr, stack, fp)
+	case OP_OS_SEEK:
+		op_os_Seek(expr, stack, fp)
+	case OP_OS_CLOSE:
+		op_os_Close(expr, stack, fp)
+	case OP_OS_READ_DIR:
+		op_os_ReadDir(expr, stack, fp)
+	case OP_OS_STAT:
+		op_os_Stat(expr, stack, fp)
+	case OP_OS_MKDIR:
+		op_os_Mkdir(expr, stack, fp)
+	case OP_OS_REMOVE:
+		op_os_Remove(expr, stack, fp)
+	case OP_OS_RENAME:
+		op_os_Rename(expr, stack, fp)
+	case OP_OS_ARGS:
+		op_os_Args(expr, stack, fp)
+	case OP_OS_GETENV:
+		op_os_Getenv(expr, stack, fp)
+	case OP_OS_SETENV:
+		op_os_Setenv(expr, stack, fp)
+	case OP_OS_EXIT:
+		op_os_Exit(expr, stack, fp)
+
+		// read
+	case OP_READ_LINE:
+		op_read_line(expr, stack, fp)
+	case OP_READ_ALL:
+		op_read_all(expr, stack, fp)
+	case OP_READ_STR:
+		op_read_str(expr, stack, fp)
+	case OP_READ_I32:
+		op_read_i32(expr, stack, fp)
+	case OP_READ_I64:
+		op_read_i64(expr, stack, fp)
+	case OP_READ_F32:
+		op_read_f32(expr, stack, fp)
+	case OP_READ_F64:
+		op_read_f64(expr, stack, fp)
+
+		// strings
+	case OP_STRINGS_INDEX:
+		op_strings_Index(expr, stack, fp)
+	case OP_STRINGS_LAST_INDEX:
+		op_strings_LastIndex(expr, stack, fp)
+	case OP_STRINGS_CONTAINS:
+		op_strings_Contains(expr, stack, fp)
+	case OP_STRINGS_HAS_PREFIX:
+		op_strings_HasPrefix(expr, stack, fp)
+	case OP_STRINGS_HAS_SUFFIX:
+		op_strings_HasSuffix(expr, stack, fp)
+	case OP_STRINGS_SPLIT:
+		op_strings_Split(expr, stack, fp)
+	case OP_STRINGS_JOIN:
+		op_strings_Join(expr, stack, fp)
+	case OP_STRINGS_SUBSTRING:
+		op_strings_Substring(expr, stack, fp)
+	case OP_STRINGS_REPLACE:
+		op_strings_Replace(expr, stack, fp)
+	case OP_STRINGS_TRIM:
+		op_strings_Trim(expr, stack, fp)
+	case OP_STRINGS_TRIM_SPACE:
+		op_strings_TrimSpace(expr, stack, fp)
+	case OP_STRINGS_TO_UPPER:
+		op_strings_ToUpper(expr, stack, fp)
+	case OP_STRINGS_TO_LOWER:
+		op_strings_ToLower(expr, stack, fp)
+	case OP_STRINGS_REPEAT:
+		op_strings_Repeat(expr, stack, fp)
+	case OP_STRINGS_RUNE_COUNT:
+		op_strings_RuneCount(expr, stack, fp)
+	case OP_STRINGS_DECODE_RUNE:
+		op_strings_DecodeRune(expr, stack, fp)
+	case OP_STRINGS_ENCODE_RUNE:
+		op_strings_EncodeRune(expr, stack, fp)
+
+		// testing
+	case OP_TEST_VALUE:
+		op_test_value(expr, stack, fp)
 	}
 }
 
@@ -794,6 +1903,18 @@ var OpNames map[int]string = map[int]string{
 	OP_UND_SPRINTF:  "sprintf",
 
 	OP_BYTE_PRINT: "byte.print",
+	OP_BYTE_BYTE:  "byte.byte",
+	OP_BYTE_STR:   "byte.str",
+	OP_BYTE_I8:    "byte.i8",
+	OP_BYTE_I16:   "byte.i16",
+	OP_BYTE_I32:   "byte.i32",
+	OP_BYTE_I64:   "byte.i64",
+	OP_BYTE_UI8:   "byte.ui8",
+	OP_BYTE_UI16:  "byte.ui16",
+	OP_BYTE_UI32:  "byte.ui32",
+	OP_BYTE_UI64:  "byte.ui64",
+	OP_BYTE_F32:   "byte.f32",
+	OP_BYTE_F64:   "byte.f64",
 
 	OP_BOOL_PRINT:   "bool.print",
 	OP_BOOL_EQUAL:   "bool.eq",
@@ -808,6 +1929,13 @@ var OpNames map[int]string = map[int]string{
 	OP_I32_I64:      "i32.i64",
 	OP_I32_F32:      "i32.f32",
 	OP_I32_F64:      "i32.f64",
+	OP_I32_I8:       "i32.i8",
+	OP_I32_I16:      "i32.i16",
+	OP_I32_UI8:      "i32.ui8",
+	OP_I32_UI16:     "i32.ui16",
+	OP_I32_UI32:     "i32.ui32",
+	OP_I32_UI64:     "i32.ui64",
+	OP_I32_FORMAT:   "i32.format",
 	OP_I32_PRINT:    "i32.print",
 	OP_I32_ADD:      "i32.add",
 	OP_I32_SUB:      "i32.sub",
@@ -865,6 +1993,19 @@ var OpNames map[int]string = map[int]string{
 	OP_I64_MAX:      "i64.max",
 	OP_I64_MIN:      "i64.min",
 	OP_I64_COS:      "i64.cos",
+	OP_I64_BYTE:     "i64.byte",
+	OP_I64_STR:      "i64.str",
+	OP_I64_I8:       "i64.i8",
+	OP_I64_I16:      "i64.i16",
+	OP_I64_I32:      "i64.i32",
+	OP_I64_I64:      "i64.i64",
+	OP_I64_UI8:      "i64.ui8",
+	OP_I64_UI16:     "i64.ui16",
+	OP_I64_UI32:     "i64.ui32",
+	OP_I64_UI64:     "i64.ui64",
+	OP_I64_F32:      "i64.f32",
+	OP_I64_F64:      "i64.f64",
+	OP_I64_FORMAT:   "i64.format",
 	OP_I64_SIN:      "i64.sin",
 	OP_F32_BYTE:     "f32.byte",
 	OP_F32_STR:      "f32.str",
@@ -872,6 +2013,13 @@ var OpNames map[int]string = map[int]string{
 	OP_F32_I64:      "f32.i64",
 	OP_F32_F32:      "f32.f32",
 	OP_F32_F64:      "f32.f64",
+	OP_F32_I8:       "f32.i8",
+	OP_F32_I16:      "f32.i16",
+	OP_F32_UI8:      "f32.ui8",
+	OP_F32_UI16:     "f32.ui16",
+	OP_F32_UI32:     "f32.ui32",
+	OP_F32_UI64:     "f32.ui64",
+	OP_F32_FORMAT:   "f32.format",
 	OP_F32_PRINT:    "f32.print",
 	OP_F32_ADD:      "f32.add",
 	OP_F32_SUB:      "f32.sub",
@@ -914,9 +2062,233 @@ var OpNames map[int]string = map[int]string{
 	OP_F64_LOG10:    "f64.log10",
 	OP_F64_MAX:      "f64.max",
 	OP_F64_MIN:      "f64.min",
+	OP_F64_BYTE:     "f64.byte",
+	OP_F64_STR:      "f64.str",
+	OP_F64_I8:       "f64.i8",
+	OP_F64_I16:      "f64.i16",
+	OP_F64_I32:      "f64.i32",
+	OP_F64_I64:      "f64.i64",
+	OP_F64_UI8:      "f64.ui8",
+	OP_F64_UI16:     "f64.ui16",
+	OP_F64_UI32:     "f64.ui32",
+	OP_F64_UI64:     "f64.ui64",
+	OP_F64_F32:      "f64.f32",
+	OP_F64_F64:      "f64.f64",
+	OP_F64_FORMAT:   "f64.format",
+
+	OP_I8_BYTE:     "i8.byte",
+	OP_I8_I8:       "i8.i8",
+	OP_I8_I16:      "i8.i16",
+	OP_I8_I32:      "i8.i32",
+	OP_I8_I64:      "i8.i64",
+	OP_I8_UI8:      "i8.ui8",
+	OP_I8_UI16:     "i8.ui16",
+	OP_I8_UI32:     "i8.ui32",
+	OP_I8_UI64:     "i8.ui64",
+	OP_I8_F32:      "i8.f32",
+	OP_I8_F64:      "i8.f64",
+	OP_I8_STR:      "i8.str",
+	OP_I8_FORMAT:   "i8.format",
+	OP_I8_PRINT:    "i8.print",
+	OP_I8_ADD:      "i8.add",
+	OP_I8_SUB:      "i8.sub",
+	OP_I8_MUL:      "i8.mul",
+	OP_I8_DIV:      "i8.div",
+	OP_I8_MOD:      "i8.mod",
+	OP_I8_ABS:      "i8.abs",
+	OP_I8_GT:       "i8.gt",
+	OP_I8_GTEQ:     "i8.gteq",
+	OP_I8_LT:       "i8.lt",
+	OP_I8_LTEQ:     "i8.lteq",
+	OP_I8_EQ:       "i8.eq",
+	OP_I8_UNEQ:     "i8.uneq",
+	OP_I8_BITAND:   "i8.bitand",
+	OP_I8_BITOR:    "i8.bitor",
+	OP_I8_BITXOR:   "i8.bitxor",
+	OP_I8_BITCLEAR: "i8.bitclear",
+	OP_I8_BITSHL:   "i8.bitshl",
+	OP_I8_BITSHR:   "i8.bitshr",
+	OP_I8_MAX:      "i8.max",
+	OP_I8_MIN:      "i8.min",
+
+	OP_I16_BYTE:     "i16.byte",
+	OP_I16_I8:       "i16.i8",
+	OP_I16_I16:      "i16.i16",
+	OP_I16_I32:      "i16.i32",
+	OP_I16_I64:      "i16.i64",
+	OP_I16_UI8:      "i16.ui8",
+	OP_I16_UI16:     "i16.ui16",
+	OP_I16_UI32:     "i16.ui32",
+	OP_I16_UI64:     "i16.ui64",
+	OP_I16_F32:      "i16.f32",
+	OP_I16_F64:      "i16.f64",
+	OP_I16_STR:      "i16.str",
+	OP_I16_FORMAT:   "i16.format",
+	OP_I16_PRINT:    "i16.print",
+	OP_I16_ADD:      "i16.add",
+	OP_I16_SUB:      "i16.sub",
+	OP_I16_MUL:      "i16.mul",
+	OP_I16_DIV:      "i16.div",
+	OP_I16_MOD:      "i16.mod",
+	OP_I16_ABS:      "i16.abs",
+	OP_I16_GT:       "i16.gt",
+	OP_I16_GTEQ:     "i16.gteq",
+	OP_I16_LT:       "i16.lt",
+	OP_I16_LTEQ:     "i16.lteq",
+	OP_I16_EQ:       "i16.eq",
+	OP_I16_UNEQ:     "i16.uneq",
+	OP_I16_BITAND:   "i16.bitand",
+	OP_I16_BITOR:    "i16.bitor",
+	OP_I16_BITXOR:   "i16.bitxor",
+	OP_I16_BITCLEAR: "i16.bitclear",
+	OP_I16_BITSHL:   "i16.bitshl",
+	OP_I16_BITSHR:   "i16.bitshr",
+	OP_I16_MAX:      "i16.max",
+	OP_I16_MIN:      "i16.min",
+
+	OP_UI8_BYTE:     "ui8.byte",
+	OP_UI8_I8:       "ui8.i8",
+	OP_UI8_I16:      "ui8.i16",
+	OP_UI8_I32:      "ui8.i32",
+	OP_UI8_I64:      "ui8.i64",
+	OP_UI8_UI8:      "ui8.ui8",
+	OP_UI8_UI16:     "ui8.ui16",
+	OP_UI8_UI32:     "ui8.ui32",
+	OP_UI8_UI64:     "ui8.ui64",
+	OP_UI8_F32:      "ui8.f32",
+	OP_UI8_F64:      "ui8.f64",
+	OP_UI8_STR:      "ui8.str",
+	OP_UI8_FORMAT:   "ui8.format",
+	OP_UI8_PRINT:    "ui8.print",
+	OP_UI8_ADD:      "ui8.add",
+	OP_UI8_SUB:      "ui8.sub",
+	OP_UI8_MUL:      "ui8.mul",
+	OP_UI8_DIV:      "ui8.div",
+	OP_UI8_MOD:      "ui8.mod",
+	OP_UI8_GT:       "ui8.gt",
+	OP_UI8_GTEQ:     "ui8.gteq",
+	OP_UI8_LT:       "ui8.lt",
+	OP_UI8_LTEQ:     "ui8.lteq",
+	OP_UI8_EQ:       "ui8.eq",
+	OP_UI8_UNEQ:     "ui8.uneq",
+	OP_UI8_BITAND:   "ui8.bitand",
+	OP_UI8_BITOR:    "ui8.bitor",
+	OP_UI8_BITXOR:   "ui8.bitxor",
+	OP_UI8_BITCLEAR: "ui8.bitclear",
+	OP_UI8_BITSHL:   "ui8.bitshl",
+	OP_UI8_BITSHR:   "ui8.bitshr",
+	OP_UI8_MAX:      "ui8.max",
+	OP_UI8_MIN:      "ui8.min",
+
+	OP_UI16_BYTE:     "ui16.byte",
+	OP_UI16_I8:       "ui16.i8",
+	OP_UI16_I16:      "ui16.i16",
+	OP_UI16_I32:      "ui16.i32",
+	OP_UI16_I64:      "ui16.i64",
+	OP_UI16_UI8:      "ui16.ui8",
+	OP_UI16_UI16:     "ui16.ui16",
+	OP_UI16_UI32:     "ui16.ui32",
+	OP_UI16_UI64:     "ui16.ui64",
+	OP_UI16_F32:      "ui16.f32",
+	OP_UI16_F64:      "ui16.f64",
+	OP_UI16_STR:      "ui16.str",
+	OP_UI16_FORMAT:   "ui16.format",
+	OP_UI16_PRINT:    "ui16.print",
+	OP_UI16_ADD:      "ui16.add",
+	OP_UI16_SUB:      "ui16.sub",
+	OP_UI16_MUL:      "ui16.mul",
+	OP_UI16_DIV:      "ui16.div",
+	OP_UI16_MOD:      "ui16.mod",
+	OP_UI16_GT:       "ui16.gt",
+	OP_UI16_GTEQ:     "ui16.gteq",
+	OP_UI16_LT:       "ui16.lt",
+	OP_UI16_LTEQ:     "ui16.lteq",
+	OP_UI16_EQ:       "ui16.eq",
+	OP_UI16_UNEQ:     "ui16.uneq",
+	OP_UI16_BITAND:   "ui16.bitand",
+	OP_UI16_BITOR:    "ui16.bitor",
+	OP_UI16_BITXOR:   "ui16.bitxor",
+	OP_UI16_BITCLEAR: "ui16.bitclear",
+	OP_UI16_BITSHL:   "ui16.bitshl",
+	OP_UI16_BITSHR:   "ui16.bitshr",
+	OP_UI16_MAX:      "ui16.max",
+	OP_UI16_MIN:      "ui16.min",
+
+	OP_UI32_BYTE:     "ui32.byte",
+	OP_UI32_I8:       "ui32.i8",
+	OP_UI32_I16:      "ui32.i16",
+	OP_UI32_I32:      "ui32.i32",
+	OP_UI32_I64:      "ui32.i64",
+	OP_UI32_UI8:      "ui32.ui8",
+	OP_UI32_UI16:     "ui32.ui16",
+	OP_UI32_UI32:     "ui32.ui32",
+	OP_UI32_UI64:     "ui32.ui64",
+	OP_UI32_F32:      "ui32.f32",
+	OP_UI32_F64:      "ui32.f64",
+	OP_UI32_STR:      "ui32.str",
+	OP_UI32_FORMAT:   "ui32.format",
+	OP_UI32_PRINT:    "ui32.print",
+	OP_UI32_ADD:      "ui32.add",
+	OP_UI32_SUB:      "ui32.sub",
+	OP_UI32_MUL:      "ui32.mul",
+	OP_UI32_DIV:      "ui32.div",
+	OP_UI32_MOD:      "ui32.mod",
+	OP_UI32_GT:       "ui32.gt",
+	OP_UI32_GTEQ:     "ui32.gteq",
+	OP_UI32_LT:       "ui32.lt",
+	OP_UI32_LTEQ:     "ui32.lteq",
+	OP_UI32_EQ:       "ui32.eq",
+	OP_UI32_UNEQ:     "ui32.uneq",
+	OP_UI32_BITAND:   "ui32.bitand",
+	OP_UI32_BITOR:    "ui32.bitor",
+	OP_UI32_BITXOR:   "ui32.bitxor",
+	OP_UI32_BITCLEAR: "ui32.bitclear",
+	OP_UI32_BITSHL:   "ui32.bitshl",
+	OP_UI32_BITSHR:   "ui32.bitshr",
+	OP_UI32_MAX:      "ui32.max",
+	OP_UI32_MIN:      "ui32.min",
+
+	OP_UI64_BYTE:     "ui64.byte",
+	OP_UI64_I8:       "ui64.i8",
+	OP_UI64_I16:      "ui64.i16",
+	OP_UI64_I32:      "ui64.i32",
+	OP_UI64_I64:      "ui64.i64",
+	OP_UI64_UI8:      "ui64.ui8",
+	OP_UI64_UI16:     "ui64.ui16",
+	OP_UI64_UI32:     "ui64.ui32",
+	OP_UI64_UI64:     "ui64.ui64",
+	OP_UI64_F32:      "ui64.f32",
+	OP_UI64_F64:      "ui64.f64",
+	OP_UI64_STR:      "ui64.str",
+	OP_UI64_FORMAT:   "ui64.format",
+	OP_UI64_PRINT:    "ui64.print",
+	OP_UI64_ADD:      "ui64.add",
+	OP_UI64_SUB:      "ui64.sub",
+	OP_UI64_MUL:      "ui64.mul",
+	OP_UI64_DIV:      "ui64.div",
+	OP_UI64_MOD:      "ui64.mod",
+	OP_UI64_GT:       "ui64.gt",
+	OP_UI64_GTEQ:     "ui64.gteq",
+	OP_UI64_LT:       "ui64.lt",
+	OP_UI64_LTEQ:     "ui64.lteq",
+	OP_UI64_EQ:       "ui64.eq",
+	OP_UI64_UNEQ:     "ui64.uneq",
+	OP_UI64_BITAND:   "ui64.bitand",
+	OP_UI64_BITOR:    "ui64.bitor",
+	OP_UI64_BITXOR:   "ui64.bitxor",
+	OP_UI64_BITCLEAR: "ui64.bitclear",
+	OP_UI64_BITSHL:   "ui64.bitshl",
+	OP_UI64_BITSHR:   "ui64.bitshr",
+	OP_UI64_MAX:      "ui64.max",
+	OP_UI64_MIN:      "ui64.min",
 
 	OP_STR_PRINT: "str.print",
 	OP_STR_EQ: "str.eq",
+	OP_STR_I32: "str.i32",
+	OP_STR_I64: "str.i64",
+	OP_STR_F32: "str.f32",
+	OP_STR_F64: "str.f64",
+	OP_STR_BOOL: "str.bool",
 
 	OP_TIME_SLEEP:      "time.Sleep",
 	OP_TIME_UNIX_MILLI: "time.UnixMilli",
@@ -925,6 +2297,7 @@ var OpNames map[int]string = map[int]string{
 	OP_TEST_START: "test.start",
 	OP_TEST_STOP:  "test.stop",
 	OP_TEST_ERROR: "test.error",
+	OP_TEST_VALUE: "test.value",
 
 	OP_ASSERT: "assert",
 
@@ -1000,9 +2373,116 @@ var OpNames map[int]string = map[int]string{
 	OP_GLFW_SET_INPUT_MODE:            "glfw.SetInputMode",
 	// http
 	OP_HTTP_GET:                       "http.Get",
+	OP_HTTP_POST:                      "http.Post",
+	OP_HTTP_DO:                        "http.Do",
+	OP_HTTP_HANDLE:                    "http.Handle",
+	OP_HTTP_LISTEN_AND_SERVE:          "http.ListenAndServe",
+
+	// net
+	OP_NET_DIAL:                       "net.Dial",
+	OP_NET_LISTEN:                     "net.Listen",
+	OP_NET_LISTEN_PACKET:              "net.ListenPacket",
+	OP_NET_ACCEPT:                     "net.Accept",
+	OP_NET_READ:                       "net.Read",
+	OP_NET_WRITE:                      "net.Write",
+	OP_NET_WRITE_STRING:               "net.WriteString",
+	OP_NET_READ_FROM:                  "net.ReadFrom",
+	OP_NET_WRITE_TO:                   "net.WriteTo",
+	OP_NET_LOCAL_ADDR:                 "net.LocalAddr",
+	OP_NET_CLOSE:                      "net.Close",
+
+	// json
+	OP_JSON_MARSHAL:                   "json.Marshal",
+	OP_JSON_UNMARSHAL:                 "json.Unmarshal",
+	OP_JSON_FIELD:                     "json.Field",
+	OP_JSON_NAMING:                    "json.Naming",
+	OP_JSON_PARSE:                     "json.Parse",
+	OP_JSON_KIND:                      "json.Kind",
+	OP_JSON_GET:                       "json.Get",
+	OP_JSON_INDEX:                     "json.Index",
+	OP_JSON_LEN:                       "json.Len",
+	OP_JSON_KEYS:                      "json.Keys",
+	OP_JSON_STR:                       "json.Str",
+	OP_JSON_BOOL:                      "json.Bool",
+	OP_JSON_I32:                       "json.I32",
+	OP_JSON_I64:                       "json.I64",
+	OP_JSON_F64:                       "json.F64",
+
+	// regexp
+	OP_REGEXP_COMPILE:                 "regexp.Compile",
+	OP_REGEXP_MATCH_STRING:            "regexp.MatchString",
+	OP_REGEXP_FIND_STRING:             "regexp.FindString",
+	OP_REGEXP_FIND_ALL_STRING:         "regexp.FindAllString",
+	OP_REGEXP_FIND_STRING_SUBMATCH:    "regexp.FindStringSubmatch",
+	OP_REGEXP_REPLACE_ALL_STRING:      "regexp.ReplaceAllString",
+
+	// crypto
+	OP_CRYPTO_SHA256:                  "crypto.SHA256",
+	OP_CRYPTO_RIPEMD160:               "crypto.RIPEMD160",
+	OP_HEX_ENCODE:                     "hex.Encode",
+	OP_HEX_DECODE:                     "hex.Decode",
+	OP_BASE58_ENCODE:                  "base58.Encode",
+	OP_BASE58_DECODE:                  "base58.Decode",
+	OP_BASE64_ENCODE:                  "base64.Encode",
+	OP_BASE64_DECODE:                  "base64.Decode",
+	OP_CIPHER_GENERATE_KEY_PAIR:       "cipher.GenerateKeyPair",
+	OP_CIPHER_GENERATE_DETERMINISTIC_KEY_PAIR:"cipher.GenerateDeterministicKeyPair",
+	OP_CIPHER_PUB_KEY_FROM_SEC_KEY:    "cipher.PubKeyFromSecKey",
+	OP_CIPHER_ADDRESS_FROM_PUB_KEY:    "cipher.AddressFromPubKey",
+	OP_CIPHER_SIGN_HASH:               "cipher.SignHash",
+	OP_CIPHER_VERIFY_SIGNATURE:        "cipher.VerifySignature",
+	OP_CIPHER_CHK_SIG:                 "cipher.ChkSig",
 
 	// os
 	OP_OS_GET_WORKING_DIRECTORY:       "os.GetWorkingDirectory",
+	OP_OS_READ_FILE:                   "os.ReadFile",
+	OP_OS_WRITE_FILE:                  "os.WriteFile",
+	OP_OS_READ_FILE_BYTES:             "os.ReadFileBytes",
+	OP_OS_WRITE_FILE_BYTES:            "os.WriteFileBytes",
+	OP_OS_OPEN:                        "os.Open",
+	OP_OS_CREATE:                      "os.Create",
+	OP_OS_READ:                        "os.Read",
+	OP_OS_WRITE:                       "os.Write",
+	OP_OS_WRITE_STRING:                "os.WriteString",
+	OP_OS_SEEK:                        "os.Seek",
+	OP_OS_CLOSE:                       "os.Close",
+	OP_OS_READ_DIR:                    "os.ReadDir",
+	OP_OS_STAT:                        "os.Stat",
+	OP_OS_MKDIR:                       "os.Mkdir",
+	OP_OS_REMOVE:                      "os.Remove",
+	OP_OS_RENAME:                      "os.Rename",
+	OP_OS_ARGS:                        "os.Args",
+	OP_OS_GETENV:                      "os.Getenv",
+	OP_OS_SETENV:                      "os.Setenv",
+	OP_OS_EXIT:                        "os.Exit",
+
+	// read
+	OP_READ_LINE:                      "read.line",
+	OP_READ_ALL:                       "read.all",
+	OP_READ_STR:                       "read.str",
+	OP_READ_I32:                       "read.i32",
+	OP_READ_I64:                       "read.i64",
+	OP_READ_F32:                       "read.f32",
+	OP_READ_F64:                       "read.f64",
+
+	// strings
+	OP_STRINGS_INDEX:                  "strings.Index",
+	OP_STRINGS_LAST_INDEX:             "strings.LastIndex",
+	OP_STRINGS_CONTAINS:               "strings.Contains",
+	OP_STRINGS_HAS_PREFIX:             "strings.HasPrefix",
+	OP_STRINGS_HAS_SUFFIX:             "strings.HasSuffix",
+	OP_STRINGS_SPLIT:                  "strings.Split",
+	OP_STRINGS_JOIN:                   "strings.Join",
+	OP_STRINGS_SUBSTRING:              "strings.Substring",
+	OP_STRINGS_REPLACE:                "strings.Replace",
+	OP_STRINGS_TRIM:                   "strings.Trim",
+	OP_STRINGS_TRIM_SPACE:             "strings.TrimSpace",
+	OP_STRINGS_TO_UPPER:               "strings.ToUpper",
+	OP_STRINGS_TO_LOWER:               "strings.ToLower",
+	OP_STRINGS_REPEAT:                 "strings.Repeat",
+	OP_STRINGS_RUNE_COUNT:             "strings.RuneCount",
+	OP_STRINGS_DECODE_RUNE:            "strings.DecodeRune",
+	OP_STRINGS_ENCODE_RUNE:            "strings.EncodeRune",
 }
 
 // For the parser. These shouldn't be used in the runtime for performance reasons
@@ -1033,6 +2513,18 @@ var OpCodes map[string]int = map[string]int{
 	"sprintf":  OP_UND_SPRINTF,
 
 	"byte.print": OP_BYTE_PRINT,
+	"byte.byte":  OP_BYTE_BYTE,
+	"byte.str":   OP_BYTE_STR,
+	"byte.i8":    OP_BYTE_I8,
+	"byte.i16":   OP_BYTE_I16,
+	"byte.i32":   OP_BYTE_I32,
+	"byte.i64":   OP_BYTE_I64,
+	"byte.ui8":   OP_BYTE_UI8,
+	"byte.ui16":  OP_BYTE_UI16,
+	"byte.ui32":  OP_BYTE_UI32,
+	"byte.ui64":  OP_BYTE_UI64,
+	"byte.f32":   OP_BYTE_F32,
+	"byte.f64":   OP_BYTE_F64,
 
 	"bool.print": OP_BOOL_PRINT,
 	"bool.eq":    OP_BOOL_EQUAL,
@@ -1047,6 +2539,13 @@ var OpCodes map[string]int = map[string]int{
 	"i32.i64":      OP_I32_I64,
 	"i32.f32":      OP_I32_F32,
 	"i32.f64":      OP_I32_F64,
+	"i32.i8":      OP_I32_I8,
+	"i32.i16":     OP_I32_I16,
+	"i32.ui8":     OP_I32_UI8,
+	"i32.ui16":    OP_I32_UI16,
+	"i32.ui32":    OP_I32_UI32,
+	"i32.ui64":    OP_I32_UI64,
+	"i32.format":  OP_I32_FORMAT,
 	"i32.print":    OP_I32_PRINT,
 	"i32.add":      OP_I32_ADD,
 	"i32.sub":      OP_I32_SUB,
@@ -1104,6 +2603,19 @@ var OpCodes map[string]int = map[string]int{
 	"i64.max":      OP_I64_MAX,
 	"i64.min":      OP_I64_MIN,
 	"i64.cos":      OP_I64_COS,
+	"i64.byte":     OP_I64_BYTE,
+	"i64.str":      OP_I64_STR,
+	"i64.i8":       OP_I64_I8,
+	"i64.i16":      OP_I64_I16,
+	"i64.i32":      OP_I64_I32,
+	"i64.i64":      OP_I64_I64,
+	"i64.ui8":      OP_I64_UI8,
+	"i64.ui16":     OP_I64_UI16,
+	"i64.ui32":     OP_I64_UI32,
+	"i64.ui64":     OP_I64_UI64,
+	"i64.f32":      OP_I64_F32,
+	"i64.f64":      OP_I64_F64,
+	"i64.format":   OP_I64_FORMAT,
 	"i64.sin":      OP_I64_SIN,
 	"f32.byte":     OP_F32_BYTE,
 	"f32.str":      OP_F32_STR,
@@ -1111,6 +2623,13 @@ var OpCodes map[string]int = map[string]int{
 	"f32.i64":      OP_F32_I64,
 	"f32.f32":      OP_F32_F32,
 	"f32.f64":      OP_F32_F64,
+	"f32.i8":      OP_F32_I8,
+	"f32.i16":     OP_F32_I16,
+	"f32.ui8":     OP_F32_UI8,
+	"f32.ui16":    OP_F32_UI16,
+	"f32.ui32":    OP_F32_UI32,
+	"f32.ui64":    OP_F32_UI64,
+	"f32.format":  OP_F32_FORMAT,
 	"f32.print":    OP_F32_PRINT,
 	"f32.add":      OP_F32_ADD,
 	"f32.sub":      OP_F32_SUB,
@@ -1153,9 +2672,233 @@ var OpCodes map[string]int = map[string]int{
 	"f64.log10":    OP_F64_LOG10,
 	"f64.max":      OP_F64_MAX,
 	"f64.min":      OP_F64_MIN,
+	"f64.byte":     OP_F64_BYTE,
+	"f64.str":      OP_F64_STR,
+	"f64.i8":       OP_F64_I8,
+	"f64.i16":      OP_F64_I16,
+	"f64.i32":      OP_F64_I32,
+	"f64.i64":      OP_F64_I64,
+	"f64.ui8":      OP_F64_UI8,
+	"f64.ui16":     OP_F64_UI16,
+	"f64.ui32":     OP_F64_UI32,
+	"f64.ui64":     OP_F64_UI64,
+	"f64.f32":      OP_F64_F32,
+	"f64.f64":      OP_F64_F64,
+	"f64.format":   OP_F64_FORMAT,
+
+	"i8.byte":     OP_I8_BYTE,
+	"i8.i8":       OP_I8_I8,
+	"i8.i16":      OP_I8_I16,
+	"i8.i32":      OP_I8_I32,
+	"i8.i64":      OP_I8_I64,
+	"i8.ui8":      OP_I8_UI8,
+	"i8.ui16":     OP_I8_UI16,
+	"i8.ui32":     OP_I8_UI32,
+	"i8.ui64":     OP_I8_UI64,
+	"i8.f32":      OP_I8_F32,
+	"i8.f64":      OP_I8_F64,
+	"i8.str":      OP_I8_STR,
+	"i8.format":   OP_I8_FORMAT,
+	"i8.print":    OP_I8_PRINT,
+	"i8.add":      OP_I8_ADD,
+	"i8.sub":      OP_I8_SUB,
+	"i8.mul":      OP_I8_MUL,
+	"i8.div":      OP_I8_DIV,
+	"i8.mod":      OP_I8_MOD,
+	"i8.abs":      OP_I8_ABS,
+	"i8.gt":       OP_I8_GT,
+	"i8.gteq":     OP_I8_GTEQ,
+	"i8.lt":       OP_I8_LT,
+	"i8.lteq":     OP_I8_LTEQ,
+	"i8.eq":       OP_I8_EQ,
+	"i8.uneq":     OP_I8_UNEQ,
+	"i8.bitand":   OP_I8_BITAND,
+	"i8.bitor":    OP_I8_BITOR,
+	"i8.bitxor":   OP_I8_BITXOR,
+	"i8.bitclear": OP_I8_BITCLEAR,
+	"i8.bitshl":   OP_I8_BITSHL,
+	"i8.bitshr":   OP_I8_BITSHR,
+	"i8.max":      OP_I8_MAX,
+	"i8.min":      OP_I8_MIN,
+
+	"i16.byte":     OP_I16_BYTE,
+	"i16.i8":       OP_I16_I8,
+	"i16.i16":      OP_I16_I16,
+	"i16.i32":      OP_I16_I32,
+	"i16.i64":      OP_I16_I64,
+	"i16.ui8":      OP_I16_UI8,
+	"i16.ui16":     OP_I16_UI16,
+	"i16.ui32":     OP_I16_UI32,
+	"i16.ui64":     OP_I16_UI64,
+	"i16.f32":      OP_I16_F32,
+	"i16.f64":      OP_I16_F64,
+	"i16.str":      OP_I16_STR,
+	"i16.format":   OP_I16_FORMAT,
+	"i16.print":    OP_I16_PRINT,
+	"i16.add":      OP_I16_ADD,
+	"i16.sub":      OP_I16_SUB,
+	"i16.mul":      OP_I16_MUL,
+	"i16.div":      OP_I16_DIV,
+	"i16.mod":      OP_I16_MOD,
+	"i16.abs":      OP_I16_ABS,
+	"i16.gt":       OP_I16_GT,
+	"i16.gteq":     OP_I16_GTEQ,
+	"i16.lt":       OP_I16_LT,
+	"i16.lteq":     OP_I16_LTEQ,
+	"i16.eq":       OP_I16_EQ,
+	"i16.uneq":     OP_I16_UNEQ,
+	"i16.bitand":   OP_I16_BITAND,
+	"i16.bitor":    OP_I16_BITOR,
+	"i16.bitxor":   OP_I16_BITXOR,
+	"i16.bitclear": OP_I16_BITCLEAR,
+	"i16.bitshl":   OP_I16_BITSHL,
+	"i16.bitshr":   OP_I16_BITSHR,
+	"i16.max":      OP_I16_MAX,
+	"i16.min":      OP_I16_MIN,
+
+	"ui8.byte":     OP_UI8_BYTE,
+	"ui8.i8":       OP_UI8_I8,
+	"ui8.i16":      OP_UI8_I16,
+	"ui8.i32":      OP_UI8_I32,
+	"ui8.i64":      OP_UI8_I64,
+	"ui8.ui8":      OP_UI8_UI8,
+	"ui8.ui16":     OP_UI8_UI16,
+	"ui8.ui32":     OP_UI8_UI32,
+	"ui8.ui64":     OP_UI8_UI64,
+	"ui8.f32":      OP_UI8_F32,
+	"ui8.f64":      OP_UI8_F64,
+	"ui8.str":      OP_UI8_STR,
+	"ui8.format":   OP_UI8_FORMAT,
+	"ui8.print":    OP_UI8_PRINT,
+	"ui8.add":      OP_UI8_ADD,
+	"ui8.sub":      OP_UI8_SUB,
+	"ui8.mul":      OP_UI8_MUL,
+	"ui8.div":      OP_UI8_DIV,
+	"ui8.mod":      OP_UI8_MOD,
+	"ui8.gt":       OP_UI8_GT,
+	"ui8.gteq":     OP_UI8_GTEQ,
+	"ui8.lt":       OP_UI8_LT,
+	"ui8.lteq":     OP_UI8_LTEQ,
+	"ui8.eq":       OP_UI8_EQ,
+	"ui8.uneq":     OP_UI8_UNEQ,
+	"ui8.bitand":   OP_UI8_BITAND,
+	"ui8.bitor":    OP_UI8_BITOR,
+	"ui8.bitxor":   OP_UI8_BITXOR,
+	"ui8.bitclear": OP_UI8_BITCLEAR,
+	"ui8.bitshl":   OP_UI8_BITSHL,
+	"ui8.bitshr":   OP_UI8_BITSHR,
+	"ui8.max":      OP_UI8_MAX,
+	"ui8.min":      OP_UI8_MIN,
+
+	"ui16.byte":     OP_UI16_BYTE,
+	"ui16.i8":       OP_UI16_I8,
+	"ui16.i16":      OP_UI16_I16,
+	"ui16.i32":      OP_UI16_I32,
+	"ui16.i64":      OP_UI16_I64,
+	"ui16.ui8":      OP_UI16_UI8,
+	"ui16.ui16":     OP_UI16_UI16,
+	"ui16.ui32":     OP_UI16_UI32,
+	"ui16.ui64":     OP_UI16_UI64,
+	"ui16.f32":      OP_UI16_F32,
+	"ui16.f64":      OP_UI16_F64,
+	"ui16.str":      OP_UI16_STR,
+	"ui16.format":   OP_UI16_FORMAT,
+	"ui16.print":    OP_UI16_PRINT,
+	"ui16.add":      OP_UI16_ADD,
+	"ui16.sub":      OP_UI16_SUB,
+	"ui16.mul":      OP_UI16_MUL,
+	"ui16.div":      OP_UI16_DIV,
+	"ui16.mod":      OP_UI16_MOD,
+	"ui16.gt":       OP_UI16_GT,
+	"ui16.gteq":     OP_UI16_GTEQ,
+	"ui16.lt":       OP_UI16_LT,
+	"ui16.lteq":     OP_UI16_LTEQ,
+	"ui16.eq":       OP_UI16_EQ,
+	"ui16.uneq":     OP_UI16_UNEQ,
+	"ui16.bitand":   OP_UI16_BITAND,
+	"ui16.bitor":    OP_UI16_BITOR,
+	"ui16.bitxor":   OP_UI16_BITXOR,
+	"ui16.bitclear": OP_UI16_BITCLEAR,
+	"ui16.bitshl":   OP_UI16_BITSHL,
+	"ui16.bitshr":   OP_UI16_BITSHR,
+	"ui16.max":      OP_UI16_MAX,
+	"ui16.min":      OP_UI16_MIN,
+
+	"ui32.byte":     OP_UI32_BYTE,
+	"ui32.i8":       OP_UI32_I8,
+	"ui32.i16":      OP_UI32_I16,
+	"ui32.i32":      OP_UI32_I32,
+	"ui32.i64":      OP_UI32_I64,
+	"ui32.ui8":      OP_UI32_UI8,
+	"ui32.ui16":     OP_UI32_UI16,
+	"ui32.ui32":     OP_UI32_UI32,
+	"ui32.ui64":     OP_UI32_UI64,
+	"ui32.f32":      OP_UI32_F32,
+	"ui32.f64":      OP_UI32_F64,
+	"ui32.str":      OP_UI32_STR,
+	"ui32.format":   OP_UI32_FORMAT,
+	"ui32.print":    OP_UI32_PRINT,
+	"ui32.add":      OP_UI32_ADD,
+	"ui32.sub":      OP_UI32_SUB,
+	"ui32.mul":      OP_UI32_MUL,
+	"ui32.div":      OP_UI32_DIV,
+	"ui32.mod":      OP_UI32_MOD,
+	"ui32.gt":       OP_UI32_GT,
+	"ui32.gteq":     OP_UI32_GTEQ,
+	"ui32.lt":       OP_UI32_LT,
+	"ui32.lteq":     OP_UI32_LTEQ,
+	"ui32.eq":       OP_UI32_EQ,
+	"ui32.uneq":     OP_UI32_UNEQ,
+	"ui32.bitand":   OP_UI32_BITAND,
+	"ui32.bitor":    OP_UI32_BITOR,
+	"ui32.bitxor":   OP_UI32_BITXOR,
+	"ui32.bitclear": OP_UI32_BITCLEAR,
+	"ui32.bitshl":   OP_UI32_BITSHL,
+	"ui32.bitshr":   OP_UI32_BITSHR,
+	"ui32.max":      OP_UI32_MAX,
+	"ui32.min":      OP_UI32_MIN,
+
+	"ui64.byte":     OP_UI64_BYTE,
+	"ui64.i8":       OP_UI64_I8,
+	"ui64.i16":      OP_UI64_I16,
+	"ui64.i32":      OP_UI64_I32,
+	"ui64.i64":      OP_UI64_I64,
+	"ui64.ui8":      OP_UI64_UI8,
+	"ui64.ui16":     OP_UI64_UI16,
+	"ui64.ui32":     OP_UI64_UI32,
+	"ui64.ui64":     OP_UI64_UI64,
+	"ui64.f32":      OP_UI64_F32,
+	"ui64.f64":      OP_UI64_F64,
+	"ui64.str":      OP_UI64_STR,
+	"ui64.format":   OP_UI64_FORMAT,
+	"ui64.print":    OP_UI64_PRINT,
+	"ui64.add":      OP_UI64_ADD,
+	"ui64.sub":      OP_UI64_SUB,
+	"ui64.mul":      OP_UI64_MUL,
+	"ui64.div":      OP_UI64_DIV,
+	"ui64.mod":      OP_UI64_MOD,
+	"ui64.gt":       OP_UI64_GT,
+	"ui64.gteq":     OP_UI64_GTEQ,
+	"ui64.lt":       OP_UI64_LT,
+	"ui64.lteq":     OP_UI64_LTEQ,
+	"ui64.eq":       OP_UI64_EQ,
+	"ui64.uneq":     OP_UI64_UNEQ,
+	"ui64.bitand":   OP_UI64_BITAND,
+	"ui64.bitor":    OP_UI64_BITOR,
+	"ui64.bitxor":   OP_UI64_BITXOR,
+	"ui64.bitclear": OP_UI64_BITCLEAR,
+	"ui64.bitshl":   OP_UI64_BITSHL,
+	"ui64.bitshr":   OP_UI64_BITSHR,
+	"ui64.max":      OP_UI64_MAX,
+	"ui64.min":      OP_UI64_MIN,
 
 	"str.print": OP_STR_PRINT,
 	"str.eq": OP_STR_EQ,
+	"str.i32": OP_STR_I32,
+	"str.i64": OP_STR_I64,
+	"str.f32": OP_STR_F32,
+	"str.f64": OP_STR_F64,
+	"str.bool": OP_STR_BOOL,
 
 	"time.Sleep":     OP_TIME_SLEEP,
 	"time.UnixMilli": OP_TIME_UNIX_MILLI,
@@ -1163,6 +2906,8 @@ var OpCodes map[string]int = map[string]int{
 
 	"test.start": OP_TEST_START,
 	"test.stop":  OP_TEST_STOP,
+	"test.error": OP_TEST_ERROR,
+	"test.value": OP_TEST_VALUE,
 	"assert":       OP_ASSERT,
 
 	// opengl
@@ -1237,8 +2982,109 @@ var OpCodes map[string]int = map[string]int{
 	"glfw.SetInputMode":           OP_GLFW_SET_INPUT_MODE,
 	// http
 	"http.Get":                    OP_HTTP_GET,
+	"http.Post":                   OP_HTTP_POST,
+	"http.Do":                     OP_HTTP_DO,
+	"http.Handle":                 OP_HTTP_HANDLE,
+	"http.ListenAndServe":         OP_HTTP_LISTEN_AND_SERVE,
+	// net
+	"net.Dial":                    OP_NET_DIAL,
+	"net.Listen":                  OP_NET_LISTEN,
+	"net.ListenPacket":            OP_NET_LISTEN_PACKET,
+	"net.Accept":                  OP_NET_ACCEPT,
+	"net.Read":                    OP_NET_READ,
+	"net.Write":                   OP_NET_WRITE,
+	"net.WriteString":             OP_NET_WRITE_STRING,
+	"net.ReadFrom":                OP_NET_READ_FROM,
+	"net.WriteTo":                 OP_NET_WRITE_TO,
+	"net.LocalAddr":               OP_NET_LOCAL_ADDR,
+	"net.Close":                   OP_NET_CLOSE,
+	// json
+	"json.Marshal":                OP_JSON_MARSHAL,
+	"json.Unmarshal":              OP_JSON_UNMARSHAL,
+	"json.Field":                  OP_JSON_FIELD,
+	"json.Naming":                 OP_JSON_NAMING,
+	"json.Parse":                  OP_JSON_PARSE,
+	"json.Kind":                   OP_JSON_KIND,
+	"json.Get":                    OP_JSON_GET,
+	"json.Index":                  OP_JSON_INDEX,
+	"json.Len":                    OP_JSON_LEN,
+	"json.Keys":                   OP_JSON_KEYS,
+	"json.Str":                    OP_JSON_STR,
+	"json.Bool":                   OP_JSON_BOOL,
+	"json.I32":                    OP_JSON_I32,
+	"json.I64":                    OP_JSON_I64,
+	"json.F64":                    OP_JSON_F64,
+	// regexp
+	"regexp.Compile":              OP_REGEXP_COMPILE,
+	"regexp.MatchString":          OP_REGEXP_MATCH_STRING,
+	"regexp.FindString":           OP_REGEXP_FIND_STRING,
+	"regexp.FindAllString":        OP_REGEXP_FIND_ALL_STRING,
+	"regexp.FindStringSubmatch":   OP_REGEXP_FIND_STRING_SUBMATCH,
+	"regexp.ReplaceAllString":     OP_REGEXP_REPLACE_ALL_STRING,
+	// crypto
+	"crypto.SHA256":               OP_CRYPTO_SHA256,
+	"crypto.RIPEMD160":            OP_CRYPTO_RIPEMD160,
+	"hex.Encode":                  OP_HEX_ENCODE,
+	"hex.Decode":                  OP_HEX_DECODE,
+	"base58.Encode":               OP_BASE58_ENCODE,
+	"base58.Decode":               OP_BASE58_DECODE,
+	"base64.Encode":               OP_BASE64_ENCODE,
+	"base64.Decode":               OP_BASE64_DECODE,
+	"cipher.GenerateKeyPair":      OP_CIPHER_GENERATE_KEY_PAIR,
+	"cipher.GenerateDeterministicKeyPair":OP_CIPHER_GENERATE_DETERMINISTIC_KEY_PAIR,
+	"cipher.PubKeyFromSecKey":     OP_CIPHER_PUB_KEY_FROM_SEC_KEY,
+	"cipher.AddressFromPubKey":    OP_CIPHER_ADDRESS_FROM_PUB_KEY,
+	"cipher.SignHash":             OP_CIPHER_SIGN_HASH,
+	"cipher.VerifySignature":      OP_CIPHER_VERIFY_SIGNATURE,
+	"cipher.ChkSig":               OP_CIPHER_CHK_SIG,
 	// os
 	"os.GetWorkingDirectory":      OP_OS_GET_WORKING_DIRECTORY,
+	"os.ReadFile":                 OP_OS_READ_FILE,
+	"os.WriteFile":                OP_OS_WRITE_FILE,
+	"os.ReadFileBytes":            OP_OS_READ_FILE_BYTES,
+	"os.WriteFileBytes":           OP_OS_WRITE_FILE_BYTES,
+	"os.Open":                     OP_OS_OPEN,
+	"os.Create":                   OP_OS_CREATE,
+	"os.Read":                     OP_OS_READ,
+	"os.Write":                    OP_OS_WRITE,
+	"os.WriteString":              OP_OS_WRITE_STRING,
+	"os.Seek":                     OP_OS_SEEK,
+	"os.Close":                    OP_OS_CLOSE,
+	"os.ReadDir":                  OP_OS_READ_DIR,
+	"os.Stat":                     OP_OS_STAT,
+	"os.Mkdir":                    OP_OS_MKDIR,
+	"os.Remove":                   OP_OS_REMOVE,
+	"os.Rename":                   OP_OS_RENAME,
+	"os.Args":                     OP_OS_ARGS,
+	"os.Getenv":                   OP_OS_GETENV,
+	"os.Setenv":                   OP_OS_SETENV,
+	"os.Exit":                     OP_OS_EXIT,
+	// read
+	"read.line":                   OP_READ_LINE,
+	"read.all":                    OP_READ_ALL,
+	"read.str":                    OP_READ_STR,
+	"read.i32":                    OP_READ_I32,
+	"read.i64":                    OP_READ_I64,
+	"read.f32":                    OP_READ_F32,
+	"read.f64":                    OP_READ_F64,
+	// strings
+	"strings.Index":               OP_STRINGS_INDEX,
+	"strings.LastIndex":           OP_STRINGS_LAST_INDEX,
+	"strings.Contains":            OP_STRINGS_CONTAINS,
+	"strings.HasPrefix":           OP_STRINGS_HAS_PREFIX,
+	"strings.HasSuffix":           OP_STRINGS_HAS_SUFFIX,
+	"strings.Split":               OP_STRINGS_SPLIT,
+	"strings.Join":                OP_STRINGS_JOIN,
+	"strings.Substring":           OP_STRINGS_SUBSTRING,
+	"strings.Replace":             OP_STRINGS_REPLACE,
+	"strings.Trim":                OP_STRINGS_TRIM,
+	"strings.TrimSpace":           OP_STRINGS_TRIM_SPACE,
+	"strings.ToUpper":             OP_STRINGS_TO_UPPER,
+	"strings.ToLower":             OP_STRINGS_TO_LOWER,
+	"strings.Repeat":              OP_STRINGS_REPEAT,
+	"strings.RuneCount":           OP_STRINGS_RUNE_COUNT,
+	"strings.DecodeRune":          OP_STRINGS_DECODE_RUNE,
+	"strings.EncodeRune":          OP_STRINGS_ENCODE_RUNE,
 }
 
 var Natives map[int]*CXFunction = map[int]*CXFunction{
@@ -1269,6 +3115,18 @@ var Natives map[int]*CXFunction = map[int]*CXFunction{
 	OP_UND_SPRINTF:  MakeNative(OP_UND_SPRINTF, []int{TYPE_UNDEFINED}, []int{TYPE_STR}),
 
 	OP_BYTE_PRINT:   MakeNative(OP_BYTE_PRINT, []int{TYPE_BYTE}, []int{}),
+	OP_BYTE_BYTE:    MakeNative(OP_BYTE_BYTE, []int{TYPE_BYTE}, []int{TYPE_BYTE}),
+	OP_BYTE_STR:     MakeNative(OP_BYTE_STR, []int{TYPE_BYTE}, []int{TYPE_STR}),
+	OP_BYTE_I8:      MakeNative(OP_BYTE_I8, []int{TYPE_BYTE}, []int{TYPE_I8}),
+	OP_BYTE_I16:     MakeNative(OP_BYTE_I16, []int{TYPE_BYTE}, []int{TYPE_I16}),
+	OP_BYTE_I32:     MakeNative(OP_BYTE_I32, []int{TYPE_BYTE}, []int{TYPE_I32}),
+	OP_BYTE_I64:     MakeNative(OP_BYTE_I64, []int{TYPE_BYTE}, []int{TYPE_I64}),
+	OP_BYTE_UI8:     MakeNative(OP_BYTE_UI8, []int{TYPE_BYTE}, []int{TYPE_UI8}),
+	OP_BYTE_UI16:    MakeNative(OP_BYTE_UI16, []int{TYPE_BYTE}, []int{TYPE_UI16}),
+	OP_BYTE_UI32:    MakeNative(OP_BYTE_UI32, []int{TYPE_BYTE}, []int{TYPE_UI32}),
+	OP_BYTE_UI64:    MakeNative(OP_BYTE_UI64, []int{TYPE_BYTE}, []int{TYPE_UI64}),
+	OP_BYTE_F32:     MakeNative(OP_BYTE_F32, []int{TYPE_BYTE}, []int{TYPE_F32}),
+	OP_BYTE_F64:     MakeNative(OP_BYTE_F64, []int{TYPE_BYTE}, []int{TYPE_F64}),
 
 	OP_BOOL_PRINT:   MakeNative(OP_BOOL_PRINT, []int{TYPE_BOOL}, []int{}),
 	OP_BOOL_EQUAL:   MakeNative(OP_BOOL_EQUAL, []int{TYPE_BOOL, TYPE_BOOL}, []int{TYPE_BOOL}),
@@ -1283,6 +3141,13 @@ var Natives map[int]*CXFunction = map[int]*CXFunction{
 	OP_I32_I64:      MakeNative(OP_I32_I64, []int{TYPE_I32}, []int{TYPE_I64}),
 	OP_I32_F32:      MakeNative(OP_I32_F32, []int{TYPE_I32}, []int{TYPE_F32}),
 	OP_I32_F64:      MakeNative(OP_I32_F64, []int{TYPE_I32}, []int{TYPE_F64}),
+	OP_I32_I8:       MakeNative(OP_I32_I8, []int{TYPE_I32}, []int{TYPE_I8}),
+	OP_I32_I16:      MakeNative(OP_I32_I16, []int{TYPE_I32}, []int{TYPE_I16}),
+	OP_I32_UI8:      MakeNative(OP_I32_UI8, []int{TYPE_I32}, []int{TYPE_UI8}),
+	OP_I32_UI16:     MakeNative(OP_I32_UI16, []int{TYPE_I32}, []int{TYPE_UI16}),
+	OP_I32_UI32:     MakeNative(OP_I32_UI32, []int{TYPE_I32}, []int{TYPE_UI32}),
+	OP_I32_UI64:     MakeNative(OP_I32_UI64, []int{TYPE_I32}, []int{TYPE_UI64}),
+	OP_I32_FORMAT:   MakeNative(OP_I32_FORMAT, []int{TYPE_I32, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
 
 	OP_I32_PRINT:    MakeNative(OP_I32_PRINT, []int{TYPE_I32}, []int{}),
 	OP_I32_ADD:      MakeNative(OP_I32_ADD, []int{TYPE_I32, TYPE_I32}, []int{TYPE_I32}),
@@ -1341,6 +3206,19 @@ var Natives map[int]*CXFunction = map[int]*CXFunction{
 	OP_I64_MAX:      MakeNative(OP_I64_MAX, []int{TYPE_I64}, []int{TYPE_I64}),
 	OP_I64_MIN:      MakeNative(OP_I64_MIN, []int{TYPE_I64}, []int{TYPE_I64}),
 	OP_I64_COS:      MakeNative(OP_I64_COS, []int{TYPE_I64}, []int{TYPE_I64}),
+	OP_I64_BYTE:     MakeNative(OP_I64_BYTE, []int{TYPE_I64}, []int{TYPE_BYTE}),
+	OP_I64_STR:      MakeNative(OP_I64_STR, []int{TYPE_I64}, []int{TYPE_STR}),
+	OP_I64_I8:       MakeNative(OP_I64_I8, []int{TYPE_I64}, []int{TYPE_I8}),
+	OP_I64_I16:      MakeNative(OP_I64_I16, []int{TYPE_I64}, []int{TYPE_I16}),
+	OP_I64_I32:      MakeNative(OP_I64_I32, []int{TYPE_I64}, []int{TYPE_I32}),
+	OP_I64_I64:      MakeNative(OP_I64_I64, []int{TYPE_I64}, []int{TYPE_I64}),
+	OP_I64_UI8:      MakeNative(OP_I64_UI8, []int{TYPE_I64}, []int{TYPE_UI8}),
+	OP_I64_UI16:     MakeNative(OP_I64_UI16, []int{TYPE_I64}, []int{TYPE_UI16}),
+	OP_I64_UI32:     MakeNative(OP_I64_UI32, []int{TYPE_I64}, []int{TYPE_UI32}),
+	OP_I64_UI64:     MakeNative(OP_I64_UI64, []int{TYPE_I64}, []int{TYPE_UI64}),
+	OP_I64_F32:      MakeNative(OP_I64_F32, []int{TYPE_I64}, []int{TYPE_F32}),
+	OP_I64_F64:      MakeNative(OP_I64_F64, []int{TYPE_I64}, []int{TYPE_F64}),
+	OP_I64_FORMAT:   MakeNative(OP_I64_FORMAT, []int{TYPE_I64, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
 	OP_I64_SIN:      MakeNative(OP_I64_SIN, []int{TYPE_I64}, []int{TYPE_I64}),
 
 	OP_F32_BYTE:     MakeNative(OP_F32_BYTE, []int{TYPE_F32}, []int{TYPE_BYTE}),
@@ -1349,6 +3227,13 @@ var Natives map[int]*CXFunction = map[int]*CXFunction{
 	OP_F32_I64:      MakeNative(OP_F32_I64,  []int{TYPE_F32}, []int{TYPE_I64}),
 	OP_F32_F32:      MakeNative(OP_F32_F32,  []int{TYPE_F32}, []int{TYPE_F32}),
 	OP_F32_F64:      MakeNative(OP_F32_F64,  []int{TYPE_F32}, []int{TYPE_F64}),
+	OP_F32_I8:       MakeNative(OP_F32_I8,  []int{TYPE_F32}, []int{TYPE_I8}),
+	OP_F32_I16:      MakeNative(OP_F32_I16,  []int{TYPE_F32}, []int{TYPE_I16}),
+	OP_F32_UI8:      MakeNative(OP_F32_UI8,  []int{TYPE_F32}, []int{TYPE_UI8}),
+	OP_F32_UI16:     MakeNative(OP_F32_UI16,  []int{TYPE_F32}, []int{TYPE_UI16}),
+	OP_F32_UI32:     MakeNative(OP_F32_UI32,  []int{TYPE_F32}, []int{TYPE_UI32}),
+	OP_F32_UI64:     MakeNative(OP_F32_UI64,  []int{TYPE_F32}, []int{TYPE_UI64}),
+	OP_F32_FORMAT:   MakeNative(OP_F32_FORMAT, []int{TYPE_F32, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
 	
 	OP_F32_PRINT:    MakeNative(OP_F32_PRINT, []int{TYPE_F32}, []int{}),
 	OP_F32_ADD:      MakeNative(OP_F32_ADD, []int{TYPE_F32, TYPE_F32}, []int{TYPE_F32}),
@@ -1391,17 +3276,243 @@ var Natives map[int]*CXFunction = map[int]*CXFunction{
 	OP_F64_LOG2:     MakeNative(OP_F64_LOG2, []int{TYPE_F64}, []int{TYPE_F64}),
 	OP_F64_LOG10:    MakeNative(OP_F64_LOG10, []int{TYPE_F64}, []int{TYPE_F64}),
 	OP_F64_MIN:      MakeNative(OP_F64_MIN, []int{TYPE_F64}, []int{TYPE_F64}),
+	OP_F64_BYTE:     MakeNative(OP_F64_BYTE, []int{TYPE_F64}, []int{TYPE_BYTE}),
+	OP_F64_STR:      MakeNative(OP_F64_STR, []int{TYPE_F64}, []int{TYPE_STR}),
+	OP_F64_I8:       MakeNative(OP_F64_I8, []int{TYPE_F64}, []int{TYPE_I8}),
+	OP_F64_I16:      MakeNative(OP_F64_I16, []int{TYPE_F64}, []int{TYPE_I16}),
+	OP_F64_I32:      MakeNative(OP_F64_I32, []int{TYPE_F64}, []int{TYPE_I32}),
+	OP_F64_I64:      MakeNative(OP_F64_I64, []int{TYPE_F64}, []int{TYPE_I64}),
+	OP_F64_UI8:      MakeNative(OP_F64_UI8, []int{TYPE_F64}, []int{TYPE_UI8}),
+	OP_F64_UI16:     MakeNative(OP_F64_UI16, []int{TYPE_F64}, []int{TYPE_UI16}),
+	OP_F64_UI32:     MakeNative(OP_F64_UI32, []int{TYPE_F64}, []int{TYPE_UI32}),
+	OP_F64_UI64:     MakeNative(OP_F64_UI64, []int{TYPE_F64}, []int{TYPE_UI64}),
+	OP_F64_F32:      MakeNative(OP_F64_F32, []int{TYPE_F64}, []int{TYPE_F32}),
+	OP_F64_F64:      MakeNative(OP_F64_F64, []int{TYPE_F64}, []int{TYPE_F64}),
+	OP_F64_FORMAT:   MakeNative(OP_F64_FORMAT, []int{TYPE_F64, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
 	OP_F64_MAX:      MakeNative(OP_F64_MAX, []int{TYPE_F64}, []int{TYPE_F64}),
 	
+	OP_I8_BYTE:     MakeNative(OP_I8_BYTE, []int{TYPE_I8}, []int{TYPE_BYTE}),
+	OP_I8_I8:       MakeNative(OP_I8_I8, []int{TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_I16:      MakeNative(OP_I8_I16, []int{TYPE_I8}, []int{TYPE_I16}),
+	OP_I8_I32:      MakeNative(OP_I8_I32, []int{TYPE_I8}, []int{TYPE_I32}),
+	OP_I8_I64:      MakeNative(OP_I8_I64, []int{TYPE_I8}, []int{TYPE_I64}),
+	OP_I8_UI8:      MakeNative(OP_I8_UI8, []int{TYPE_I8}, []int{TYPE_UI8}),
+	OP_I8_UI16:     MakeNative(OP_I8_UI16, []int{TYPE_I8}, []int{TYPE_UI16}),
+	OP_I8_UI32:     MakeNative(OP_I8_UI32, []int{TYPE_I8}, []int{TYPE_UI32}),
+	OP_I8_UI64:     MakeNative(OP_I8_UI64, []int{TYPE_I8}, []int{TYPE_UI64}),
+	OP_I8_F32:      MakeNative(OP_I8_F32, []int{TYPE_I8}, []int{TYPE_F32}),
+	OP_I8_F64:      MakeNative(OP_I8_F64, []int{TYPE_I8}, []int{TYPE_F64}),
+	OP_I8_STR:      MakeNative(OP_I8_STR, []int{TYPE_I8}, []int{TYPE_STR}),
+	OP_I8_FORMAT:   MakeNative(OP_I8_FORMAT, []int{TYPE_I8, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_I8_PRINT:    MakeNative(OP_I8_PRINT, []int{TYPE_I8}, []int{}),
+	OP_I8_ADD:      MakeNative(OP_I8_ADD, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_SUB:      MakeNative(OP_I8_SUB, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_MUL:      MakeNative(OP_I8_MUL, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_DIV:      MakeNative(OP_I8_DIV, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_MOD:      MakeNative(OP_I8_MOD, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_ABS:      MakeNative(OP_I8_ABS, []int{TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_GT:       MakeNative(OP_I8_GT, []int{TYPE_I8, TYPE_I8}, []int{TYPE_BOOL}),
+	OP_I8_GTEQ:     MakeNative(OP_I8_GTEQ, []int{TYPE_I8, TYPE_I8}, []int{TYPE_BOOL}),
+	OP_I8_LT:       MakeNative(OP_I8_LT, []int{TYPE_I8, TYPE_I8}, []int{TYPE_BOOL}),
+	OP_I8_LTEQ:     MakeNative(OP_I8_LTEQ, []int{TYPE_I8, TYPE_I8}, []int{TYPE_BOOL}),
+	OP_I8_EQ:       MakeNative(OP_I8_EQ, []int{TYPE_I8, TYPE_I8}, []int{TYPE_BOOL}),
+	OP_I8_UNEQ:     MakeNative(OP_I8_UNEQ, []int{TYPE_I8, TYPE_I8}, []int{TYPE_BOOL}),
+	OP_I8_BITAND:   MakeNative(OP_I8_BITAND, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_BITOR:    MakeNative(OP_I8_BITOR, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_BITXOR:   MakeNative(OP_I8_BITXOR, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_BITCLEAR: MakeNative(OP_I8_BITCLEAR, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_BITSHL:   MakeNative(OP_I8_BITSHL, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_BITSHR:   MakeNative(OP_I8_BITSHR, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_MAX:      MakeNative(OP_I8_MAX, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+	OP_I8_MIN:      MakeNative(OP_I8_MIN, []int{TYPE_I8, TYPE_I8}, []int{TYPE_I8}),
+
+	OP_I16_BYTE:     MakeNative(OP_I16_BYTE, []int{TYPE_I16}, []int{TYPE_BYTE}),
+	OP_I16_I8:       MakeNative(OP_I16_I8, []int{TYPE_I16}, []int{TYPE_I8}),
+	OP_I16_I16:      MakeNative(OP_I16_I16, []int{TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_I32:      MakeNative(OP_I16_I32, []int{TYPE_I16}, []int{TYPE_I32}),
+	OP_I16_I64:      MakeNative(OP_I16_I64, []int{TYPE_I16}, []int{TYPE_I64}),
+	OP_I16_UI8:      MakeNative(OP_I16_UI8, []int{TYPE_I16}, []int{TYPE_UI8}),
+	OP_I16_UI16:     MakeNative(OP_I16_UI16, []int{TYPE_I16}, []int{TYPE_UI16}),
+	OP_I16_UI32:     MakeNative(OP_I16_UI32, []int{TYPE_I16}, []int{TYPE_UI32}),
+	OP_I16_UI64:     MakeNative(OP_I16_UI64, []int{TYPE_I16}, []int{TYPE_UI64}),
+	OP_I16_F32:      MakeNative(OP_I16_F32, []int{TYPE_I16}, []int{TYPE_F32}),
+	OP_I16_F64:      MakeNative(OP_I16_F64, []int{TYPE_I16}, []int{TYPE_F64}),
+	OP_I16_STR:      MakeNative(OP_I16_STR, []int{TYPE_I16}, []int{TYPE_STR}),
+	OP_I16_FORMAT:   MakeNative(OP_I16_FORMAT, []int{TYPE_I16, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_I16_PRINT:    MakeNative(OP_I16_PRINT, []int{TYPE_I16}, []int{}),
+	OP_I16_ADD:      MakeNative(OP_I16_ADD, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_SUB:      MakeNative(OP_I16_SUB, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_MUL:      MakeNative(OP_I16_MUL, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_DIV:      MakeNative(OP_I16_DIV, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_MOD:      MakeNative(OP_I16_MOD, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_ABS:      MakeNative(OP_I16_ABS, []int{TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_GT:       MakeNative(OP_I16_GT, []int{TYPE_I16, TYPE_I16}, []int{TYPE_BOOL}),
+	OP_I16_GTEQ:     MakeNative(OP_I16_GTEQ, []int{TYPE_I16, TYPE_I16}, []int{TYPE_BOOL}),
+	OP_I16_LT:       MakeNative(OP_I16_LT, []int{TYPE_I16, TYPE_I16}, []int{TYPE_BOOL}),
+	OP_I16_LTEQ:     MakeNative(OP_I16_LTEQ, []int{TYPE_I16, TYPE_I16}, []int{TYPE_BOOL}),
+	OP_I16_EQ:       MakeNative(OP_I16_EQ, []int{TYPE_I16, TYPE_I16}, []int{TYPE_BOOL}),
+	OP_I16_UNEQ:     MakeNative(OP_I16_UNEQ, []int{TYPE_I16, TYPE_I16}, []int{TYPE_BOOL}),
+	OP_I16_BITAND:   MakeNative(OP_I16_BITAND, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_BITOR:    MakeNative(OP_I16_BITOR, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_BITXOR:   MakeNative(OP_I16_BITXOR, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_BITCLEAR: MakeNative(OP_I16_BITCLEAR, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_BITSHL:   MakeNative(OP_I16_BITSHL, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_BITSHR:   MakeNative(OP_I16_BITSHR, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_MAX:      MakeNative(OP_I16_MAX, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+	OP_I16_MIN:      MakeNative(OP_I16_MIN, []int{TYPE_I16, TYPE_I16}, []int{TYPE_I16}),
+
+	OP_UI8_BYTE:     MakeNative(OP_UI8_BYTE, []int{TYPE_UI8}, []int{TYPE_BYTE}),
+	OP_UI8_I8:       MakeNative(OP_UI8_I8, []int{TYPE_UI8}, []int{TYPE_I8}),
+	OP_UI8_I16:      MakeNative(OP_UI8_I16, []int{TYPE_UI8}, []int{TYPE_I16}),
+	OP_UI8_I32:      MakeNative(OP_UI8_I32, []int{TYPE_UI8}, []int{TYPE_I32}),
+	OP_UI8_I64:      MakeNative(OP_UI8_I64, []int{TYPE_UI8}, []int{TYPE_I64}),
+	OP_UI8_UI8:      MakeNative(OP_UI8_UI8, []int{TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_UI16:     MakeNative(OP_UI8_UI16, []int{TYPE_UI8}, []int{TYPE_UI16}),
+	OP_UI8_UI32:     MakeNative(OP_UI8_UI32, []int{TYPE_UI8}, []int{TYPE_UI32}),
+	OP_UI8_UI64:     MakeNative(OP_UI8_UI64, []int{TYPE_UI8}, []int{TYPE_UI64}),
+	OP_UI8_F32:      MakeNative(OP_UI8_F32, []int{TYPE_UI8}, []int{TYPE_F32}),
+	OP_UI8_F64:      MakeNative(OP_UI8_F64, []int{TYPE_UI8}, []int{TYPE_F64}),
+	OP_UI8_STR:      MakeNative(OP_UI8_STR, []int{TYPE_UI8}, []int{TYPE_STR}),
+	OP_UI8_FORMAT:   MakeNative(OP_UI8_FORMAT, []int{TYPE_UI8, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_UI8_PRINT:    MakeNative(OP_UI8_PRINT, []int{TYPE_UI8}, []int{}),
+	OP_UI8_ADD:      MakeNative(OP_UI8_ADD, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_SUB:      MakeNative(OP_UI8_SUB, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_MUL:      MakeNative(OP_UI8_MUL, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_DIV:      MakeNative(OP_UI8_DIV, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_MOD:      MakeNative(OP_UI8_MOD, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_GT:       MakeNative(OP_UI8_GT, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_BOOL}),
+	OP_UI8_GTEQ:     MakeNative(OP_UI8_GTEQ, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_BOOL}),
+	OP_UI8_LT:       MakeNative(OP_UI8_LT, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_BOOL}),
+	OP_UI8_LTEQ:     MakeNative(OP_UI8_LTEQ, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_BOOL}),
+	OP_UI8_EQ:       MakeNative(OP_UI8_EQ, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_BOOL}),
+	OP_UI8_UNEQ:     MakeNative(OP_UI8_UNEQ, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_BOOL}),
+	OP_UI8_BITAND:   MakeNative(OP_UI8_BITAND, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_BITOR:    MakeNative(OP_UI8_BITOR, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_BITXOR:   MakeNative(OP_UI8_BITXOR, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_BITCLEAR: MakeNative(OP_UI8_BITCLEAR, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_BITSHL:   MakeNative(OP_UI8_BITSHL, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_BITSHR:   MakeNative(OP_UI8_BITSHR, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_MAX:      MakeNative(OP_UI8_MAX, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+	OP_UI8_MIN:      MakeNative(OP_UI8_MIN, []int{TYPE_UI8, TYPE_UI8}, []int{TYPE_UI8}),
+
+	OP_UI16_BYTE:     MakeNative(OP_UI16_BYTE, []int{TYPE_UI16}, []int{TYPE_BYTE}),
+	OP_UI16_I8:       MakeNative(OP_UI16_I8, []int{TYPE_UI16}, []int{TYPE_I8}),
+	OP_UI16_I16:      MakeNative(OP_UI16_I16, []int{TYPE_UI16}, []int{TYPE_I16}),
+	OP_UI16_I32:      MakeNative(OP_UI16_I32, []int{TYPE_UI16}, []int{TYPE_I32}),
+	OP_UI16_I64:      MakeNative(OP_UI16_I64, []int{TYPE_UI16}, []int{TYPE_I64}),
+	OP_UI16_UI8:      MakeNative(OP_UI16_UI8, []int{TYPE_UI16}, []int{TYPE_UI8}),
+	OP_UI16_UI16:     MakeNative(OP_UI16_UI16, []int{TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_UI32:     MakeNative(OP_UI16_UI32, []int{TYPE_UI16}, []int{TYPE_UI32}),
+	OP_UI16_UI64:     MakeNative(OP_UI16_UI64, []int{TYPE_UI16}, []int{TYPE_UI64}),
+	OP_UI16_F32:      MakeNative(OP_UI16_F32, []int{TYPE_UI16}, []int{TYPE_F32}),
+	OP_UI16_F64:      MakeNative(OP_UI16_F64, []int{TYPE_UI16}, []int{TYPE_F64}),
+	OP_UI16_STR:      MakeNative(OP_UI16_STR, []int{TYPE_UI16}, []int{TYPE_STR}),
+	OP_UI16_FORMAT:   MakeNative(OP_UI16_FORMAT, []int{TYPE_UI16, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_UI16_PRINT:    MakeNative(OP_UI16_PRINT, []int{TYPE_UI16}, []int{}),
+	OP_UI16_ADD:      MakeNative(OP_UI16_ADD, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_SUB:      MakeNative(OP_UI16_SUB, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_MUL:      MakeNative(OP_UI16_MUL, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_DIV:      MakeNative(OP_UI16_DIV, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_MOD:      MakeNative(OP_UI16_MOD, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_GT:       MakeNative(OP_UI16_GT, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_BOOL}),
+	OP_UI16_GTEQ:     MakeNative(OP_UI16_GTEQ, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_BOOL}),
+	OP_UI16_LT:       MakeNative(OP_UI16_LT, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_BOOL}),
+	OP_UI16_LTEQ:     MakeNative(OP_UI16_LTEQ, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_BOOL}),
+	OP_UI16_EQ:       MakeNative(OP_UI16_EQ, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_BOOL}),
+	OP_UI16_UNEQ:     MakeNative(OP_UI16_UNEQ, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_BOOL}),
+	OP_UI16_BITAND:   MakeNative(OP_UI16_BITAND, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_BITOR:    MakeNative(OP_UI16_BITOR, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_BITXOR:   MakeNative(OP_UI16_BITXOR, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_BITCLEAR: MakeNative(OP_UI16_BITCLEAR, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_BITSHL:   MakeNative(OP_UI16_BITSHL, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_BITSHR:   MakeNative(OP_UI16_BITSHR, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_MAX:      MakeNative(OP_UI16_MAX, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+	OP_UI16_MIN:      MakeNative(OP_UI16_MIN, []int{TYPE_UI16, TYPE_UI16}, []int{TYPE_UI16}),
+
+	OP_UI32_BYTE:     MakeNative(OP_UI32_BYTE, []int{TYPE_UI32}, []int{TYPE_BYTE}),
+	OP_UI32_I8:       MakeNative(OP_UI32_I8, []int{TYPE_UI32}, []int{TYPE_I8}),
+	OP_UI32_I16:      MakeNative(OP_UI32_I16, []int{TYPE_UI32}, []int{TYPE_I16}),
+	OP_UI32_I32:      MakeNative(OP_UI32_I32, []int{TYPE_UI32}, []int{TYPE_I32}),
+	OP_UI32_I64:      MakeNative(OP_UI32_I64, []int{TYPE_UI32}, []int{TYPE_I64}),
+	OP_UI32_UI8:      MakeNative(OP_UI32_UI8, []int{TYPE_UI32}, []int{TYPE_UI8}),
+	OP_UI32_UI16:     MakeNative(OP_UI32_UI16, []int{TYPE_UI32}, []int{TYPE_UI16}),
+	OP_UI32_UI32:     MakeNative(OP_UI32_UI32, []int{TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_UI64:     MakeNative(OP_UI32_UI64, []int{TYPE_UI32}, []int{TYPE_UI64}),
+	OP_UI32_F32:      MakeNative(OP_UI32_F32, []int{TYPE_UI32}, []int{TYPE_F32}),
+	OP_UI32_F64:      MakeNative(OP_UI32_F64, []int{TYPE_UI32}, []int{TYPE_F64}),
+	OP_UI32_STR:      MakeNative(OP_UI32_STR, []int{TYPE_UI32}, []int{TYPE_STR}),
+	OP_UI32_FORMAT:   MakeNative(OP_UI32_FORMAT, []int{TYPE_UI32, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_UI32_PRINT:    MakeNative(OP_UI32_PRINT, []int{TYPE_UI32}, []int{}),
+	OP_UI32_ADD:      MakeNative(OP_UI32_ADD, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_SUB:      MakeNative(OP_UI32_SUB, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_MUL:      MakeNative(OP_UI32_MUL, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_DIV:      MakeNative(OP_UI32_DIV, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_MOD:      MakeNative(OP_UI32_MOD, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_GT:       MakeNative(OP_UI32_GT, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_BOOL}),
+	OP_UI32_GTEQ:     MakeNative(OP_UI32_GTEQ, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_BOOL}),
+	OP_UI32_LT:       MakeNative(OP_UI32_LT, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_BOOL}),
+	OP_UI32_LTEQ:     MakeNative(OP_UI32_LTEQ, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_BOOL}),
+	OP_UI32_EQ:       MakeNative(OP_UI32_EQ, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_BOOL}),
+	OP_UI32_UNEQ:     MakeNative(OP_UI32_UNEQ, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_BOOL}),
+	OP_UI32_BITAND:   MakeNative(OP_UI32_BITAND, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_BITOR:    MakeNative(OP_UI32_BITOR, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_BITXOR:   MakeNative(OP_UI32_BITXOR, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_BITCLEAR: MakeNative(OP_UI32_BITCLEAR, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_BITSHL:   MakeNative(OP_UI32_BITSHL, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_BITSHR:   MakeNative(OP_UI32_BITSHR, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_MAX:      MakeNative(OP_UI32_MAX, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+	OP_UI32_MIN:      MakeNative(OP_UI32_MIN, []int{TYPE_UI32, TYPE_UI32}, []int{TYPE_UI32}),
+
+	OP_UI64_BYTE:     MakeNative(OP_UI64_BYTE, []int{TYPE_UI64}, []int{TYPE_BYTE}),
+	OP_UI64_I8:       MakeNative(OP_UI64_I8, []int{TYPE_UI64}, []int{TYPE_I8}),
+	OP_UI64_I16:      MakeNative(OP_UI64_I16, []int{TYPE_UI64}, []int{TYPE_I16}),
+	OP_UI64_I32:      MakeNative(OP_UI64_I32, []int{TYPE_UI64}, []int{TYPE_I32}),
+	OP_UI64_I64:      MakeNative(OP_UI64_I64, []int{TYPE_UI64}, []int{TYPE_I64}),
+	OP_UI64_UI8:      MakeNative(OP_UI64_UI8, []int{TYPE_UI64}, []int{TYPE_UI8}),
+	OP_UI64_UI16:     MakeNative(OP_UI64_UI16, []int{TYPE_UI64}, []int{TYPE_UI16}),
+	OP_UI64_UI32:     MakeNative(OP_UI64_UI32, []int{TYPE_UI64}, []int{TYPE_UI32}),
+	OP_UI64_UI64:     MakeNative(OP_UI64_UI64, []int{TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_F32:      MakeNative(OP_UI64_F32, []int{TYPE_UI64}, []int{TYPE_F32}),
+	OP_UI64_F64:      MakeNative(OP_UI64_F64, []int{TYPE_UI64}, []int{TYPE_F64}),
+	OP_UI64_STR:      MakeNative(OP_UI64_STR, []int{TYPE_UI64}, []int{TYPE_STR}),
+	OP_UI64_FORMAT:   MakeNative(OP_UI64_FORMAT, []int{TYPE_UI64, TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_UI64_PRINT:    MakeNative(OP_UI64_PRINT, []int{TYPE_UI64}, []int{}),
+	OP_UI64_ADD:      MakeNative(OP_UI64_ADD, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_SUB:      MakeNative(OP_UI64_SUB, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_MUL:      MakeNative(OP_UI64_MUL, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_DIV:      MakeNative(OP_UI64_DIV, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_MOD:      MakeNative(OP_UI64_MOD, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_GT:       MakeNative(OP_UI64_GT, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_BOOL}),
+	OP_UI64_GTEQ:     MakeNative(OP_UI64_GTEQ, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_BOOL}),
+	OP_UI64_LT:       MakeNative(OP_UI64_LT, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_BOOL}),
+	OP_UI64_LTEQ:     MakeNative(OP_UI64_LTEQ, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_BOOL}),
+	OP_UI64_EQ:       MakeNative(OP_UI64_EQ, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_BOOL}),
+	OP_UI64_UNEQ:     MakeNative(OP_UI64_UNEQ, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_BOOL}),
+	OP_UI64_BITAND:   MakeNative(OP_UI64_BITAND, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_BITOR:    MakeNative(OP_UI64_BITOR, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_BITXOR:   MakeNative(OP_UI64_BITXOR, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_BITCLEAR: MakeNative(OP_UI64_BITCLEAR, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_BITSHL:   MakeNative(OP_UI64_BITSHL, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_BITSHR:   MakeNative(OP_UI64_BITSHR, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_MAX:      MakeNative(OP_UI64_MAX, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+	OP_UI64_MIN:      MakeNative(OP_UI64_MIN, []int{TYPE_UI64, TYPE_UI64}, []int{TYPE_UI64}),
+
 	OP_STR_PRINT:    MakeNative(OP_STR_PRINT, []int{TYPE_STR}, []int{}),
 	OP_STR_EQ:       MakeNative(OP_STR_EQ, []int{TYPE_STR, TYPE_STR}, []int{TYPE_BOOL}),
+	OP_STR_I32:      MakeNative(OP_STR_I32, []int{TYPE_STR}, []int{TYPE_I32, TYPE_BOOL}),
+	OP_STR_I64:      MakeNative(OP_STR_I64, []int{TYPE_STR}, []int{TYPE_I64, TYPE_BOOL}),
+	OP_STR_F32:      MakeNative(OP_STR_F32, []int{TYPE_STR}, []int{TYPE_F32, TYPE_BOOL}),
+	OP_STR_F64:      MakeNative(OP_STR_F64, []int{TYPE_STR}, []int{TYPE_F64, TYPE_BOOL}),
+	OP_STR_BOOL:     MakeNative(OP_STR_BOOL, []int{TYPE_STR}, []int{TYPE_BOOL, TYPE_BOOL}),
 
 	OP_TIME_SLEEP:      MakeNative(OP_TIME_SLEEP, []int{TYPE_I32}, []int{}),
 	OP_TIME_UNIX_MILLI: MakeNative(OP_TIME_UNIX_MILLI, []int{}, []int{TYPE_I64}),
 	OP_TIME_UNIX_NANO:  MakeNative(OP_TIME_UNIX_NANO, []int{}, []int{TYPE_I64}),
 
 	OP_TEST_START: MakeNative(OP_TEST_START, []int{}, []int{}),
-	OP_TEST_STOP:  MakeNative(OP_TEST_START, []int{}, []int{}),
+	OP_TEST_STOP:  MakeNative(OP_TEST_STOP, []int{}, []int{}),
+	OP_TEST_ERROR: MakeNative(OP_TEST_ERROR, []int{TYPE_STR}, []int{}),
+	OP_TEST_VALUE: MakeNative(OP_TEST_VALUE, []int{TYPE_UNDEFINED, TYPE_UNDEFINED, TYPE_STR}, []int{}),
 	OP_ASSERT:     MakeNative(OP_ASSERT, []int{TYPE_UNDEFINED, TYPE_UNDEFINED, TYPE_STR}, []int{}),
 
 	// opengl
@@ -1478,8 +3589,115 @@ var Natives map[int]*CXFunction = map[int]*CXFunction{
 	OP_GLFW_SET_INPUT_MODE:            MakeNative(OP_GLFW_SET_INPUT_MODE, []int{TYPE_STR, TYPE_I32, TYPE_I32}, []int{}),
 
 	// http
-	OP_HTTP_GET:                       MakeNative(OP_HTTP_GET, []int{TYPE_STR}, []int{TYPE_STR}),
+	OP_HTTP_GET:                       MakeNative(OP_HTTP_GET, []int{TYPE_STR}, []int{TYPE_CUSTOM}),
+	OP_HTTP_POST:                      MakeNative(OP_HTTP_POST, []int{TYPE_STR, TYPE_STR, TYPE_STR}, []int{TYPE_CUSTOM}),
+	OP_HTTP_DO:                        MakeNative(OP_HTTP_DO, []int{TYPE_CUSTOM}, []int{TYPE_CUSTOM}),
+	OP_HTTP_HANDLE:                    MakeNative(OP_HTTP_HANDLE, []int{TYPE_STR, TYPE_STR}, []int{TYPE_STR}),
+	OP_HTTP_LISTEN_AND_SERVE:          MakeNative(OP_HTTP_LISTEN_AND_SERVE, []int{TYPE_STR}, []int{TYPE_STR}),
+
+	// net
+	OP_NET_DIAL:                       MakeNative(OP_NET_DIAL, []int{TYPE_STR, TYPE_STR}, []int{TYPE_I32, TYPE_I32}),
+	OP_NET_LISTEN:                     MakeNative(OP_NET_LISTEN, []int{TYPE_STR, TYPE_STR}, []int{TYPE_I32, TYPE_I32}),
+	OP_NET_LISTEN_PACKET:              MakeNative(OP_NET_LISTEN_PACKET, []int{TYPE_STR, TYPE_STR}, []int{TYPE_I32, TYPE_I32}),
+	OP_NET_ACCEPT:                     MakeNative(OP_NET_ACCEPT, []int{TYPE_I32}, []int{TYPE_I32, TYPE_I32}),
+	OP_NET_READ:                       MakeNative(OP_NET_READ, []int{TYPE_I32}, []int{TYPE_BYTE, TYPE_I32, TYPE_I32}),
+	OP_NET_WRITE:                      MakeNative(OP_NET_WRITE, []int{TYPE_I32, TYPE_BYTE, TYPE_I32}, []int{TYPE_I32, TYPE_I32}),
+	OP_NET_WRITE_STRING:               MakeNative(OP_NET_WRITE_STRING, []int{TYPE_I32, TYPE_STR}, []int{TYPE_I32}),
+	OP_NET_READ_FROM:                  MakeNative(OP_NET_READ_FROM, []int{TYPE_I32}, []int{TYPE_BYTE, TYPE_I32, TYPE_STR, TYPE_I32}),
+	OP_NET_WRITE_TO:                   MakeNative(OP_NET_WRITE_TO, []int{TYPE_I32, TYPE_BYTE, TYPE_I32, TYPE_STR}, []int{TYPE_I32, TYPE_I32}),
+	OP_NET_LOCAL_ADDR:                 MakeNative(OP_NET_LOCAL_ADDR, []int{TYPE_I32}, []int{TYPE_STR}),
+	OP_NET_CLOSE:                      MakeNative(OP_NET_CLOSE, []int{TYPE_I32}, []int{TYPE_I32}),
+
+	// json
+	OP_JSON_MARSHAL:                   MakeNative(OP_JSON_MARSHAL, []int{TYPE_UNDEFINED}, []int{TYPE_STR}),
+	OP_JSON_UNMARSHAL:                 MakeNative(OP_JSON_UNMARSHAL, []int{TYPE_STR, TYPE_UNDEFINED}, []int{TYPE_BOOL}),
+	OP_JSON_FIELD:                     MakeNative(OP_JSON_FIELD, []int{TYPE_STR, TYPE_STR, TYPE_STR}, []int{}),
+	OP_JSON_NAMING:                    MakeNative(OP_JSON_NAMING, []int{TYPE_STR}, []int{TYPE_BOOL}),
+	OP_JSON_PARSE:                     MakeNative(OP_JSON_PARSE, []int{TYPE_STR}, []int{TYPE_I32, TYPE_BOOL}),
+	OP_JSON_KIND:                      MakeNative(OP_JSON_KIND, []int{TYPE_I32}, []int{TYPE_STR}),
+	OP_JSON_GET:                       MakeNative(OP_JSON_GET, []int{TYPE_I32, TYPE_STR}, []int{TYPE_I32, TYPE_BOOL}),
+	OP_JSON_INDEX:                     MakeNative(OP_JSON_INDEX, []int{TYPE_I32, TYPE_I32}, []int{TYPE_I32, TYPE_BOOL}),
+	OP_JSON_LEN:                       MakeNative(OP_JSON_LEN, []int{TYPE_I32}, []int{TYPE_I32}),
+	OP_JSON_KEYS:                      MakeNative(OP_JSON_KEYS, []int{TYPE_I32}, []int{TYPE_STR, TYPE_I32}),
+	OP_JSON_STR:                       MakeNative(OP_JSON_STR, []int{TYPE_I32}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_JSON_BOOL:                      MakeNative(OP_JSON_BOOL, []int{TYPE_I32}, []int{TYPE_BOOL, TYPE_BOOL}),
+	OP_JSON_I32:                       MakeNative(OP_JSON_I32, []int{TYPE_I32}, []int{TYPE_I32, TYPE_BOOL}),
+	OP_JSON_I64:                       MakeNative(OP_JSON_I64, []int{TYPE_I32}, []int{TYPE_I64, TYPE_BOOL}),
+	OP_JSON_F64:                       MakeNative(OP_JSON_F64, []int{TYPE_I32}, []int{TYPE_F64, TYPE_BOOL}),
+
+	// regexp
+	OP_REGEXP_COMPILE:                 MakeNative(OP_REGEXP_COMPILE, []int{TYPE_STR}, []int{TYPE_I32, TYPE_STR}),
+	OP_REGEXP_MATCH_STRING:            MakeNative(OP_REGEXP_MATCH_STRING, []int{TYPE_I32, TYPE_STR}, []int{TYPE_BOOL}),
+	OP_REGEXP_FIND_STRING:             MakeNative(OP_REGEXP_FIND_STRING, []int{TYPE_I32, TYPE_STR}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_REGEXP_FIND_ALL_STRING:         MakeNative(OP_REGEXP_FIND_ALL_STRING, []int{TYPE_I32, TYPE_STR, TYPE_I32}, []int{TYPE_STR, TYPE_I32}),
+	OP_REGEXP_FIND_STRING_SUBMATCH:    MakeNative(OP_REGEXP_FIND_STRING_SUBMATCH, []int{TYPE_I32, TYPE_STR}, []int{TYPE_STR, TYPE_I32}),
+	OP_REGEXP_REPLACE_ALL_STRING:      MakeNative(OP_REGEXP_REPLACE_ALL_STRING, []int{TYPE_I32, TYPE_STR, TYPE_STR}, []int{TYPE_STR}),
+
+	// crypto
+	OP_CRYPTO_SHA256:                  MakeNative(OP_CRYPTO_SHA256, []int{TYPE_BYTE, TYPE_I32}, []int{TYPE_BYTE}),
+	OP_CRYPTO_RIPEMD160:               MakeNative(OP_CRYPTO_RIPEMD160, []int{TYPE_BYTE, TYPE_I32}, []int{TYPE_BYTE}),
+	OP_HEX_ENCODE:                     MakeNative(OP_HEX_ENCODE, []int{TYPE_BYTE, TYPE_I32}, []int{TYPE_STR}),
+	OP_HEX_DECODE:                     MakeNative(OP_HEX_DECODE, []int{TYPE_STR}, []int{TYPE_BYTE, TYPE_I32, TYPE_BOOL}),
+	OP_BASE58_ENCODE:                  MakeNative(OP_BASE58_ENCODE, []int{TYPE_BYTE, TYPE_I32}, []int{TYPE_STR}),
+	OP_BASE58_DECODE:                  MakeNative(OP_BASE58_DECODE, []int{TYPE_STR}, []int{TYPE_BYTE, TYPE_I32, TYPE_BOOL}),
+	OP_BASE64_ENCODE:                  MakeNative(OP_BASE64_ENCODE, []int{TYPE_BYTE, TYPE_I32}, []int{TYPE_STR}),
+	OP_BASE64_DECODE:                  MakeNative(OP_BASE64_DECODE, []int{TYPE_STR}, []int{TYPE_BYTE, TYPE_I32, TYPE_BOOL}),
+	OP_CIPHER_GENERATE_KEY_PAIR:       MakeNative(OP_CIPHER_GENERATE_KEY_PAIR, []int{}, []int{TYPE_STR, TYPE_STR}),
+	OP_CIPHER_GENERATE_DETERMINISTIC_KEY_PAIR:MakeNative(OP_CIPHER_GENERATE_DETERMINISTIC_KEY_PAIR, []int{TYPE_STR}, []int{TYPE_STR, TYPE_STR}),
+	OP_CIPHER_PUB_KEY_FROM_SEC_KEY:    MakeNative(OP_CIPHER_PUB_KEY_FROM_SEC_KEY, []int{TYPE_STR}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_CIPHER_ADDRESS_FROM_PUB_KEY:    MakeNative(OP_CIPHER_ADDRESS_FROM_PUB_KEY, []int{TYPE_STR}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_CIPHER_SIGN_HASH:               MakeNative(OP_CIPHER_SIGN_HASH, []int{TYPE_BYTE, TYPE_STR}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_CIPHER_VERIFY_SIGNATURE:        MakeNative(OP_CIPHER_VERIFY_SIGNATURE, []int{TYPE_STR, TYPE_STR, TYPE_BYTE}, []int{TYPE_BOOL}),
+	OP_CIPHER_CHK_SIG:                 MakeNative(OP_CIPHER_CHK_SIG, []int{TYPE_STR, TYPE_BYTE, TYPE_STR}, []int{TYPE_BOOL}),
 
 	// os
 	OP_OS_GET_WORKING_DIRECTORY:       MakeNative(OP_OS_GET_WORKING_DIRECTORY, []int{}, []int{TYPE_STR}),
+	OP_OS_READ_FILE:                   MakeNative(OP_OS_READ_FILE, []int{TYPE_STR}, []int{TYPE_STR, TYPE_I32}),
+	OP_OS_WRITE_FILE:                  MakeNative(OP_OS_WRITE_FILE, []int{TYPE_STR, TYPE_STR}, []int{TYPE_I32}),
+	OP_OS_READ_FILE_BYTES:             MakeNative(OP_OS_READ_FILE_BYTES, []int{TYPE_STR}, []int{TYPE_BYTE, TYPE_I32, TYPE_I32}),
+	OP_OS_WRITE_FILE_BYTES:            MakeNative(OP_OS_WRITE_FILE_BYTES, []int{TYPE_STR, TYPE_BYTE, TYPE_I32}, []int{TYPE_I32}),
+	OP_OS_OPEN:                        MakeNative(OP_OS_OPEN, []int{TYPE_STR}, []int{TYPE_I32, TYPE_I32}),
+	OP_OS_CREATE:                      MakeNative(OP_OS_CREATE, []int{TYPE_STR}, []int{TYPE_I32, TYPE_I32}),
+	OP_OS_READ:                        MakeNative(OP_OS_READ, []int{TYPE_I32}, []int{TYPE_BYTE, TYPE_I32, TYPE_I32}),
+	OP_OS_WRITE:                       MakeNative(OP_OS_WRITE, []int{TYPE_I32, TYPE_BYTE, TYPE_I32}, []int{TYPE_I32, TYPE_I32}),
+	OP_OS_WRITE_STRING:                MakeNative(OP_OS_WRITE_STRING, []int{TYPE_I32, TYPE_STR}, []int{TYPE_I32}),
+	OP_OS_SEEK:                        MakeNative(OP_OS_SEEK, []int{TYPE_I32, TYPE_I64, TYPE_I32}, []int{TYPE_I64, TYPE_I32}),
+	OP_OS_CLOSE:                       MakeNative(OP_OS_CLOSE, []int{TYPE_I32}, []int{TYPE_I32}),
+	OP_OS_READ_DIR:                    MakeNative(OP_OS_READ_DIR, []int{TYPE_STR}, []int{TYPE_STR, TYPE_I32, TYPE_I32}),
+	OP_OS_STAT:                        MakeNative(OP_OS_STAT, []int{TYPE_STR}, []int{TYPE_I64, TYPE_BOOL, TYPE_I64, TYPE_I32}),
+	OP_OS_MKDIR:                       MakeNative(OP_OS_MKDIR, []int{TYPE_STR}, []int{TYPE_I32}),
+	OP_OS_REMOVE:                      MakeNative(OP_OS_REMOVE, []int{TYPE_STR}, []int{TYPE_I32}),
+	OP_OS_RENAME:                      MakeNative(OP_OS_RENAME, []int{TYPE_STR, TYPE_STR}, []int{TYPE_I32}),
+	OP_OS_ARGS:                        MakeNative(OP_OS_ARGS, []int{}, []int{TYPE_STR, TYPE_I32}),
+	OP_OS_GETENV:                      MakeNative(OP_OS_GETENV, []int{TYPE_STR}, []int{TYPE_STR}),
+	OP_OS_SETENV:                      MakeNative(OP_OS_SETENV, []int{TYPE_STR, TYPE_STR}, []int{TYPE_I32}),
+	OP_OS_EXIT:                        MakeNative(OP_OS_EXIT, []int{TYPE_I32}, []int{}),
+
+	// read
+	OP_READ_LINE:                      MakeNative(OP_READ_LINE, []int{}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_READ_ALL:                       MakeNative(OP_READ_ALL, []int{}, []int{TYPE_STR}),
+	OP_READ_STR:                       MakeNative(OP_READ_STR, []int{}, []int{TYPE_STR, TYPE_BOOL}),
+	OP_READ_I32:                       MakeNative(OP_READ_I32, []int{}, []int{TYPE_I32, TYPE_BOOL, TYPE_BOOL}),
+	OP_READ_I64:                       MakeNative(OP_READ_I64, []int{}, []int{TYPE_I64, TYPE_BOOL, TYPE_BOOL}),
+	OP_READ_F32:                       MakeNative(OP_READ_F32, []int{}, []int{TYPE_F32, TYPE_BOOL, TYPE_BOOL}),
+	OP_READ_F64:                       MakeNative(OP_READ_F64, []int{}, []int{TYPE_F64, TYPE_BOOL, TYPE_BOOL}),
+
+	// strings
+	OP_STRINGS_INDEX:                  MakeNative(OP_STRINGS_INDEX, []int{TYPE_STR, TYPE_STR}, []int{TYPE_I32}),
+	OP_STRINGS_LAST_INDEX:             MakeNative(OP_STRINGS_LAST_INDEX, []int{TYPE_STR, TYPE_STR}, []int{TYPE_I32}),
+	OP_STRINGS_CONTAINS:               MakeNative(OP_STRINGS_CONTAINS, []int{TYPE_STR, TYPE_STR}, []int{TYPE_BOOL}),
+	OP_STRINGS_HAS_PREFIX:             MakeNative(OP_STRINGS_HAS_PREFIX, []int{TYPE_STR, TYPE_STR}, []int{TYPE_BOOL}),
+	OP_STRINGS_HAS_SUFFIX:             MakeNative(OP_STRINGS_HAS_SUFFIX, []int{TYPE_STR, TYPE_STR}, []int{TYPE_BOOL}),
+	OP_STRINGS_SPLIT:                  MakeNative(OP_STRINGS_SPLIT, []int{TYPE_STR, TYPE_STR}, []int{TYPE_STR, TYPE_I32}),
+	OP_STRINGS_JOIN:                   MakeNative(OP_STRINGS_JOIN, []int{TYPE_STR, TYPE_STR}, []int{TYPE_STR}),
+	OP_STRINGS_SUBSTRING:              MakeNative(OP_STRINGS_SUBSTRING, []int{TYPE_STR, TYPE_I32, TYPE_I32}, []int{TYPE_STR}),
+	OP_STRINGS_REPLACE:                MakeNative(OP_STRINGS_REPLACE, []int{TYPE_STR, TYPE_STR, TYPE_STR}, []int{TYPE_STR}),
+	OP_STRINGS_TRIM:                   MakeNative(OP_STRINGS_TRIM, []int{TYPE_STR, TYPE_STR}, []int{TYPE_STR}),
+	OP_STRINGS_TRIM_SPACE:             MakeNative(OP_STRINGS_TRIM_SPACE, []int{TYPE_STR}, []int{TYPE_STR}),
+	OP_STRINGS_TO_UPPER:               MakeNative(OP_STRINGS_TO_UPPER, []int{TYPE_STR}, []int{TYPE_STR}),
+	OP_STRINGS_TO_LOWER:               MakeNative(OP_STRINGS_TO_LOWER, []int{TYPE_STR}, []int{TYPE_STR}),
+	OP_STRINGS_REPEAT:                 MakeNative(OP_STRINGS_REPEAT, []int{TYPE_STR, TYPE_I32}, []int{TYPE_STR}),
+	OP_STRINGS_RUNE_COUNT:             MakeNative(OP_STRINGS_RUNE_COUNT, []int{TYPE_STR}, []int{TYPE_I32}),
+	OP_STRINGS_DECODE_RUNE:            MakeNative(OP_STRINGS_DECODE_RUNE, []int{TYPE_STR, TYPE_I32}, []int{TYPE_I32, TYPE_I32}),
+	OP_STRINGS_ENCODE_RUNE:            MakeNative(OP_STRINGS_ENCODE_RUNE, []int{TYPE_I32}, []int{TYPE_STR}),
 }
@@ -0,0 +1,302 @@
+package base
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/pprof/profile"
+)
+
+// a call stack of the profiled program, as a node of the tree of every call
+// stack seen while running it. The expressions of the nodes from the root are
+// the ones being run by each frame of the stack, the last one by the function
+// on top
+type profileNode struct {
+	expr     *CXExpression
+	fn       *CXFunction
+	parent   *profileNode
+	children map[*CXExpression]*profileNode
+	steps    int   // times its expression ran with this call stack
+	calls    int   // calls to its function that started with this call stack
+	nanos    int64 // time spent running its expression
+}
+
+func (node *profileNode) child(expr *CXExpression, fn *CXFunction) *profileNode {
+	if child, found := node.children[expr]; found {
+		return child
+	}
+	if node.children == nil {
+		node.children = make(map[*CXExpression]*profileNode)
+	}
+	child := &profileNode{expr: expr, fn: fn, parent: node}
+	node.children[expr] = child
+	return child
+}
+
+// Profiler is an exact profiler of the CX call stack. Every expression run by
+// the program is recorded with the call stack that ran it, and the time until
+// the next one starts is charged to it, so the time of a native is charged to
+// the expression calling it. Measuring every expression slows the program
+// down, so the times are better compared to each other than to the time of an
+// unprofiled run
+type Profiler struct {
+	root    profileNode
+	current *profileNode
+	last    time.Time
+	start   time.Time
+	elapsed time.Duration
+}
+
+// EnableProfiling makes the program record the time spent and the calls made
+// in each of its functions and lines
+func (prgrm *CXProgram) EnableProfiling() *Profiler {
+	prgrm.Profiler = &Profiler{start: time.Now()}
+	return prgrm.Profiler
+}
+
+// tick is called by ccall before running the expression on top of the call
+// stack. The time since the previous tick is charged to the previous
+// expression
+func (prof *Profiler) tick(prgrm *CXProgram) {
+	now := time.Now()
+	if prof.current != nil {
+		prof.current.nanos += int64(now.Sub(prof.last))
+	}
+
+	node := &prof.root
+	for c := 0; c <= prgrm.CallCounter; c++ {
+		call := &prgrm.CallStack[c]
+		node = node.child(call.Operator.Expressions[call.Line], call.Operator)
+	}
+	node.steps++
+	if prgrm.CallStack[prgrm.CallCounter].Line == 0 {
+		node.calls++
+	}
+
+	prof.current = node
+	prof.last = time.Now()
+}
+
+// Stop charges the time since the last tick to the last expression run, and
+// ends the profile
+func (prof *Profiler) Stop() {
+	if prof.current != nil {
+		prof.current.nanos += int64(time.Since(prof.last))
+		prof.current = nil
+	}
+	prof.elapsed = time.Since(prof.start)
+}
+
+// walk calls `fn` with every node of the tree and the call stack leading to
+// it, from the root
+func (prof *Profiler) walk(fn func(node *profileNode, stack []*profileNode)) {
+	var visit func(node *profileNode, stack []*profileNode)
+	visit = func(node *profileNode, stack []*profileNode) {
+		for _, child := range node.children {
+			stack := append(stack, child)
+			fn(child, stack)
+			visit(child, stack)
+		}
+	}
+	visit(&prof.root, nil)
+}
+
+// profileFuncName returns the name of a function in the profiles
+func profileFuncName(fn *CXFunction) string {
+	if fn.Package == nil {
+		return fn.Name
+	}
+	return fn.Package.Name + "." + fn.Name
+}
+
+// profileFileName returns the absolute path of the file of an expression
+func profileFileName(expr *CXExpression) string {
+	if abs, err := filepath.Abs(expr.FileName); err == nil && expr.FileName != "" {
+		return abs
+	}
+	return expr.FileName
+}
+
+// Profile returns the profile in the format of pprof. Every function is a
+// pprof function and every line a location, and the samples are the call
+// stacks with the calls, expressions run and nanoseconds spent in them
+func (prof *Profiler) Profile() *profile.Profile {
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "calls", Unit: "count"},
+			{Type: "steps", Unit: "count"},
+			{Type: "time", Unit: "nanoseconds"},
+		},
+		DefaultSampleType: "time",
+		TimeNanos:         prof.start.UnixNano(),
+		DurationNanos:     int64(prof.elapsed),
+	}
+
+	type locKey struct {
+		fn   *CXFunction
+		line int
+	}
+	funcs := make(map[*CXFunction]*profile.Function)
+	locs := make(map[locKey]*profile.Location)
+
+	location := func(node *profileNode) *profile.Location {
+		key := locKey{node.fn, node.expr.FileLine}
+		if loc, found := locs[key]; found {
+			return loc
+		}
+
+		fn, found := funcs[node.fn]
+		if !found {
+			name := profileFuncName(node.fn)
+			fn = &profile.Function{
+				ID:         uint64(len(p.Function) + 1),
+				Name:       name,
+				SystemName: name,
+				Filename:   profileFileName(node.expr),
+			}
+			if len(node.fn.Expressions) > 0 {
+				fn.StartLine = int64(node.fn.Expressions[0].FileLine)
+			}
+			funcs[node.fn] = fn
+			p.Function = append(p.Function, fn)
+		}
+
+		loc := &profile.Location{
+			ID:   uint64(len(p.Location) + 1),
+			Line: []profile.Line{{Function: fn, Line: int64(node.expr.FileLine)}},
+		}
+		locs[key] = loc
+		p.Location = append(p.Location, loc)
+		return loc
+	}
+
+	prof.walk(func(node *profileNode, stack []*profileNode) {
+		if node.steps == 0 && node.nanos == 0 {
+			return
+		}
+		// pprof's locations start with the leaf
+		sample := &profile.Sample{Value: []int64{int64(node.calls), int64(node.steps), node.nanos}}
+		for c := len(stack) - 1; c >= 0; c-- {
+			sample.Location = append(sample.Location, location(stack[c]))
+		}
+		p.Sample = append(p.Sample, sample)
+	})
+
+	return p
+}
+
+// WriteProfile writes the profile as a gzipped profile.proto, which can be
+// read by go tool pprof
+func (prof *Profiler) WriteProfile(w io.Writer) error {
+	return prof.Profile().Write(w)
+}
+
+// the totals of a function or line in the flat report
+type profileEntry struct {
+	name  string
+	flat  int64
+	cum   int64
+	calls int
+	steps int
+}
+
+// sortedEntries returns the entries sorted by flat time, and by name when
+// they are equal
+func sortedEntries(entries map[string]*profileEntry) []*profileEntry {
+	sorted := make([]*profileEntry, 0, len(entries))
+	for _, entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].flat != sorted[j].flat {
+			return sorted[i].flat > sorted[j].flat
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
+
+// profilePercent returns `nanos` as a percentage of `total`
+func profilePercent(nanos, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(nanos) / float64(total)
+}
+
+// WriteReport writes a flat report of the profile, as go tool pprof -top
+// does, with the calls made to every function. The flat time of a function
+// is spent running its own expressions, and its cumulative time includes the
+// functions it called. The lines are listed after the functions
+func (prof *Profiler) WriteReport(w io.Writer) error {
+	funcs := make(map[string]*profileEntry)
+	lines := make(map[string]*profileEntry)
+	var total int64
+
+	prof.walk(func(node *profileNode, stack []*profileNode) {
+		total += node.nanos
+
+		name := profileFuncName(node.fn)
+		fn := funcs[name]
+		if fn == nil {
+			fn = &profileEntry{name: name}
+			funcs[name] = fn
+		}
+		fn.flat += node.nanos
+		fn.calls += node.calls
+		fn.steps += node.steps
+
+		// a recursive function is only charged once per call stack
+		seen := make(map[string]bool)
+		for _, frame := range stack {
+			name := profileFuncName(frame.fn)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			if funcs[name] == nil {
+				funcs[name] = &profileEntry{name: name}
+			}
+			funcs[name].cum += node.nanos
+		}
+
+		if node.expr.FileName != "" {
+			name := fmt.Sprintf("%s:%d", node.expr.FileName, node.expr.FileLine)
+			line := lines[name]
+			if line == nil {
+				line = &profileEntry{name: name}
+				lines[name] = line
+			}
+			line.flat += node.nanos
+			line.steps += node.steps
+		}
+	})
+
+	if _, err := fmt.Fprintf(w, "Total: %v\n%12s %7s %12s %7s %10s %12s  %s\n", time.Duration(total), "flat", "flat%", "cum", "cum%", "calls", "steps", "function"); err != nil {
+		return err
+	}
+	for _, fn := range sortedEntries(funcs) {
+		_, err := fmt.Fprintf(w, "%12v %6.2f%% %12v %6.2f%% %10d %12d  %s\n",
+			time.Duration(fn.flat), profilePercent(fn.flat, total),
+			time.Duration(fn.cum), profilePercent(fn.cum, total),
+			fn.calls, fn.steps, fn.name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "\n%12s %7s %12s  %s\n", "flat", "flat%", "steps", "line"); err != nil {
+		return err
+	}
+	for _, line := range sortedEntries(lines) {
+		_, err := fmt.Fprintf(w, "%12v %6.2f%% %12d  %s\n",
+			time.Duration(line.flat), profilePercent(line.flat, total), line.steps, line.name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
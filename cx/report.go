@@ -0,0 +1,252 @@
+package base
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// report formats accepted by TestReport.WriteFile
+const (
+	REPORT_JUNIT = "junit"
+	REPORT_JSON  = "json"
+)
+
+// TestFailure is a failed check of a test, made by assert, test.value or
+// test.error, or the runtime error that stopped the test
+type TestFailure struct {
+	FileName string `json:"file"`
+	FileLine int    `json:"line"`
+	Message  string `json:"message"`
+}
+
+func (f TestFailure) String() string {
+	if f.FileName == "" {
+		return f.Message
+	}
+	if f.FileLine == 0 {
+		return fmt.Sprintf("%s: %s", f.FileName, f.Message)
+	}
+	return fmt.Sprintf("%s: %d: %s", f.FileName, f.FileLine, f.Message)
+}
+
+// TestResult is the outcome of a test function run by cx test
+type TestResult struct {
+	Name     string
+	Package  string
+	FileName string
+	Duration time.Duration
+	Failures []TestFailure
+}
+
+// Passed reports whether none of the test's checks failed
+func (res TestResult) Passed() bool {
+	return len(res.Failures) == 0
+}
+
+// TestReport collects the results of a cx test run, to be written in the
+// formats read by CI servers
+type TestReport struct {
+	Results  []TestResult
+	Duration time.Duration
+}
+
+// Failed returns the number of tests that failed
+func (rep *TestReport) Failed() (n int) {
+	for _, res := range rep.Results {
+		if !res.Passed() {
+			n++
+		}
+	}
+	return n
+}
+
+// addTestFailure records a failed check of the running program, which is
+// printed unless cx test is running it, as it reports each test's failures
+func (prgrm *CXProgram) addTestFailure(fileName string, fileLine int, message string) {
+	failure := TestFailure{FileName: fileName, FileLine: fileLine, Message: message}
+	prgrm.TestFailures = append(prgrm.TestFailures, failure)
+	if !prgrm.IsTest {
+		fmt.Println(failure)
+	}
+}
+
+// JUnit's XML schema, as read by Jenkins and most CI servers. Every package
+// is a test suite
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitTime formats a duration in seconds, as JUnit's time attributes
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// WriteJUnit writes the report as JUnit XML. The failure message of a test is
+// its first failure, and every failure is listed in the failure's text
+func (rep *TestReport) WriteJUnit(w io.Writer) error {
+	suites := junitTestSuites{
+		Tests:    len(rep.Results),
+		Failures: rep.Failed(),
+		Time:     junitTime(rep.Duration),
+	}
+
+	suiteIdxs := make(map[string]int)
+	var suiteTimes []time.Duration
+	for _, res := range rep.Results {
+		idx, found := suiteIdxs[res.Package]
+		if !found {
+			idx = len(suites.Suites)
+			suiteIdxs[res.Package] = idx
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: res.Package})
+			suiteTimes = append(suiteTimes, 0)
+		}
+		suite := &suites.Suites[idx]
+		suiteTimes[idx] += res.Duration
+
+		testCase := junitTestCase{
+			Name:      res.Name,
+			ClassName: res.Package,
+			File:      res.FileName,
+			Time:      junitTime(res.Duration),
+		}
+		if !res.Passed() {
+			var lines []string
+			for _, failure := range res.Failures {
+				lines = append(lines, failure.String())
+			}
+			testCase.Failure = &junitFailure{
+				Message: res.Failures[0].String(),
+				Type:    "failure",
+				Text:    strings.Join(lines, "\n"),
+			}
+			suite.Failures++
+		}
+
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	for c := range suites.Suites {
+		suites.Suites[c].Time = junitTime(suiteTimes[c])
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// the JSON report's schema. Durations are in seconds
+type jsonTestReport struct {
+	Tests    int              `json:"tests"`
+	Passed   int              `json:"passed"`
+	Failed   int              `json:"failed"`
+	Duration float64          `json:"duration"`
+	Results  []jsonTestResult `json:"results"`
+}
+
+type jsonTestResult struct {
+	Name     string        `json:"name"`
+	Package  string        `json:"package"`
+	File     string        `json:"file"`
+	Passed   bool          `json:"passed"`
+	Duration float64       `json:"duration"`
+	Failures []TestFailure `json:"failures"`
+}
+
+// WriteJSON writes the report as a JSON object
+func (rep *TestReport) WriteJSON(w io.Writer) error {
+	out := jsonTestReport{
+		Tests:    len(rep.Results),
+		Failed:   rep.Failed(),
+		Duration: rep.Duration.Seconds(),
+		Results:  []jsonTestResult{},
+	}
+	out.Passed = out.Tests - out.Failed
+
+	for _, res := range rep.Results {
+		failures := res.Failures
+		if failures == nil {
+			failures = []TestFailure{}
+		}
+		out.Results = append(out.Results, jsonTestResult{
+			Name:     res.Name,
+			Package:  res.Package,
+			File:     res.FileName,
+			Passed:   res.Passed(),
+			Duration: res.Duration.Seconds(),
+			Failures: failures,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	return enc.Encode(out)
+}
+
+// WriteFile writes the report as `spec` says, which is a format and a file
+// name separated by a colon, e.g. junit:out.xml or json:out.json
+func (rep *TestReport) WriteFile(spec string) error {
+	idx := strings.Index(spec, ":")
+	if idx < 1 || idx == len(spec)-1 {
+		return fmt.Errorf("invalid report %q, expected FORMAT:FILE", spec)
+	}
+	format, fileName := spec[:idx], spec[idx+1:]
+
+	var write func(io.Writer) error
+	switch format {
+	case REPORT_JUNIT:
+		write = rep.WriteJUnit
+	case REPORT_JSON:
+		write = rep.WriteJSON
+	default:
+		return fmt.Errorf("unknown report format %q, expected %s or %s", format, REPORT_JUNIT, REPORT_JSON)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
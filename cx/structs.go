@@ -1,5 +1,11 @@
 package base
 
+import (
+	"bufio"
+	"os"
+	"regexp"
+)
+
 /*
   Root Program
 */
@@ -17,6 +23,7 @@ type CXProgram struct {
 
 	CallStack   []CXCall
 	CallCounter int
+	CallBase    int // the program terminates when the call at this index returns
 
 	Stacks []CXStack
 	Heap   CXHeap
@@ -26,6 +33,30 @@ type CXProgram struct {
 
 	Path  string
 	Steps [][]CXCall
+
+	Args     []string      // command-line arguments received by the program
+	ExitCode int           // returned by main, if it has an i32 output, or passed to os.Exit
+	Exited   bool          // set by os.Exit, which terminates the program with ExitCode
+	Stdin    *bufio.Reader // buffered standard input, created by the first read unless it was set before
+
+	FileHandles  []*os.File             // files opened by os.Open and os.Create
+	NetHandles   []interface{}          // connections and listeners opened by the net natives
+	HTTPHandlers map[string]*CXFunction // functions registered by http.Handle, by path
+
+	JSONFieldNames map[string]string // JSON keys set by json.Field, by "Struct.Field"
+	JSONNaming     string            // naming style of the other JSON keys, set by json.Naming
+	JSONNodes      []interface{}     // values of the nodes returned by json.Parse
+
+	Regexps       []*regexp.Regexp // regular expressions compiled by regexp.Compile
+	RegexpHandles map[string]int32 // handles of the compiled patterns, so they're compiled once
+
+	IsTest       bool          // run by cx test, which reports the failed checks itself
+	TestFailures []TestFailure // failed assert, test.value and test.error checks
+
+	Coverage map[*CXExpression]int // execution counts of the expressions, recorded if not nil
+	Profiler *Profiler             // profiles the calls and lines run, if not nil
+	Tracer   *Tracer               // writes the calls and collections made, if not nil
+	Debugger *Debugger             // stops the program at breakpoints, if not nil
 }
 
 type CXHeap struct {
@@ -81,6 +112,10 @@ type CXStruct struct {
 	Fields []*CXArgument
 	Size   int
 
+	// where the struct is declared
+	FileName string
+	FileLine int
+
 	Package *CXPackage
 	Program *CXProgram
 }
@@ -104,6 +139,10 @@ type CXFunction struct {
 	IsNative bool
 	OpCode   int
 
+	// where the function is declared
+	FileName string
+	FileLine int
+
 	CurrentExpression *CXExpression
 	Package           *CXPackage
 	Program           *CXProgram
@@ -128,6 +167,7 @@ type CXExpression struct {
 	IsStructLiteral bool
 	IsArrayLiteral  bool
 	IsFlattened bool // used for nested struct literals
+	IsSpread bool // f(xs...) passes xs as the variadic argument
 
 	Function *CXFunction
 	Package  *CXPackage
@@ -172,6 +212,7 @@ type CXArgument struct {
 	IsField            bool
 	IsRest             bool // pkg.var <- var is rest
 	IsLocalDeclaration bool
+	IsVariadic         bool // packs the rest of a call's arguments, f(args ...i32)
 
 	PassBy int  // pass by value or reference
 	DoesEscape bool
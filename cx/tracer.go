@@ -0,0 +1,127 @@
+package base
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ids of the process and thread of the trace events. CX is still
+// single-threaded, so every event happens in the same thread
+const (
+	TRACE_PID = 1
+	TRACE_TID = 1
+)
+
+// Tracer writes the calls made by a program, and the collections of its
+// garbage collector, as they happen in the trace event format of Chrome, so
+// they can be opened in chrome://tracing or Perfetto. The events are written
+// as they are recorded, so long runs don't keep them in memory
+type Tracer struct {
+	w     *bufio.Writer
+	start time.Time
+	open  []*CXFunction          // functions entered and not exited yet
+	names map[*CXFunction][]byte // JSON strings of the functions' names
+	first bool                   // no event written yet
+}
+
+// NewTracer returns a tracer writing its events to `w`. The trace is not
+// complete until Close is called
+func NewTracer(w io.Writer) *Tracer {
+	tracer := &Tracer{
+		w:     bufio.NewWriter(w),
+		start: time.Now(),
+		names: make(map[*CXFunction][]byte),
+		first: true,
+	}
+
+	tracer.w.WriteString(`{"displayTimeUnit":"ns","traceEvents":[`)
+	tracer.metadata("process_name", "cx")
+	tracer.metadata("thread_name", "main")
+
+	return tracer
+}
+
+// EnableTracing makes the program write its calls and collections to `w`
+func (prgrm *CXProgram) EnableTracing(w io.Writer) *Tracer {
+	prgrm.Tracer = NewTracer(w)
+	return prgrm.Tracer
+}
+
+// timestamp returns the microseconds since the trace started, the time unit
+// of the trace events
+func (tracer *Tracer) timestamp(t time.Time) string {
+	return fmt.Sprintf("%.3f", float64(t.Sub(tracer.start))/float64(time.Microsecond))
+}
+
+// event starts writing an event, which is closed by the caller
+func (tracer *Tracer) event(name []byte, cat, ph string, ts time.Time) {
+	if tracer.first {
+		tracer.first = false
+	} else {
+		tracer.w.WriteByte(',')
+	}
+	tracer.w.WriteString("\n{\"name\":")
+	tracer.w.Write(name)
+	fmt.Fprintf(tracer.w, `,"cat":"%s","ph":"%s","ts":%s,"pid":%d,"tid":%d`, cat, ph, tracer.timestamp(ts), TRACE_PID, TRACE_TID)
+}
+
+// metadata writes an event naming the process or thread of the trace
+func (tracer *Tracer) metadata(name, value string) {
+	val, _ := json.Marshal(value)
+	tracer.event([]byte(`"`+name+`"`), "__metadata", "M", tracer.start)
+	fmt.Fprintf(tracer.w, `,"args":{"name":%s}}`, val)
+}
+
+// name returns the JSON string of the name of `fn`
+func (tracer *Tracer) name(fn *CXFunction) []byte {
+	if name, found := tracer.names[fn]; found {
+		return name
+	}
+	name, _ := json.Marshal(profileFuncName(fn))
+	tracer.names[fn] = name
+	return name
+}
+
+// enter records a call to `fn`, made by `expr`, or by the runtime if it's
+// nil
+func (tracer *Tracer) enter(fn *CXFunction, expr *CXExpression) {
+	tracer.open = append(tracer.open, fn)
+	tracer.event(tracer.name(fn), "call", "B", time.Now())
+	if expr != nil && expr.FileName != "" {
+		file, _ := json.Marshal(expr.FileName)
+		fmt.Fprintf(tracer.w, `,"args":{"file":%s,"line":%d}`, file, expr.FileLine)
+	}
+	tracer.w.WriteByte('}')
+}
+
+// exit records the return of the last function entered
+func (tracer *Tracer) exit() {
+	if len(tracer.open) == 0 {
+		return
+	}
+	fn := tracer.open[len(tracer.open)-1]
+	tracer.open = tracer.open[:len(tracer.open)-1]
+	tracer.event(tracer.name(fn), "call", "E", time.Now())
+	tracer.w.WriteByte('}')
+}
+
+// gc records a collection of the heap that started at `start`, when the
+// heap pointer was `before`
+func (tracer *Tracer) gc(prgrm *CXProgram, start time.Time, before int) {
+	dur := float64(time.Since(start)) / float64(time.Microsecond)
+	tracer.event([]byte(`"MarkAndCompact"`), "gc", "X", start)
+	fmt.Fprintf(tracer.w, `,"dur":%.3f,"args":{"heap_before":%d,"heap_after":%d}}`, dur, before, prgrm.Heap.HeapPointer)
+}
+
+// Close exits the functions still running, as when the program stops with
+// an error, and ends the trace
+func (tracer *Tracer) Close() error {
+	for len(tracer.open) > 0 {
+		tracer.exit()
+	}
+	tracer.w.WriteString("\n]}\n")
+	return tracer.w.Flush()
+}
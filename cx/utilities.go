@@ -783,7 +783,7 @@ func IsMultiDim(typ string) bool {
 }
 
 func IsBasicType(typ string) bool {
-	re := regexp.MustCompile("\\**(\\[\\])*(bool|str|i32|i64|f32|f64|byte)")
+	re := regexp.MustCompile("\\**(\\[\\])*(bool|str|i8|i16|i32|i64|ui8|ui16|ui32|ui64|f32|f64|byte)")
 	if re.FindString(typ) != "" {
 		return true
 	} else {
@@ -2387,11 +2387,13 @@ func SetCorrectArithmeticOp(expr *CXExpression) {
 
 func GetArgSize(typ int) int {
 	switch typ {
-	case TYPE_BOOL, TYPE_BYTE:
+	case TYPE_BOOL, TYPE_BYTE, TYPE_I8, TYPE_UI8:
 		return 1
-	case TYPE_STR, TYPE_I32, TYPE_F32:
+	case TYPE_I16, TYPE_UI16:
+		return 2
+	case TYPE_STR, TYPE_I32, TYPE_UI32, TYPE_F32:
 		return 4
-	case TYPE_I64, TYPE_F64:
+	case TYPE_I64, TYPE_UI64, TYPE_F64:
 		return 8
 	default:
 		return 4
@@ -73,10 +73,49 @@ func ErrorHeader (currentFile string, lineNo int) string {
 	return "error: " + currentFile + ":" + strconv.FormatInt(int64(lineNo+1), 10)
 }
 
+// CollectErrors makes the parser collect the errors it finds in
+// CompileErrors instead of printing them. The errors that stop the
+// compilation then panic with their CompileError instead of exiting, so
+// tools such as the language server can compile programs with errors
+var CollectErrors bool
+var CompileErrors []CompileError
+
+// SyntaxError reports a syntax error found by one of the parsers, and whether
+// it was collected, so it doesn't have to be printed
+func SyntaxError(fileName string, fileLine int, msg string) bool {
+	if !CollectErrors {
+		return false
+	}
+	CompileErrors = append(CompileErrors, CompileError{FileName: fileName, FileLine: fileLine, Message: "syntax error: " + msg})
+	return true
+}
+
+// compileError reports an error that stops the compilation. `lineNo` is
+// 1-based, as the lines of the expressions and of the second pass's lexer
+func compileError(fileName string, lineNo int, msg string) {
+	if CollectErrors {
+		err := CompileError{FileName: fileName, FileLine: lineNo, Message: msg}
+		CompileErrors = append(CompileErrors, err)
+		panic(err)
+	}
+	println("error: " + fileName + ":" + strconv.Itoa(lineNo) + " " + msg)
+	os.Exit(3)
+}
+
+// opName returns the name a function is called by in the messages of the
+// compile errors. Natives are named by their opcode, as in i32.add
+func opName(op *CXFunction) string {
+	if op.IsNative {
+		return OpNames[op.OpCode]
+	}
+	return op.Name
+}
+
 func DeclareGlobal(declarator *CXArgument, declaration_specifiers *CXArgument, initializer []*CXExpression, doesInitialize bool) {
 	if doesInitialize {
 		if pkg, err := PRGRM.GetCurrentPackage(); err == nil {
 			if glbl, err := PRGRM.GetGlobal(declarator.Name); err != nil {
+				declaration_specifiers.FileName, declaration_specifiers.FileLine = declarator.FileName, declarator.FileLine
 				expr := WritePrimary(declaration_specifiers.Type, make([]byte, declaration_specifiers.Size), true)
 				exprOut := expr[0].Outputs[0]
 				declaration_specifiers.Name = declarator.Name
@@ -90,6 +129,9 @@ func DeclareGlobal(declarator *CXArgument, declaration_specifiers *CXArgument, i
 
 				pkg.AddGlobal(declaration_specifiers)
 			} else {
+				// declared by the first pass, which doesn't know where
+				glbl.FileName, glbl.FileLine = declarator.FileName, declarator.FileLine
+
 				if initializer[len(initializer)-1].Operator == nil {
 					expr := MakeExpression(Natives[OP_IDENTITY], CurrentFile, LineNo)
 					expr.Package = pkg
@@ -128,10 +170,11 @@ func DeclareGlobal(declarator *CXArgument, declaration_specifiers *CXArgument, i
 		}
 	} else {
 		if pkg, err := PRGRM.GetCurrentPackage(); err == nil {
-			if _, err := PRGRM.GetGlobal(declarator.Name); err != nil {
+			if glbl, err := PRGRM.GetGlobal(declarator.Name); err != nil {
 				expr := WritePrimary(declaration_specifiers.Type, make([]byte, declaration_specifiers.Size), true)
 				exprOut := expr[0].Outputs[0]
 				declaration_specifiers.Name = declarator.Name
+				declaration_specifiers.FileName, declaration_specifiers.FileLine = declarator.FileName, declarator.FileLine
 				// declaration_specifiers.MemoryRead = MEM_DATA
 				// declaration_specifiers.MemoryWrite = MEM_DATA
 				declaration_specifiers.Offset = exprOut.Offset
@@ -140,6 +183,9 @@ func DeclareGlobal(declarator *CXArgument, declaration_specifiers *CXArgument, i
 				declaration_specifiers.TotalSize = exprOut.TotalSize
 				declaration_specifiers.Package = exprOut.Package
 				pkg.AddGlobal(declaration_specifiers)
+			} else {
+				// declared by the first pass, which doesn't know where
+				glbl.FileName, glbl.FileLine = declarator.FileName, declarator.FileLine
 			}
 		} else {
 			panic(err)
@@ -147,10 +193,13 @@ func DeclareGlobal(declarator *CXArgument, declaration_specifiers *CXArgument, i
 	}
 }
 
-func DeclareStruct(ident string, strctFlds []*CXArgument) {
+// DeclareStruct declares the struct named by `ident`, which holds where it's
+// declared
+func DeclareStruct(ident *CXArgument, strctFlds []*CXArgument) {
 	if pkg, err := PRGRM.GetCurrentPackage(); err == nil {
-		if _, err := PRGRM.GetStruct(ident, pkg.Name); err != nil {
-			strct := MakeStruct(ident)
+		if strct, err := PRGRM.GetStruct(ident.Name, pkg.Name); err != nil {
+			strct := MakeStruct(ident.Name)
+			strct.FileName, strct.FileLine = ident.FileName, ident.FileLine
 			pkg.AddStruct(strct)
 
 			var size int
@@ -159,6 +208,14 @@ func DeclareStruct(ident string, strctFlds []*CXArgument) {
 				size += fld.TotalSize
 			}
 			strct.Size = size
+		} else {
+			// declared by the first pass, which doesn't know where
+			strct.FileName, strct.FileLine = ident.FileName, ident.FileLine
+			for i, fld := range strctFlds {
+				if i < len(strct.Fields) {
+					strct.Fields[i].FileName, strct.Fields[i].FileLine = fld.FileName, fld.FileLine
+				}
+			}
 		}
 	} else {
 		panic(err)
@@ -180,6 +237,8 @@ func DeclareImport(ident string) {
 		if _, err := pkg.GetImport(ident); err != nil {
 			if imp, err := PRGRM.GetPackage(ident); err == nil {
 				pkg.AddImport(imp)
+			} else if imp, err := PRGRM.AddCorePackage(ident); err == nil {
+				pkg.AddImport(imp)
 			} else {
 				// look in the workspace
 				
@@ -199,11 +258,13 @@ func FunctionHeader(ident string, receiver []*CXArgument, isMethod bool) *CXFunc
 		if pkg, err := PRGRM.GetCurrentPackage(); err == nil {
 			if fn, err := PRGRM.GetFunction(ident, pkg.Name); err == nil {
 				fn.AddInput(receiver[0])
+				fn.FileName, fn.FileLine = CurrentFile, LineNo
 				return fn
 			} else {
 				fn := MakeFunction(ident)
 				pkg.AddFunction(fn)
 				fn.AddInput(receiver[0])
+				fn.FileName, fn.FileLine = CurrentFile, LineNo
 				return fn
 			}
 		} else {
@@ -212,10 +273,12 @@ func FunctionHeader(ident string, receiver []*CXArgument, isMethod bool) *CXFunc
 	} else {
 		if pkg, err := PRGRM.GetCurrentPackage(); err == nil {
 			if fn, err := PRGRM.GetFunction(ident, pkg.Name); err == nil {
+				fn.FileName, fn.FileLine = CurrentFile, LineNo
 				return fn
 			} else {
 				fn := MakeFunction(ident)
 				pkg.AddFunction(fn)
+				fn.FileName, fn.FileLine = CurrentFile, LineNo
 				return fn
 			}
 		} else {
@@ -224,6 +287,38 @@ func FunctionHeader(ident string, receiver []*CXArgument, isMethod bool) *CXFunc
 	}
 }
 
+// VariadicParameter declares a parameter that packs the rest of a call's
+// arguments into a slice, e.g. func foo (args ...i32) ()
+func VariadicParameter(declarator *CXArgument, declaration_specifiers *CXArgument) *CXArgument {
+	arg := DeclarationSpecifiers(declaration_specifiers, 0, DECL_SLICE)
+	arg.Name = declarator.Name
+	arg.Package = declarator.Package
+	arg.IsVariadic = true
+	return arg
+}
+
+// VariadicInputs checks that only the last parameter is variadic. If it is,
+// a hidden i32 parameter is added right after it, which receives the number
+// of arguments that were packed
+func VariadicInputs(inputs []*CXArgument) []*CXArgument {
+	for i, inp := range inputs {
+		if !inp.IsVariadic {
+			continue
+		}
+		if i != len(inputs)-1 {
+			compileError(inp.FileName, inp.FileLine, "can only use ... with final parameter in list")
+		}
+
+		count := MakeArgument(VARIADIC_LEN_PREFIX+inp.Name, inp.FileName, inp.FileLine).AddType(TypeNames[TYPE_I32])
+		count.Package = inp.Package
+		count.TotalSize = count.Size
+
+		return append(inputs, count)
+	}
+
+	return inputs
+}
+
 // const (
 
 // )
@@ -653,8 +748,7 @@ func PostfixExpressionNative(typCode int, opStrCode string) []*CXExpression {
 
 		return []*CXExpression{expr}
 	} else {
-		println(ErrorHeader(CurrentFile, LineNo) + " function '" + TypeNames[typCode]+"."+opStrCode + "' does not exist")
-		os.Exit(3)
+		compileError(CurrentFile, LineNo, "function '" + TypeNames[typCode]+"."+opStrCode + "' does not exist")
 		return nil
 		// panic(ok)
 	}
@@ -1431,6 +1525,10 @@ func Assignment (to []*CXExpression, assignOp string, from []*CXExpression) []*C
 			if from[idx].Operator == nil {
 				// then it's a literal
 				sym = MakeArgument(to[0].Outputs[0].Name, CurrentFile, LineNo).AddType(TypeNames[from[idx].Outputs[0].Type])
+			} else if len(from[idx].Operator.Outputs) == 0 {
+				compileError(CurrentFile, LineNo, "function '" + from[idx].Operator.Name + "' has no outputs and can't be used as a value")
+			} else if from[idx].Operator.Outputs[0].Type != TYPE_UNDEFINED {
+				sym = ShortDeclarationSymbol(to[0].Outputs[0].Name, from[idx].Operator.Outputs[0])
 			} else {
 				sym = MakeArgument(to[0].Outputs[0].Name, CurrentFile, LineNo).AddType(TypeNames[from[idx].Inputs[0].Type])
 			}
@@ -1533,6 +1631,84 @@ func Assignment (to []*CXExpression, assignOp string, from []*CXExpression) []*C
 	}
 }
 
+// ShortDeclarationSymbol returns the symbol declared by `name := foo()`,
+// typed as foo's output `out`
+func ShortDeclarationSymbol(name string, out *CXArgument) *CXArgument {
+	sym := MakeArgument(name, CurrentFile, LineNo).AddType(TypeNames[out.Type])
+	sym.CustomType = out.CustomType
+	sym.Size = out.Size
+	sym.TotalSize = out.TotalSize
+	sym.Lengths = out.Lengths
+	sym.IsArray = out.IsArray
+	sym.IsPointer = out.IsPointer
+	sym.IndirectionLevels = out.IndirectionLevels
+	sym.Pointee = out.Pointee
+	sym.PointeeSize = out.PointeeSize
+	sym.DeclarationSpecifiers = out.DeclarationSpecifiers
+	return sym
+}
+
+// CheckAssignmentCount checks that the last of `exprs`, if it assigns the
+// outputs of a function, assigns as many as the function returns. Natives can
+// be assigned fewer, as their outputs are optional. Statements with a single
+// target are checked once they're complete, as `c = foo()` is also the end of
+// `a, b, c = foo()`
+func CheckAssignmentCount(exprs []*CXExpression) {
+	expr := exprs[len(exprs)-1]
+	op := expr.Operator
+	if op == nil || len(expr.Outputs) == 0 {
+		return
+	}
+	if op == Natives[OP_IDENTITY] && len(expr.Outputs) > 1 {
+		compileError(expr.FileName, expr.FileLine, "assignment mismatch: " + strconv.Itoa(len(expr.Outputs)) + " variables but 1 value (parallel assignments such as 'a, b = b, a' aren't supported)")
+	}
+	if len(expr.Outputs) > len(op.Outputs) || (!op.IsNative && len(expr.Outputs) < len(op.Outputs)) {
+		compileError(expr.FileName, expr.FileLine, "assignment mismatch: " + strconv.Itoa(len(expr.Outputs)) + " variables but '" + opName(op) + "' returns " + strconv.Itoa(len(op.Outputs)) + " values")
+	}
+}
+
+// MultipleAssignment handles the assignment of several outputs, e.g.
+// a, b = foo() and a, b := foo(). The outputs of the preceding expressions
+// are prepended to the outputs of the last one
+func MultipleAssignment(left []*CXExpression, right []*CXExpression) []*CXExpression {
+	expr := right[len(right)-1]
+	expr.Outputs = append(left[len(left)-1].Outputs, expr.Outputs...)
+
+	if expr.Operator == nil {
+		// still collecting the left-hand side, e.g. `a, b` in a, b, c = foo()
+		return append(left, right...)
+	}
+
+	op := expr.Operator
+	CheckAssignmentCount(right)
+
+	if !right[0].IsShortDeclaration {
+		return append(left, right...)
+	}
+
+	// the left-hand side identifiers and the last identifier's declaration
+	// (which was typed by Assignment as if it were the first output) are
+	// replaced by declarations typed as the corresponding outputs
+	var decls []*CXExpression
+	for i, out := range expr.Outputs {
+		if op.Outputs[i].Type == TYPE_UNDEFINED {
+			compileError(expr.FileName, expr.FileLine, "cannot infer the type of '" + out.Name + "'")
+		}
+
+		sym := ShortDeclarationSymbol(out.Name, op.Outputs[i])
+		sym.Package = right[0].Package
+
+		decl := MakeExpression(nil, CurrentFile, LineNo)
+		decl.IsShortDeclaration = true
+		decl.Package = right[0].Package
+		decl.AddOutput(sym)
+
+		decls = append(decls, decl)
+	}
+
+	return append(decls, right[1:]...)
+}
+
 func SelectionExpressions(condExprs []*CXExpression, thenExprs []*CXExpression, elseExprs []*CXExpression) []*CXExpression {
 	jmpFn := Natives[OP_JMP]
 	pkg, err := PRGRM.GetCurrentPackage()
@@ -1706,8 +1882,7 @@ func GiveOffset(symbols *map[string]*CXArgument, sym *CXArgument, offset *int, s
 		if arg, found := (*symbols)[sym.Package.Name+"."+sym.Name]; !found {
 			if shouldExist {
 				// it should exist. error
-				println(ErrorHeader(sym.FileName, sym.FileLine) + " identifier '" + sym.Name + "' does not exist")
-				os.Exit(3)
+				compileError(sym.FileName, sym.FileLine, "identifier '" + sym.Name + "' does not exist")
 				
 				// panic(FilePlusLine() + " identifier '" + sym.Name + "' does not exist")
 			}
@@ -1754,8 +1929,10 @@ func GiveOffset(symbols *map[string]*CXArgument, sym *CXArgument, offset *int, s
 				strct := arg.CustomType
 				// fmt.Println("arg.Name", arg.Name, sym.Fields, arg.CustomType)
 
-				// for _, outFld := range sym.Fields {
-				for c := len(sym.Fields) - 1; c >= 0; c-- {
+				// the fields of a selector are in order, as in u.Home.X,
+				// and each one is looked up in the struct of the previous
+				// one. a field's CustomType is its containing struct
+				for c := 0; c < len(sym.Fields); c++ {
 					
 					if sym.Fields[c].CustomType != nil {
 						strct = sym.Fields[c].CustomType
@@ -1770,7 +1947,7 @@ func GiveOffset(symbols *map[string]*CXArgument, sym *CXArgument, offset *int, s
 						// 	outFld.CustomType = strct
 						// }
 
-						strct = inFld.CustomType
+						strct = DeclarationStruct(inFld)
 						
 						// fmt.Println("types", strct.Name, sym.Fields[c].Name, sym.Fields[c].Type, inFld.Type)
 						
@@ -1797,10 +1974,9 @@ func GiveOffset(symbols *map[string]*CXArgument, sym *CXArgument, offset *int, s
 			
 			var isFieldPointer bool
 			if len(sym.Fields) > 0 {
-				var found bool
-
 				strct := arg.CustomType
 				for _, nameFld := range sym.Fields {
+					var found bool
 					if nameFld.CustomType != nil {
 						strct = nameFld.CustomType
 					}
@@ -1887,10 +2063,9 @@ func GiveOffset(symbols *map[string]*CXArgument, sym *CXArgument, offset *int, s
 
 			// checking if it's accessing fields
 			if len(sym.Fields) > 0 {
-				var found bool
-
 				strct := arg.CustomType
 				for _, nameFld := range sym.Fields {
+					var found bool
 					if nameFld.CustomType != nil {
 						strct = nameFld.CustomType
 					}
@@ -1957,6 +2132,8 @@ func GiveOffset(symbols *map[string]*CXArgument, sym *CXArgument, offset *int, s
 			sym.CustomType = arg.CustomType
 			sym.Pointee = arg.Pointee
 			sym.Lengths = arg.Lengths
+			sym.DeclarationSpecifiers = arg.DeclarationSpecifiers
+			sym.IsVariadic = arg.IsVariadic
 			sym.PointeeSize = arg.PointeeSize
 			sym.Package = arg.Package
 			sym.Program = arg.Program
@@ -2020,7 +2197,7 @@ func ProcessShortDeclaration(expr *CXExpression) {
 
 func FunctionDeclaration(fn *CXFunction, inputs []*CXArgument, outputs []*CXArgument, exprs []*CXExpression) {
 	// adding inputs, outputs
-	for _, inp := range inputs {
+	for _, inp := range VariadicInputs(inputs) {
 		fn.AddInput(inp)
 	}
 	
@@ -2032,14 +2209,21 @@ func FunctionDeclaration(fn *CXFunction, inputs []*CXArgument, outputs []*CXArgu
 	var offset int
 
 	for i, expr := range exprs {
-		if expr.Label != "" && expr.Operator == Natives[OP_JMP] {
-			// then it's a goto
+		if expr.Label != "" && expr.Operator == Natives[OP_JMP] && expr.ThenLines != MAX_INT32 {
+			// then it's a goto. returns share the " " label, and
+			// already jump past the last expression. other gotos to
+			// the same label are only its target if nothing else is,
+			// or two of them would jump to each other forever
+			target := -1
 			for j, e := range exprs {
-				if e.Label == expr.Label && i != j {
-					// ElseLines is used because arg's default val is false
-					expr.ThenLines = j - i - 1
+				if e.Label == expr.Label && i != j && (target < 0 || e.Operator != Natives[OP_JMP]) {
+					target = j
 				}
 			}
+			if target >= 0 {
+				// ElseLines is used because arg's default val is false
+				expr.ThenLines = target - i - 1
+			}
 		}
 
 		fn.AddExpression(expr)
@@ -2109,6 +2293,8 @@ func FunctionDeclaration(fn *CXFunction, inputs []*CXArgument, outputs []*CXArgu
 
 		SetCorrectArithmeticOp(expr)
 		ProcessTempVariable(expr)
+		CheckFormatCall(expr)
+		CheckSpreadCall(expr)
 	}
 
 	// checking if assigning pointer to pointer
@@ -2162,7 +2348,11 @@ func FunctionCall(exprs []*CXExpression, args []*CXExpression) []*CXExpression {
 		// 	inpExpr.Outputs[0].DereferenceOperations = append(inpExpr.Outputs[0].DereferenceOperations, DEREF_POINTER)
 		// }
 		
-		if inpExpr.Operator == nil {
+		if inpExpr.Operator == nil && inpExpr.Outputs[0].IsLocalDeclaration {
+			// then it's declaring a symbol used by a nested call,
+			// e.g. the slice packing a variadic call's arguments
+			nestedExprs = append(nestedExprs, inpExpr)
+		} else if inpExpr.Operator == nil {
 			// then it's a literal
 			expr.AddInput(inpExpr.Outputs[0])
 		} else {
@@ -2197,5 +2387,163 @@ func FunctionCall(exprs []*CXExpression, args []*CXExpression) []*CXExpression {
 		}
 	}
 
+	if !expr.Operator.IsNative {
+		isSpread := len(args) > 0 && args[len(args)-1].IsSpread
+		nestedExprs = append(nestedExprs, CallArguments(expr, isSpread)...)
+	}
+
 	return append(nestedExprs, exprs...)
 }
+
+// CheckFormatCall reports the printf and sprintf calls whose arguments don't
+// match the verbs of their format string. Only string literal formats can be
+// checked, so it needs to run once the arguments' types are known
+func CheckFormatCall(expr *CXExpression) {
+	if expr.Operator != Natives[OP_UND_PRINTF] && expr.Operator != Natives[OP_UND_SPRINTF] {
+		return
+	}
+	if len(expr.Inputs) == 0 {
+		compileError(expr.FileName, expr.FileLine, opName(expr.Operator) + " is missing its format string")
+	}
+
+	fmtArg := expr.Inputs[0]
+	if fmtArg.Name != "" || fmtArg.Type != TYPE_STR || fmtArg.Value == nil {
+		return
+	}
+
+	var fmtStr string
+	encoder.DeserializeRaw(*fmtArg.Value, &fmtStr)
+
+	if err := CheckFormat(fmtStr, expr.Inputs[1:]); err != nil {
+		compileError(expr.FileName, expr.FileLine, opName(expr.Operator) + ": " + err.Error())
+	}
+}
+
+// CheckSpreadCall reports the calls that spread, as in f(xs...), something
+// other than an array or a slice of the variadic parameter's type. Like
+// CheckFormatCall, it needs to run once the arguments' types are known
+func CheckSpreadCall(expr *CXExpression) {
+	fn := expr.Operator
+	if fn == nil || fn.IsNative {
+		return
+	}
+	nInps := len(fn.Inputs)
+	if nInps < 2 || !fn.Inputs[nInps-2].IsVariadic || len(expr.Inputs) != nInps {
+		return
+	}
+
+	// the arguments that were packed by CallArguments are always valid
+	param, arg := fn.Inputs[nInps-2], expr.Inputs[nInps-2]
+	elt := arg
+	if len(arg.Fields) > 0 {
+		elt = arg.Fields[len(arg.Fields)-1]
+	}
+
+	if len(elt.Lengths) <= len(elt.Indexes) && !elt.IsVariadic {
+		compileError(expr.FileName, expr.FileLine, "cannot use "+arg.Name+" (type "+TypeNames[elt.Type]+") as an array or slice in call to '"+fn.Name+"'")
+	}
+	if elt.Type != param.Type || (param.CustomType != nil && elt.CustomType != param.CustomType) {
+		compileError(expr.FileName, expr.FileLine, "cannot use "+arg.Name+" (elements of type "+TypeNames[elt.Type]+") as the elements of type "+TypeNames[param.Type]+" of '"+fn.Name+"'")
+	}
+}
+
+// variadicSlice returns a local slice that can be passed to the variadic
+// parameter `param`
+func variadicSlice(name string, param *CXArgument, pkg *CXPackage) *CXArgument {
+	sym := MakeArgument(name, CurrentFile, LineNo).AddType(TypeNames[param.Type])
+	sym.CustomType = param.CustomType
+	sym.Size = param.Size
+	sym.Lengths = param.Lengths
+	sym.TotalSize = param.TotalSize
+	sym.IsArray = true
+	sym.Package = pkg
+
+	return sym
+}
+
+// CallArguments checks that a call to a function defined in CX receives as
+// many arguments as the function has parameters. The arguments received by a
+// variadic parameter are packed into a slice, followed by their count. The
+// returned expressions need to run before the call
+func CallArguments(expr *CXExpression, isSpread bool) []*CXExpression {
+	fn := expr.Operator
+	pkg, err := PRGRM.GetCurrentPackage()
+	if err != nil {
+		panic(err)
+	}
+
+	nInps := len(fn.Inputs)
+	if nInps < 2 || !fn.Inputs[nInps-2].IsVariadic {
+		if isSpread {
+			compileError(CurrentFile, LineNo, "cannot use ... in call to non-variadic function '" + fn.Name + "'")
+		}
+		if len(expr.Inputs) != nInps {
+			compileError(CurrentFile, LineNo, "wrong number of arguments in call to '" + fn.Name + "': have " + strconv.Itoa(len(expr.Inputs)) + ", want " + strconv.Itoa(nInps))
+		}
+		return nil
+	}
+
+	// the variadic parameter is followed by its hidden count parameter
+	param := fn.Inputs[nInps-2]
+	nFixed := nInps - 2
+
+	if isSpread {
+		if len(expr.Inputs) != nFixed+1 {
+			compileError(CurrentFile, LineNo, "wrong number of arguments in call to '" + fn.Name + "': have " + strconv.Itoa(len(expr.Inputs)) + ", want " + strconv.Itoa(nFixed+1))
+		}
+
+		// the count is the spread slice's length, known at runtime
+		count := MakeArgument(MakeGenSym(LOCAL_PREFIX), CurrentFile, LineNo).AddType(TypeNames[TYPE_I32])
+		count.Package = pkg
+
+		lenExpr := MakeExpression(Natives[OP_UND_LEN], CurrentFile, LineNo)
+		lenExpr.Package = pkg
+		lenExpr.AddInput(expr.Inputs[nFixed])
+		lenExpr.AddOutput(count)
+
+		expr.AddInput(count)
+		return []*CXExpression{lenExpr}
+	}
+
+	if len(expr.Inputs) < nFixed {
+		compileError(CurrentFile, LineNo, "not enough arguments in call to '" + fn.Name + "': have " + strconv.Itoa(len(expr.Inputs)) + ", want at least " + strconv.Itoa(nFixed))
+	}
+
+	packed := expr.Inputs[nFixed:]
+	if len(packed) > SLICE_SIZE {
+		compileError(CurrentFile, LineNo, "too many arguments in call to '" + fn.Name + "': a variadic parameter receives at most " + strconv.Itoa(SLICE_SIZE))
+	}
+
+	slcName := MakeGenSym(LOCAL_PREFIX)
+
+	// declaring the slice, as it could receive no arguments at all
+	decl := MakeExpression(nil, CurrentFile, LineNo)
+	decl.Package = pkg
+	decl.AddOutput(variadicSlice(slcName, param, pkg))
+	decl.Outputs[0].IsLocalDeclaration = true
+
+	result := []*CXExpression{decl}
+
+	for i, inp := range packed {
+		sym := variadicSlice(slcName, param, pkg)
+		// literals are copied by reference, as in Assignment
+		sym.PassBy = inp.PassBy
+
+		idxExpr := WritePrimary(TYPE_I32, encoder.Serialize(int32(i)), false)
+		sym.Indexes = append(sym.Indexes, idxExpr[0].Outputs[0])
+		sym.DereferenceOperations = append(sym.DereferenceOperations, DEREF_ARRAY)
+
+		packExpr := MakeExpression(Natives[OP_IDENTITY], CurrentFile, LineNo)
+		packExpr.Package = pkg
+		packExpr.AddInput(inp)
+		packExpr.AddOutput(sym)
+
+		result = append(result, packExpr)
+	}
+
+	count := WritePrimary(TYPE_I32, encoder.Serialize(int32(len(packed))), false)
+
+	expr.Inputs = append(expr.Inputs[:nFixed:nFixed], variadicSlice(slcName, param, pkg), count[0].Outputs[0])
+
+	return result
+}
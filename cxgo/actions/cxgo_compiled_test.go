@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/skycoin/cx/cx"
+)
+
+// assignmentError returns the error reported by CheckAssignmentCount for the
+// assignment of `targets` outputs of `op` on line 12, or "" if there's none
+func assignmentError(op *CXFunction, targets int) (msg string) {
+	CollectErrors, CompileErrors = true, nil
+	defer func() {
+		CollectErrors, CompileErrors = false, nil
+		if r := recover(); r != nil {
+			err := r.(CompileError)
+			msg = err.Message
+			if err.FileLine != 12 {
+				msg = "reported on line " + strconv.Itoa(err.FileLine)
+			}
+		}
+	}()
+
+	expr := MakeExpression(op, "y.cx", 12)
+	for c := 0; c < targets; c++ {
+		expr.AddOutput(MakeArgument("a", "", 0).AddType("i32"))
+	}
+	CheckAssignmentCount([]*CXExpression{expr})
+	return ""
+}
+
+func TestCheckAssignmentCount(t *testing.T) {
+	fn := MakeFunction("two")
+	fn.AddOutput(MakeArgument("a", "", 0).AddType("i32"))
+	fn.AddOutput(MakeArgument("b", "", 0).AddType("i32"))
+
+	for _, targets := range []int{1, 3} {
+		msg := assignmentError(fn, targets)
+		if !strings.HasPrefix(msg, "assignment mismatch") {
+			t.Errorf("assigning 2 outputs to %d variables: got %q, want an assignment mismatch", targets, msg)
+		}
+	}
+	for _, targets := range []int{0, 2} {
+		if msg := assignmentError(fn, targets); msg != "" {
+			t.Errorf("assigning 2 outputs to %d variables: unexpected error %q", targets, msg)
+		}
+	}
+
+	// natives' outputs are optional
+	if msg := assignmentError(Natives[OP_OS_READ_FILE], 1); msg != "" {
+		t.Errorf("assigning 1 output of a native: unexpected error %q", msg)
+	}
+	if msg := assignmentError(Natives[OP_I32_ADD], 2); !strings.Contains(msg, "'i32.add' returns 1 values") {
+		t.Errorf("assigning 1 output of a native to 2 variables: got %q, want the native named", msg)
+	}
+	if msg := assignmentError(Natives[OP_IDENTITY], 2); !strings.Contains(msg, "parallel assignments") {
+		t.Errorf("assigning 1 value to 2 variables: got %q, want a parallel assignment error", msg)
+	}
+}
+
+// spreadError returns the error reported by CheckSpreadCall for the call
+// fn(arg...), or "" if there's none
+func spreadError(fn *CXFunction, arg *CXArgument) (msg string) {
+	CollectErrors, CompileErrors = true, nil
+	defer func() {
+		CollectErrors, CompileErrors = false, nil
+		if r := recover(); r != nil {
+			msg = r.(CompileError).Message
+		}
+	}()
+
+	count := MakeArgument("n", "", 0).AddType("i32")
+	expr := MakeExpression(fn, "y.cx", 12)
+	expr.AddInput(arg).AddInput(count)
+	CheckSpreadCall(expr)
+	return ""
+}
+
+func TestCheckSpreadCall(t *testing.T) {
+	fn := MakeFunction("count")
+	xs := MakeArgument("xs", "", 0).AddType("i32")
+	xs.IsVariadic, xs.Lengths = true, []int{SLICE_SIZE}
+	fn.AddInput(xs)
+	fn.AddInput(MakeArgument(VARIADIC_LEN_PREFIX+"xs", "", 0).AddType("i32"))
+
+	k := MakeArgument("k", "", 0).AddType("i32")
+	if msg := spreadError(fn, k); !strings.Contains(msg, "as an array or slice") {
+		t.Errorf("spreading an i32: got %q, want an error", msg)
+	}
+
+	fs := MakeArgument("fs", "", 0).AddType("f32")
+	fs.Lengths = []int{3}
+	if msg := spreadError(fn, fs); !strings.Contains(msg, "elements of type f32") {
+		t.Errorf("spreading a [3]f32: got %q, want an error", msg)
+	}
+
+	is := MakeArgument("is", "", 0).AddType("i32")
+	is.Lengths = []int{3}
+	if msg := spreadError(fn, is); msg != "" {
+		t.Errorf("spreading a [3]i32: unexpected error %q", msg)
+	}
+}
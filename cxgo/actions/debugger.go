@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	. "github.com/skycoin/cx/cx"
+)
+
+// lines of the source files shown by the debugger, by file name
+var debugSources = make(map[string][]string)
+
+// DebugCommand runs `line` if it's a debugger command of the REPL, and
+// reports whether it was one. The debugger runs the loaded program on the
+// compiled VM:
+//
+//	:break [file.cx:LINE | function]  stops the program at a line or function, or lists the breakpoints
+//	:continue                         runs until a breakpoint or watchpoint stops the program
+//	:next                             runs until the next line, stepping over calls
+//	:stepin                           runs until the next line, entering calls
+//	:finish                           runs until the current function returns
+//	:watch var                        stops the program when the value of a variable changes
+//	:print [expr]                     prints a variable, e.g. points[2].x, or all of them
+func DebugCommand(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case ":break", ":continue", ":next", ":stepin", ":finish", ":watch", ":print":
+	default:
+		return false
+	}
+	arg := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), fields[0]))
+
+	dbg := PRGRM.Debugger
+	if dbg == nil {
+		dbg = PRGRM.EnableDebugging()
+	}
+
+	switch fields[0] {
+	case ":break":
+		if arg == "" {
+			for _, bp := range dbg.Breakpoints {
+				fmt.Printf("%s, hit %d times\n", bp, bp.Hits)
+			}
+			return true
+		}
+		if bp, err := dbg.Break(PRGRM, arg); err == nil {
+			fmt.Println(strings.ToUpper(bp.String()[:1]) + bp.String()[1:])
+		} else {
+			fmt.Println(err)
+		}
+	case ":continue":
+		debugResume(DEBUG_CONTINUE)
+	case ":next":
+		debugResume(DEBUG_NEXT)
+	case ":stepin":
+		debugResume(DEBUG_STEPIN)
+	case ":finish":
+		debugResume(DEBUG_FINISH)
+	case ":watch":
+		if w, err := dbg.Watch(PRGRM, arg); err == nil {
+			fmt.Printf("Watchpoint %d: %s\n", w.ID, w.Expr)
+		} else {
+			fmt.Println(err)
+		}
+	case ":print":
+		var out string
+		var err error
+		if arg == "" {
+			out, err = PRGRM.DebugLocals()
+			out = strings.TrimSuffix(out, "\n")
+		} else {
+			out, err = PRGRM.DebugPrint(arg)
+		}
+		if err == nil {
+			fmt.Println(out)
+		} else {
+			fmt.Println(err)
+		}
+	}
+
+	return true
+}
+
+// debugResume resumes the program and shows where it stopped
+func debugResume(mode int) {
+	dbg := PRGRM.Debugger
+	if err := PRGRM.DebugResume(mode); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if dbg.Finished {
+		fmt.Printf("Program exited with status %d\n", PRGRM.ExitCode)
+		return
+	}
+
+	fn, expr := dbg.Location(PRGRM)
+	if dbg.Reason != "step" && dbg.Reason != "finish" {
+		fmt.Println(strings.ToUpper(dbg.Reason[:1]) + dbg.Reason[1:])
+	}
+	fmt.Printf("%s.%s at %s:%d\n", fn.Package.Name, fn.Name, expr.FileName, expr.FileLine)
+	if src := debugSourceLine(expr.FileName, expr.FileLine); src != "" {
+		fmt.Printf("%d\t%s\n", expr.FileLine, src)
+	}
+}
+
+// debugSourceLine returns a line of a source file, or "" if it can't be read
+func debugSourceLine(fileName string, line int) string {
+	lines, found := debugSources[fileName]
+	if !found {
+		if src, err := ioutil.ReadFile(fileName); err == nil {
+			lines = strings.Split(string(src), "\n")
+		}
+		debugSources[fileName] = lines
+	}
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return strings.TrimSpace(lines[line-1])
+}
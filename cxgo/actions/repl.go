@@ -0,0 +1,32 @@
+package actions
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// SaveCommand runs `line` if it's the REPL command
+//
+//	:save file.cx  writes the source code of the program, as it is in memory
+//
+// and reports whether it was. Functions changed by affordances or by the
+// meta natives are saved with their changes
+func SaveCommand(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || fields[0] != ":save" {
+		return false
+	}
+	if len(fields) != 2 {
+		fmt.Println("usage: :save file.cx")
+		return true
+	}
+
+	if err := ioutil.WriteFile(fields[1], []byte(PRGRM.ToSource()), 0644); err != nil {
+		fmt.Println(err)
+		return true
+	}
+	fmt.Printf("Saved the program to %s\n", fields[1])
+
+	return true
+}
@@ -19,6 +19,12 @@
 	i64 int64
 	f32 float32
 	f64 float64
+	i8 int8
+	i16 int16
+	ui8 uint8
+	ui16 uint16
+	ui32 uint32
+	ui64 uint64
 	tok string
 	bool bool
 	string string
@@ -46,8 +52,14 @@
 %token  <i64>           LONG_LITERAL
 %token  <f32>           FLOAT_LITERAL
 %token  <f64>           DOUBLE_LITERAL
+%token  <i8>            SHORTBYTE_LITERAL
+%token  <i16>           SHORT_LITERAL
+%token  <ui8>           UNSIGNED_BYTE_LITERAL
+%token  <ui16>          UNSIGNED_SHORT_LITERAL
+%token  <ui32>          UNSIGNED_INT_LITERAL
+%token  <ui64>          UNSIGNED_LONG_LITERAL
 %token  <tok>           FUNC OP LPAREN RPAREN LBRACE RBRACE LBRACK RBRACK IDENTIFIER
-                        VAR COMMA PERIOD COMMENT STRING_LITERAL PACKAGE IF ELSE FOR TYPSTRUCT STRUCT
+                        VAR COMMA PERIOD ELLIPSIS COMMENT STRING_LITERAL PACKAGE IF ELSE FOR TYPSTRUCT STRUCT
                         SEMICOLON NEWLINE
                         ASSIGN CASSIGN IMPORT RETURN GOTO GT_OP LT_OP GTEQ_OP LTEQ_OP EQUAL COLON NEW
                         EQUALWORD GTHANWORD LTHANWORD
@@ -92,6 +104,7 @@
 %type   <i>             type_specifier
 %type   <argument>      declaration_specifiers
 %type   <argument>      declarator
+%type   <argument>      struct_header
 %type   <argument>      direct_declarator
 %type   <argument>      parameter_declaration
 %type   <arguments>     parameter_type_list
@@ -267,10 +280,17 @@ global_declaration:
                 }
                 ;
 
+struct_header:
+                TYPE IDENTIFIER STRUCT
+                {
+			$$ = MakeArgument($2, CurrentFile, LineNo)
+                }
+                ;
+
 struct_declaration:
-                TYPE IDENTIFIER STRUCT struct_fields
+                struct_header struct_fields
                 {
-			DeclareStruct($2, $4)
+			DeclareStruct($1, $2)
                 }
                 ;
 
@@ -369,6 +389,10 @@ parameter_declaration:
 			// $2.Package = $1.Package
 			$$ = $2
                 }
+        |       declarator ELLIPSIS declaration_specifiers
+                {
+			$$ = VariadicParameter($1, $3)
+                }
                 ;
 
 declarator:     direct_declarator
@@ -743,6 +767,30 @@ primary_expression:
                 {
 			$$ = WritePrimary(TYPE_I64, encoder.Serialize($1), false)
                 }
+        |       SHORTBYTE_LITERAL
+                {
+			$$ = WritePrimary(TYPE_I8, encoder.Serialize($1), false)
+                }
+        |       SHORT_LITERAL
+                {
+			$$ = WritePrimary(TYPE_I16, encoder.Serialize($1), false)
+                }
+        |       UNSIGNED_BYTE_LITERAL
+                {
+			$$ = WritePrimary(TYPE_UI8, encoder.Serialize($1), false)
+                }
+        |       UNSIGNED_SHORT_LITERAL
+                {
+			$$ = WritePrimary(TYPE_UI16, encoder.Serialize($1), false)
+                }
+        |       UNSIGNED_INT_LITERAL
+                {
+			$$ = WritePrimary(TYPE_UI32, encoder.Serialize($1), false)
+                }
+        |       UNSIGNED_LONG_LITERAL
+                {
+			$$ = WritePrimary(TYPE_UI64, encoder.Serialize($1), false)
+                }
         |       LPAREN expression RPAREN
                 { $$ = $2 }
         |       array_literal_expression
@@ -780,6 +828,11 @@ postfix_expression:
                 {
 			$$ = PostfixExpressionFunCall($1, $3)
                 }
+	|       postfix_expression LPAREN argument_expression_list ELLIPSIS RPAREN
+                {
+			$3[len($3) - 1].IsSpread = true
+			$$ = PostfixExpressionFunCall($1, $3)
+                }
 	|       postfix_expression INC_OP
                 {
 			$$ = PostfixExpressionIncDec($1, true)
@@ -793,6 +846,11 @@ postfix_expression:
                 {
 			PostfixExpressionField($1, $3)
                 }
+        |       postfix_expression PERIOD type_specifier
+                {
+			// a package's function named after a type, as in read.i32
+			PostfixExpressionField($1, TypeNames[$3])
+                }
         // |       postfix_expression PERIOD IDENTIFIER LBRACE struct_literal_fields RBRACE
         //         {
 	// 		$$ = PrimaryStructLiteralExternal($1[0].Outputs[0].Name, $3, $5)
@@ -1000,8 +1058,7 @@ assignment_operator:
 expression:     assignment_expression
 	|       expression COMMA assignment_expression
                 {
-			$3[len($3) - 1].Outputs = append($1[len($1) - 1].Outputs, $3[len($3) - 1].Outputs...)
-			$$ = append($1, $3...)
+			$$ = MultipleAssignment($1, $3)
                 }
                 ;
 
@@ -1119,6 +1176,7 @@ expression_statement:
 			if $1[len($1) - 1].Operator == nil {
 				$$ = nil
 			} else {
+				CheckAssignmentCount($1)
 				$$ = $1
 			}
 			// $$ = $1
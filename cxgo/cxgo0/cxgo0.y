@@ -51,7 +51,7 @@
 
 	func PreFunctionDeclaration (fn *CXFunction, inputs []*CXArgument, outputs []*CXArgument, exprs []*CXExpression) {
 		// adding inputs, outputs
-		for _, inp := range inputs {
+		for _, inp := range VariadicInputs(inputs) {
 			fn.AddInput(inp)
 		}
 		for _, out := range outputs {
@@ -72,6 +72,12 @@
 	i64 int64
 	f32 float32
 	f64 float64
+	i8 int8
+	i16 int16
+	ui8 uint8
+	ui16 uint16
+	ui32 uint32
+	ui64 uint64
 	tok string
 	bool bool
 	string string
@@ -98,8 +104,14 @@
 %token  <i64>           LONG_LITERAL
 %token  <f32>           FLOAT_LITERAL
 %token  <f64>           DOUBLE_LITERAL
+%token  <i8>            SHORTBYTE_LITERAL
+%token  <i16>           SHORT_LITERAL
+%token  <ui8>           UNSIGNED_BYTE_LITERAL
+%token  <ui16>          UNSIGNED_SHORT_LITERAL
+%token  <ui32>          UNSIGNED_INT_LITERAL
+%token  <ui64>          UNSIGNED_LONG_LITERAL
 %token  <tok>           FUNC OP LPAREN RPAREN LBRACE RBRACE LBRACK RBRACK IDENTIFIER
-                        VAR COMMA PERIOD COMMENT STRING_LITERAL PACKAGE IF ELSE FOR TYPSTRUCT STRUCT
+                        VAR COMMA PERIOD ELLIPSIS COMMENT STRING_LITERAL PACKAGE IF ELSE FOR TYPSTRUCT STRUCT
                         SEMICOLON NEWLINE
                         ASSIGN CASSIGN IMPORT RETURN GOTO GT_OP LT_OP GTEQ_OP LTEQ_OP EQUAL COLON NEW
                         EQUALWORD GTHANWORD LTHANWORD
@@ -266,6 +278,8 @@ import_declaration:
 				if _, err := pkg.GetImport($2); err != nil {
 					if imp, err := PRGRM0.GetPackage($2); err == nil {
 						pkg.AddImport(imp)
+					} else if imp, err := PRGRM0.AddCorePackage($2); err == nil {
+						pkg.AddImport(imp)
 					} else {
 						panic(err)
 					}
@@ -361,6 +375,10 @@ parameter_declaration:
 			// $2.MemoryType = MEM_STACK
 			$$ = $2
                 }
+        |       declarator ELLIPSIS declaration_specifiers
+                {
+			$$ = VariadicParameter($1, $3)
+                }
                 ;
 
 identifier_list:
@@ -472,6 +490,9 @@ declaration_specifiers:
 				if strct, err := PRGRM0.GetStruct($1, pkg.Name); err == nil {
 					arg := MakeArgument("", CurrentFile, LineNo)
 					arg.AddType(TypeNames[TYPE_CUSTOM])
+					// struct fields get their containing struct as
+					// CustomType, so their own type is kept by name
+					arg.Typ = $1
 					arg.DeclarationSpecifiers = append(arg.DeclarationSpecifiers, DECL_STRUCT)
 					arg.CustomType = strct
 					arg.Size = strct.Size
@@ -493,6 +514,7 @@ declaration_specifiers:
 					if strct, err := PRGRM0.GetStruct($3, imp.Name); err == nil {
 						arg := MakeArgument("", CurrentFile, LineNo)
 						arg.AddType(TypeNames[TYPE_CUSTOM])
+						arg.Typ = $3
 						arg.CustomType = strct
 						arg.Size = strct.Size
 						arg.TotalSize = strct.Size
@@ -640,6 +662,12 @@ primary_expression:
         |       FLOAT_LITERAL
         |       DOUBLE_LITERAL
         |       LONG_LITERAL
+        |       SHORTBYTE_LITERAL
+        |       SHORT_LITERAL
+        |       UNSIGNED_BYTE_LITERAL
+        |       UNSIGNED_SHORT_LITERAL
+        |       UNSIGNED_INT_LITERAL
+        |       UNSIGNED_LONG_LITERAL
         |       LPAREN expression RPAREN
         |       array_literal_expression
         |       slice_literal_expression
@@ -655,9 +683,11 @@ postfix_expression:
         |       type_specifier PERIOD after_period
 	|       postfix_expression LPAREN RPAREN
 	|       postfix_expression LPAREN argument_expression_list RPAREN
+	|       postfix_expression LPAREN argument_expression_list ELLIPSIS RPAREN
 	|       postfix_expression INC_OP
         |       postfix_expression DEC_OP
         |       postfix_expression PERIOD IDENTIFIER
+        |       postfix_expression PERIOD type_specifier
         /* |       postfix_expression PERIOD IDENTIFIER LBRACE struct_literal_fields RBRACE */
                 ;
 
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/skycoin/cx/cx"
+)
+
+// exampleFiles returns the programs of examples/ and tests/
+func exampleFiles(t *testing.T) (fileNames []string) {
+	for _, dir := range []string{"../examples", "../tests"} {
+		filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+			if err == nil && !fi.IsDir() && strings.HasSuffix(path, ".cx") {
+				fileNames = append(fileNames, path)
+			}
+			return err
+		})
+	}
+	if len(fileNames) == 0 {
+		t.Fatal("no programs found in examples/ and tests/")
+	}
+	return fileNames
+}
+
+// TestFormatExamples formats every program of examples/ and tests/ without
+// syntax errors, which must compile to the same program, and be formatted
+// already once formatted
+func TestFormatExamples(t *testing.T) {
+	for _, fileName := range exampleFiles(t) {
+		src, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, errs := fmtProgram(fileName, src); len(errs) > 0 {
+			continue
+		}
+
+		formatted, err := formatSource(fileName, src)
+		if err != nil {
+			t.Errorf("%v", err)
+			continue
+		}
+		again, err := formatSource(fileName, formatted)
+		if err != nil || string(again) != string(formatted) {
+			t.Errorf("%s: formatting isn't idempotent: %v\n%s", fileName, err, DiffSource(fileName, formatted, again))
+		}
+	}
+}
+
+// TestToSourceExamples decompiles the programs of examples/ and tests/ that
+// compile, and the program made of the files of tests/. The source code must
+// compile again, to a program that decompiles to the same source code
+func TestToSourceExamples(t *testing.T) {
+	programs := make(map[string][]string)
+	var testsDir []string
+	for _, fileName := range exampleFiles(t) {
+		programs[fileName] = []string{fileName}
+		if filepath.Dir(fileName) == filepath.Clean("../tests") && !strings.HasSuffix(fileName, "_test.cx") {
+			testsDir = append(testsDir, fileName)
+		}
+	}
+	programs["../tests"] = testsDir
+
+	for name, fileNames := range programs {
+		var sources []string
+		for _, fileName := range fileNames {
+			src, err := ioutil.ReadFile(fileName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sources = append(sources, string(src))
+		}
+
+		prgrm, errs := compileQuietly(fileNames, sources)
+		if len(errs) > 0 {
+			continue
+		}
+		want := prgrm.ToSource()
+
+		prgrm, errs = compileQuietly([]string{name + ".decompiled.cx"}, []string{want})
+		if len(errs) > 0 {
+			t.Errorf("%s: the decompiled program doesn't compile: %v\n%s", name, errs[0], want)
+			continue
+		}
+		if got := prgrm.ToSource(); got != want {
+			t.Errorf("%s: the decompiled program compiles to another program\n%s", name, DiffSource(name, []byte(want), []byte(got)))
+		}
+	}
+}
+
+// compileQuietly compiles `sources` as compileCollectingErrors does, but
+// without printing what the parser prints
+func compileQuietly(fileNames []string, sources []string) (*CXProgram, []CompileError) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return compileCollectingErrors(fileNames, sources)
+	}
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = stdout
+		devNull.Close()
+	}()
+	return compileCollectingErrors(fileNames, sources)
+}